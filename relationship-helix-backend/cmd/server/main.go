@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -18,6 +19,10 @@ import (
 	"relationship-helix/internal/api/routes"
 	"relationship-helix/internal/config"
 	"relationship-helix/internal/db"
+	"relationship-helix/internal/jobs"
+	"relationship-helix/internal/mailer"
+	"relationship-helix/internal/notify"
+	"relationship-helix/internal/push"
 )
 
 func main() {
@@ -28,6 +33,9 @@ func main() {
 
 	// Inițializează configurația
 	cfg := config.LoadConfig()
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Configurație invalidă: %v", err)
+	}
 
 	// Inițializează conexiunea la baza de date
 	database, err := db.InitDB(cfg.DatabaseURL)
@@ -79,6 +87,29 @@ func main() {
 	// Setează rutele API
 	routes.SetupRoutes(app, database, cfg)
 
+	// Notificările push pentru membrii care nu sunt conectați prin WebSocket
+	var pushNotifier notify.Notifier
+	pushService, err := push.NewService(database, cfg)
+	if err != nil {
+		log.Printf("Notificările push sunt dezactivate: %v\n", err)
+	}
+	if pushService != nil {
+		handlers.SetOfflineDelivery(pushService.Deliver)
+		pushNotifier = pushService
+	}
+
+	// Pornește job-urile de fundal
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go jobs.NewMilestoneJob(database, cfg, handlers.SendToUser).Run(ctx)
+	go jobs.NewRevealJob(database, handlers.SendToUser).Run(ctx)
+	go jobs.NewPauseJob(database, handlers.SendToUser).Run(ctx)
+	go jobs.NewReminderJob(database, cfg, notify.FromConfig(cfg, pushNotifier), handlers.SendToUser).Run(ctx)
+	go jobs.NewWebhookJob(database, cfg).Run(ctx)
+	go jobs.NewEventReminderJob(database, cfg, handlers.SendToUser).Run(ctx)
+	go jobs.NewDecayJob(database, handlers.SendToUser).Run(ctx)
+	go jobs.NewDigestJob(database, cfg, mailer.FromConfig(cfg)).Run(ctx)
+
 	// Determină portul serverului
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -29,6 +29,7 @@ type RegisterRequest struct {
 	Username string `json:"username" validate:"required,min=3,max=50"`
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=6"`
+	Timezone string `json:"timezone"`
 }
 
 // Register înregistrează un nou utilizator
@@ -50,6 +51,14 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Validează fusul orar (opțional)
+	if req.Timezone != "" && !utils.IsValidTimezone(req.Timezone) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Fus orar invalid",
+		})
+	}
+	
 	// Verifică dacă email-ul există deja
 	var exists bool
 	err := h.DB.QueryRow("SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", req.Email).Scan(&exists)
@@ -79,11 +88,11 @@ func (h *AuthHandler) Register(c *fiber.Ctx) error {
 	// Creează utilizatorul
 	var user models.User
 	err = h.DB.QueryRow(
-		`INSERT INTO users (username, email, password, created_at, updated_at) 
-         VALUES ($1, $2, $3, NOW(), NOW()) 
-         RETURNING id, username, email, created_at, updated_at`,
-		req.Username, req.Email, hashedPassword,
-	).Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+		`INSERT INTO users (username, email, password, timezone, created_at, updated_at) 
+         VALUES ($1, $2, $3, NULLIF($4, ''), NOW(), NOW()) 
+         RETURNING id, username, email, COALESCE(timezone, ''), created_at, updated_at`,
+		req.Username, req.Email, hashedPassword, req.Timezone,
+	).Scan(&user.ID, &user.Username, &user.Email, &user.Timezone, &user.CreatedAt, &user.UpdatedAt)
 	
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -136,11 +145,11 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 	// Caută utilizatorul după email
 	var user models.User
 	err := h.DB.QueryRow(
-		`SELECT id, username, email, password, created_at, updated_at 
+		`SELECT id, username, email, password, COALESCE(timezone, ''), created_at, updated_at 
          FROM users 
          WHERE email = $1`,
 		req.Email,
-	).Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.Username, &user.Email, &user.Password, &user.Timezone, &user.CreatedAt, &user.UpdatedAt)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -194,11 +203,11 @@ func (h *AuthHandler) GetMe(c *fiber.Ctx) error {
 	// Caută utilizatorul în baza de date
 	var user models.User
 	err := h.DB.QueryRow(
-		`SELECT id, username, email, created_at, updated_at 
+		`SELECT id, username, email, COALESCE(timezone, ''), created_at, updated_at 
          FROM users 
          WHERE id = $1`,
 		userID,
-	).Scan(&user.ID, &user.Username, &user.Email, &user.CreatedAt, &user.UpdatedAt)
+	).Scan(&user.ID, &user.Username, &user.Email, &user.Timezone, &user.CreatedAt, &user.UpdatedAt)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/axes"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+)
+
+// UpdateAxesRequest reprezintă noua configurație a dimensiunilor apropierii
+type UpdateAxesRequest struct {
+	Axes []axes.Axis `json:"axes"`
+}
+
+// GetAxes returnează dimensiunile relației și valorile curente ale partenerilor
+func (h *RelationshipHandler) GetAxes(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	state, err := h.loadAxesState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea dimensiunilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(state)
+}
+
+// UpdateAxes înlocuiește configurația dimensiunilor (chei, etichete, ponderi și intervale)
+func (h *RelationshipHandler) UpdateAxes(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdateAxesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	if err := axes.Validate(req.Axes); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Configurație invalidă: " + err.Error(),
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// Istoricul pe dimensiuni se păstrează chiar dacă o dimensiune este eliminată
+	if _, err := tx.Exec(`DELETE FROM relationship_axes WHERE relationship_id = $1`, relationship.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea dimensiunilor",
+		})
+	}
+
+	for i, a := range req.Axes {
+		_, err := tx.Exec(
+			`INSERT INTO relationship_axes (relationship_id, key, label, weight, min_value, max_value, sort_order, created_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())`,
+			relationship.ID, a.Key, a.Label, a.Weight, a.Min, a.Max, i,
+		)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la salvarea dimensiunilor",
+			})
+		}
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Anunță ceilalți membri despre noua configurație
+	SendToMembers(relationship, userID, "axes_changed", fiber.Map{
+		"axes": req.Axes,
+	})
+
+	state, err := h.loadAxesState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea dimensiunilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(state)
+}
+
+// loadAxesState încarcă configurația și valorile pe dimensiuni ale tuturor membrilor;
+// valorile membrilor aflați în pauză sunt ascunse
+func (h *RelationshipHandler) loadAxesState(relationship *models.Relationship, userID uint) (fiber.Map, error) {
+	list, err := axes.Load(h.DB, relationship.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	memberValues := make(map[uint]map[string]int)
+	for _, memberID := range relationship.MemberIDs() {
+		if memberID != userID {
+			memberPause, err := pause.Active(h.DB, relationship.ID, memberID)
+			if err != nil {
+				return nil, err
+			}
+			if memberPause != nil {
+				memberValues[memberID] = nil
+				continue
+			}
+		}
+
+		values, err := axes.LoadValues(h.DB, relationship.ID, memberID)
+		if err != nil {
+			return nil, err
+		}
+		memberValues[memberID] = axes.Merge(list, values, nil)
+	}
+
+	state := fiber.Map{
+		"axes":       list,
+		"userAxes":   memberValues[userID],
+		"memberAxes": memberValues,
+	}
+
+	// Câmpul pentru cupluri rămâne compatibil cu clienții existenți
+	if relationship.IsCouple() {
+		state["partnerAxes"] = memberValues[relationship.PartnerID(userID)]
+	}
+
+	return state, nil
+}
+
+// resolveAxes validează valorile pe dimensiuni din cerere și calculează poziția compusă.
+// Dimensiunile lipsă din cerere își păstrează valoarea curentă. Returnează mesajul de eroare
+// pentru client sau un șir gol dacă cererea este validă.
+func (h *RelationshipHandler) resolveAxes(relationshipID, userID uint, req *UpdatePositionRequest) (string, error) {
+	if len(req.Axes) == 0 {
+		return "", nil
+	}
+
+	list, err := axes.Load(h.DB, relationshipID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := axes.CheckValues(list, req.Axes); err != nil {
+		return "Valori invalide pentru dimensiuni: " + err.Error(), nil
+	}
+
+	current, err := axes.LoadValues(h.DB, relationshipID, userID)
+	if err != nil {
+		return "", err
+	}
+
+	req.Axes = axes.Merge(list, current, req.Axes)
+	position, err := axes.Composite(list, req.Axes)
+	if err != nil {
+		return "", err
+	}
+
+	// Câmpul position rămâne compatibil cu clienții vechi: este poziția compusă
+	req.Position = position
+	return "", nil
+}
+
+// checkAxisParam verifică parametrul axis din query (gol = poziția compusă).
+// Returnează mesajul de eroare pentru client sau un șir gol dacă dimensiunea există.
+func (h *RelationshipHandler) checkAxisParam(relationshipID uint, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+
+	list, err := axes.Load(h.DB, relationshipID)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := axes.Find(list, key); !ok {
+		return "Dimensiune necunoscută: " + key, nil
+	}
+	return "", nil
+}
+
+// axisParamError transformă rezultatul lui checkAxisParam în răspunsul HTTP potrivit
+func axisParamError(c *fiber.Ctx, message string, err error) error {
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea dimensiunilor",
+		})
+	}
+
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   true,
+		"message": message,
+	})
+}
@@ -0,0 +1,763 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/calendar"
+	"relationship-helix/internal/milestones"
+	"relationship-helix/internal/models"
+)
+
+const (
+	// Limitele câmpurilor unui eveniment
+	maxEventTitleLength       = 100
+	maxEventDescriptionLength = 2000
+	maxEventLocationLength    = 255
+	maxEventDuration          = 30 * 24 * time.Hour
+
+	// Mementouri: cel mult 5 pe eveniment, până la 4 săptămâni înainte
+	maxEventReminders       = 5
+	maxEventReminderMinutes = 4 * 7 * 24 * 60
+
+	// Intervalul implicit și maxim pentru care se calculează aparițiile
+	defaultEventRangeDays = 30
+	maxEventRangeDays     = 366
+	maxEventOccurrences   = 2000
+
+	// Domeniul folosit în UID-urile evenimentelor din fluxul iCalendar
+	calendarUIDDomain = "relationship-helix"
+)
+
+// Acțiunile trimise în evenimentul calendar_event
+const (
+	calendarEventCreated = "created"
+	calendarEventUpdated = "updated"
+	calendarEventDeleted = "deleted"
+)
+
+// EventRequest reprezintă cererea de creare sau înlocuire a unui eveniment din calendar
+type EventRequest struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Location    *string `json:"location"`
+	StartsAt    string  `json:"startsAt"` // RFC 3339 sau, pentru allDay, YYYY-MM-DD
+	EndsAt      string  `json:"endsAt"`   // Opțional: implicit o oră, respectiv aceeași zi (inclusiv pentru allDay)
+	AllDay      bool    `json:"allDay"`
+	Timezone    string  `json:"timezone"`   // Opțional: implicit fusul orar al utilizatorului
+	Recurrence  string  `json:"recurrence"` // Regulă RRULE (opțională), ex. FREQ=WEEKLY;BYDAY=FR
+	Reminders   []int   `json:"reminders"`  // Minute înainte de început
+}
+
+// eventColumns sunt coloanele citite de scanEvent
+const eventColumns = `id, relationship_id, created_by, title, description, location, starts_at, ends_at, all_day, timezone, recurrence, reminders, created_at, updated_at`
+
+// scanEvent citește un eveniment din calendar
+func scanEvent(row interface{ Scan(...interface{}) error }) (*models.RelationshipEvent, error) {
+	var e models.RelationshipEvent
+	var createdBy sql.NullInt64
+	var reminders pq.Int64Array
+	err := row.Scan(&e.ID, &e.RelationshipID, &createdBy, &e.Title, &e.Description, &e.Location, &e.StartsAt, &e.EndsAt,
+		&e.AllDay, &e.Timezone, &e.Recurrence, &reminders, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	e.CreatedBy = nullableID(createdBy)
+	e.Reminders = make([]int, len(reminders))
+	for i, m := range reminders {
+		e.Reminders[i] = int(m)
+	}
+	return &e, nil
+}
+
+// GetEvents returnează evenimentele din calendarul relației și aparițiile lor în intervalul
+// ?from= - ?to= (implicit următoarele 30 de zile)
+func (h *RelationshipHandler) GetEvents(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	loc := h.userLocation(userID)
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if value := c.Query("from"); value != "" {
+		t, err := parseEventTime(value, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Parametrul from este invalid",
+			})
+		}
+		from = t
+	}
+
+	to := from.AddDate(0, 0, defaultEventRangeDays)
+	if value := c.Query("to"); value != "" {
+		t, err := parseEventTime(value, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Parametrul to este invalid",
+			})
+		}
+		to = t
+	}
+
+	if !to.After(from) || to.Sub(from) > maxEventRangeDays*24*time.Hour {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Intervalul trebuie să fie de cel mult " + strconv.Itoa(maxEventRangeDays) + " de zile",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	events, err := h.loadEvents(relationship.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea evenimentelor",
+		})
+	}
+
+	occurrences := []models.EventOccurrence{}
+	for _, e := range events {
+		occurrences = append(occurrences, h.eventOccurrences(e, from, to, maxEventOccurrences)...)
+	}
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartsAt.Before(occurrences[j].StartsAt)
+	})
+	if len(occurrences) > maxEventOccurrences {
+		occurrences = occurrences[:maxEventOccurrences]
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"events":      events,
+		"occurrences": occurrences,
+		"from":        from,
+		"to":          to,
+	})
+}
+
+// GetEvent returnează un eveniment din calendar
+func (h *RelationshipHandler) GetEvent(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	event, err := h.findEvent(c, relationship.ID)
+	if err != nil {
+		return eventLookupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"event": event,
+	})
+}
+
+// CreateEvent adaugă un eveniment în calendarul relației
+func (h *RelationshipHandler) CreateEvent(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req EventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	event, message := h.normalizeEvent(&req, userID)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	created, err := scanEvent(h.DB.QueryRow(
+		`INSERT INTO relationship_events (relationship_id, created_by, title, description, location, starts_at, ends_at, all_day, timezone, recurrence, reminders, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW(), NOW())
+         RETURNING `+eventColumns,
+		relationship.ID, userID, event.Title, event.Description, event.Location, event.StartsAt, event.EndsAt,
+		event.AllDay, event.Timezone, event.Recurrence, pq.Array(event.Reminders),
+	))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea evenimentului",
+		})
+	}
+
+	SendToMembers(relationship, userID, "calendar_event", fiber.Map{
+		"action": calendarEventCreated,
+		"userId": userID,
+		"event":  created,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"event": created,
+	})
+}
+
+// UpdateEvent înlocuiește un eveniment din calendar; orice membru al relației îl poate modifica
+func (h *RelationshipHandler) UpdateEvent(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req EventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	event, message := h.normalizeEvent(&req, userID)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	existing, err := h.findEvent(c, relationship.ID)
+	if err != nil {
+		return eventLookupError(c, err)
+	}
+
+	updated, err := scanEvent(h.DB.QueryRow(
+		`UPDATE relationship_events
+         SET title = $2, description = $3, location = $4, starts_at = $5, ends_at = $6, all_day = $7,
+             timezone = $8, recurrence = $9, reminders = $10, updated_at = NOW()
+         WHERE id = $1
+         RETURNING `+eventColumns,
+		existing.ID, event.Title, event.Description, event.Location, event.StartsAt, event.EndsAt,
+		event.AllDay, event.Timezone, event.Recurrence, pq.Array(event.Reminders),
+	))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea evenimentului",
+		})
+	}
+
+	SendToMembers(relationship, userID, "calendar_event", fiber.Map{
+		"action": calendarEventUpdated,
+		"userId": userID,
+		"event":  updated,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"event": updated,
+	})
+}
+
+// DeleteEvent șterge un eveniment din calendar
+func (h *RelationshipHandler) DeleteEvent(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	event, err := h.findEvent(c, relationship.ID)
+	if err != nil {
+		return eventLookupError(c, err)
+	}
+
+	if _, err := h.DB.Exec(`DELETE FROM relationship_events WHERE id = $1`, event.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea evenimentului",
+		})
+	}
+
+	SendToMembers(relationship, userID, "calendar_event", fiber.Map{
+		"action": calendarEventDeleted,
+		"userId": userID,
+		"event":  event,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// GetCalendarFeed creează la prima cerere link-ul privat al fluxului iCalendar al utilizatorului pentru relație.
+// Link-ul este afișat o singură dată; ulterior se returnează doar data creării, iar un link nou se obține prin rotire.
+func (h *RelationshipHandler) GetCalendarFeed(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	var createdAt time.Time
+	err = h.DB.QueryRow(
+		`SELECT created_at FROM calendar_feeds WHERE user_id = $1 AND relationship_id = $2`,
+		userID, relationship.ID,
+	).Scan(&createdAt)
+
+	if err == sql.ErrNoRows {
+		return h.saveCalendarFeed(c, userID, relationship.ID, fiber.StatusCreated)
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea fluxului de calendar",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"active":    true,
+		"createdAt": createdAt,
+	})
+}
+
+// RotateCalendarFeed înlocuiește link-ul fluxului iCalendar; link-ul vechi nu mai funcționează
+func (h *RelationshipHandler) RotateCalendarFeed(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	return h.saveCalendarFeed(c, userID, relationship.ID, fiber.StatusOK)
+}
+
+// DeleteCalendarFeed dezactivează link-ul fluxului iCalendar
+func (h *RelationshipHandler) DeleteCalendarFeed(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	_, err = h.DB.Exec(
+		`DELETE FROM calendar_feeds WHERE user_id = $1 AND relationship_id = $2`,
+		userID, relationship.ID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea fluxului de calendar",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// ServeCalendarFeed returnează fluxul iCalendar (public, protejat prin token-ul din URL): evenimentele
+// relației și aniversarea datei de început. Link-ul încetează să funcționeze când utilizatorul părăsește relația.
+func (h *RelationshipHandler) ServeCalendarFeed(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("file"), ".ics")
+
+	var userID, relationshipID uint
+	err := h.DB.QueryRow(
+		`SELECT f.user_id, f.relationship_id
+         FROM calendar_feeds f
+         JOIN relationship_members m ON m.relationship_id = f.relationship_id AND m.user_id = f.user_id
+         WHERE f.token_hash = $1`,
+		shareTokenHash(token),
+	).Scan(&userID, &relationshipID)
+
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Calendarul nu a fost găsit",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea calendarului",
+		})
+	}
+
+	relationship, err := loadRelationship(h.DB, relationshipID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea relației",
+		})
+	}
+
+	events, err := h.loadEvents(relationshipID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea evenimentelor",
+		})
+	}
+
+	name := "Relationship Helix"
+	if relationship.Name != nil && *relationship.Name != "" {
+		name = *relationship.Name
+	}
+
+	// Aniversarea se repetă anual, din primul an după data de început, în fusul orar al utilizatorului
+	start := milestones.Date(relationship.StartDate, h.userLocation(userID)).AddDate(1, 0, 0)
+	cal := &calendar.Calendar{
+		Name: name,
+		Events: []calendar.Event{{
+			UID:     "anniversary-" + strconv.FormatUint(uint64(relationship.ID), 10) + "@" + calendarUIDDomain,
+			Summary: "Aniversarea relației",
+			Start:   start,
+			End:     start.AddDate(0, 0, 1),
+			AllDay:  true,
+			Rule:    "FREQ=YEARLY",
+		}},
+	}
+
+	for _, e := range events {
+		cal.Events = append(cal.Events, h.calendarEvent(e))
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea calendarului",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="relationship.ics"`)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=900")
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
+}
+
+// calendarEvent transformă un eveniment al relației în formatul fluxului iCalendar
+func (h *RelationshipHandler) calendarEvent(e *models.RelationshipEvent) calendar.Event {
+	loc := h.Config.Location(e.Timezone)
+	event := calendar.Event{
+		UID:      "event-" + strconv.FormatUint(uint64(e.ID), 10) + "@" + calendarUIDDomain,
+		Summary:  e.Title,
+		Start:    e.StartsAt.In(loc),
+		End:      e.EndsAt.In(loc),
+		AllDay:   e.AllDay,
+		Alarms:   e.Reminders,
+		Created:  e.CreatedAt,
+		Modified: e.UpdatedAt,
+	}
+	if e.Description != nil {
+		event.Description = *e.Description
+	}
+	if e.Location != nil {
+		event.Location = *e.Location
+	}
+	if e.Recurrence != nil {
+		event.Rule = *e.Recurrence
+	}
+	return event
+}
+
+// saveCalendarFeed generează un token nou pentru fluxul iCalendar al utilizatorului; se salvează doar amprenta lui
+func (h *RelationshipHandler) saveCalendarFeed(c *fiber.Ctx, userID, relationshipID uint, status int) error {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea link-ului",
+		})
+	}
+	token := hex.EncodeToString(buf)
+
+	var createdAt time.Time
+	err := h.DB.QueryRow(
+		`INSERT INTO calendar_feeds (user_id, relationship_id, token_hash, created_at)
+         VALUES ($1, $2, $3, NOW())
+         ON CONFLICT (user_id, relationship_id) DO UPDATE SET token_hash = $3, created_at = NOW()
+         RETURNING created_at`,
+		userID, relationshipID, shareTokenHash(token),
+	).Scan(&createdAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea fluxului de calendar",
+		})
+	}
+
+	url := h.Config.PublicURL + "/api/calendar/" + token + ".ics"
+	return c.Status(status).JSON(fiber.Map{
+		"active":    true,
+		"createdAt": createdAt,
+		"url":       url,
+		"webcalUrl": "webcal://" + strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://"),
+	})
+}
+
+// eventOccurrences returnează aparițiile evenimentului care se suprapun cu intervalul [from, to]
+func (h *RelationshipHandler) eventOccurrences(e *models.RelationshipEvent, from, to time.Time, limit int) []models.EventOccurrence {
+	rule := ""
+	if e.Recurrence != nil {
+		rule = *e.Recurrence
+	}
+
+	// Regula se aplică în fusul orar al evenimentului, ca ora locală să nu se schimbe odată cu ora de vară
+	duration := e.EndsAt.Sub(e.StartsAt)
+	starts, err := calendar.Expand(e.StartsAt.In(h.Config.Location(e.Timezone)), duration, rule, from, to, limit)
+	if err != nil {
+		return nil
+	}
+
+	result := make([]models.EventOccurrence, len(starts))
+	for i, s := range starts {
+		result[i] = models.EventOccurrence{
+			EventID:  e.ID,
+			Title:    e.Title,
+			StartsAt: s,
+			EndsAt:   s.Add(duration),
+			AllDay:   e.AllDay,
+		}
+	}
+	return result
+}
+
+// normalizeEvent validează cererea și construiește evenimentul; returnează un mesaj de eroare dacă nu este validă
+func (h *RelationshipHandler) normalizeEvent(req *EventRequest, userID uint) (*models.RelationshipEvent, string) {
+	event := &models.RelationshipEvent{
+		Title:       strings.TrimSpace(req.Title),
+		Description: trimOptional(req.Description),
+		Location:    trimOptional(req.Location),
+		AllDay:      req.AllDay,
+	}
+
+	if event.Title == "" || utf8.RuneCountInString(event.Title) > maxEventTitleLength {
+		return nil, "Titlul trebuie să aibă între 1 și " + strconv.Itoa(maxEventTitleLength) + " de caractere"
+	}
+	if event.Description != nil && utf8.RuneCountInString(*event.Description) > maxEventDescriptionLength {
+		return nil, "Descrierea poate avea cel mult " + strconv.Itoa(maxEventDescriptionLength) + " de caractere"
+	}
+	if event.Location != nil && utf8.RuneCountInString(*event.Location) > maxEventLocationLength {
+		return nil, "Locația poate avea cel mult " + strconv.Itoa(maxEventLocationLength) + " de caractere"
+	}
+
+	loc := h.userLocation(userID)
+	if req.Timezone != "" {
+		l, err := time.LoadLocation(req.Timezone)
+		if err != nil {
+			return nil, "Fus orar invalid"
+		}
+		loc = l
+	}
+	event.Timezone = loc.String()
+
+	startsAt, err := parseEventTime(req.StartsAt, loc)
+	if err != nil {
+		return nil, "Data de început este invalidă"
+	}
+
+	if req.AllDay {
+		// Un eveniment de o zi întreagă ține de la miezul nopții primei zile până la miezul nopții de după ultima
+		startsAt = time.Date(startsAt.Year(), startsAt.Month(), startsAt.Day(), 0, 0, 0, 0, loc)
+		lastDay := startsAt
+		if req.EndsAt != "" {
+			endsAt, err := parseEventTime(req.EndsAt, loc)
+			if err != nil {
+				return nil, "Data de sfârșit este invalidă"
+			}
+			lastDay = time.Date(endsAt.Year(), endsAt.Month(), endsAt.Day(), 0, 0, 0, 0, loc)
+		}
+		event.StartsAt = startsAt
+		event.EndsAt = lastDay.AddDate(0, 0, 1)
+	} else {
+		event.StartsAt = startsAt
+		event.EndsAt = startsAt.Add(time.Hour)
+		if req.EndsAt != "" {
+			endsAt, err := parseEventTime(req.EndsAt, loc)
+			if err != nil {
+				return nil, "Data de sfârșit este invalidă"
+			}
+			event.EndsAt = endsAt
+		}
+	}
+
+	if !event.EndsAt.After(event.StartsAt) {
+		return nil, "Evenimentul trebuie să se termine după ce începe"
+	}
+	if event.EndsAt.Sub(event.StartsAt) > maxEventDuration {
+		return nil, "Un eveniment poate dura cel mult 30 de zile"
+	}
+
+	if strings.TrimSpace(req.Recurrence) != "" {
+		rule, err := calendar.ParseRule(req.Recurrence)
+		if err != nil {
+			return nil, "Regula de recurență este invalidă (sunt acceptate FREQ, INTERVAL, COUNT, UNTIL și BYDAY pentru WEEKLY)"
+		}
+		normalized := rule.String()
+		event.Recurrence = &normalized
+	}
+
+	if len(req.Reminders) > maxEventReminders {
+		return nil, "Un eveniment poate avea cel mult " + strconv.Itoa(maxEventReminders) + " mementouri"
+	}
+	event.Reminders = []int{}
+	seen := make(map[int]bool)
+	for _, m := range req.Reminders {
+		if m < 0 || m > maxEventReminderMinutes {
+			return nil, "Mementourile trebuie să fie între 0 și " + strconv.Itoa(maxEventReminderMinutes) + " de minute înainte"
+		}
+		if !seen[m] {
+			seen[m] = true
+			event.Reminders = append(event.Reminders, m)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(event.Reminders)))
+
+	return event, ""
+}
+
+// parseEventTime acceptă un moment RFC 3339 sau o dată (YYYY-MM-DD), interpretată în fusul orar dat
+func parseEventTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// loadEvents încarcă evenimentele din calendarul relației, în ordinea începutului
+func (h *RelationshipHandler) loadEvents(relationshipID uint) ([]*models.RelationshipEvent, error) {
+	rows, err := h.DB.Query(
+		`SELECT `+eventColumns+` FROM relationship_events WHERE relationship_id = $1 ORDER BY starts_at, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*models.RelationshipEvent{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// findEvent încarcă evenimentul indicat de parametrul :eventId din calendarul relației
+func (h *RelationshipHandler) findEvent(c *fiber.Ctx, relationshipID uint) (*models.RelationshipEvent, error) {
+	eventID, err := c.ParamsInt("eventId")
+	if err != nil || eventID <= 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return scanEvent(h.DB.QueryRow(
+		`SELECT `+eventColumns+` FROM relationship_events WHERE id = $1 AND relationship_id = $2`,
+		eventID, relationshipID,
+	))
+}
+
+// eventLookupError transformă eroarea de căutare a unui eveniment într-un răspuns HTTP
+func eventLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Evenimentul nu a fost găsit",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea evenimentului",
+	})
+}
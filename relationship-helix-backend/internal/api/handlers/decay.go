@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/decay"
+	"relationship-helix/internal/models"
+)
+
+// DecayPolicyRequest reprezintă cererea de configurare a estompării pozițiilor; câmpurile lipsă rămân neschimbate
+type DecayPolicyRequest struct {
+	Mode      *string `json:"mode"` // off, stale sau drift
+	AfterDays *int    `json:"afterDays"`
+	Rate      *int    `json:"rate"`
+}
+
+// decayPolicyResponse descrie politica de estompare a relației
+func decayPolicyResponse(relationship *models.Relationship) fiber.Map {
+	return fiber.Map{
+		"mode":      relationship.DecayMode,
+		"afterDays": relationship.DecayAfterDays,
+		"rate":      relationship.DecayRate,
+		"neutral":   decay.Neutral,
+	}
+}
+
+// GetDecayPolicy returnează politica de estompare a pozițiilor neactualizate
+func (h *RelationshipHandler) GetDecayPolicy(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"decay": decayPolicyResponse(relationship),
+	})
+}
+
+// UpdateDecayPolicy configurează estomparea pozițiilor neactualizate: marcarea lor ca vechi sau apropierea
+// treptată de mijlocul scalei. Starea pozițiilor este recalculată imediat.
+func (h *RelationshipHandler) UpdateDecayPolicy(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req DecayPolicyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	policy := decay.Policy{
+		Mode:      relationship.DecayMode,
+		AfterDays: relationship.DecayAfterDays,
+		Rate:      relationship.DecayRate,
+	}
+	if req.Mode != nil {
+		policy.Mode = *req.Mode
+	}
+	if req.AfterDays != nil {
+		policy.AfterDays = *req.AfterDays
+	}
+	if req.Rate != nil {
+		policy.Rate = *req.Rate
+	}
+
+	if err := policy.Validate(); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Modul trebuie să fie off, stale sau drift, pragul între 1 și 365 de zile, iar ritmul între 1 și 50 de puncte pe zi",
+		})
+	}
+
+	err = h.DB.QueryRow(
+		`UPDATE relationships
+         SET decay_mode = $2, decay_after_days = $3, decay_rate = $4, updated_at = NOW()
+         WHERE id = $1
+         RETURNING decay_mode, decay_after_days, decay_rate, updated_at`,
+		relationship.ID, policy.Mode, policy.AfterDays, policy.Rate,
+	).Scan(&relationship.DecayMode, &relationship.DecayAfterDays, &relationship.DecayRate, &relationship.UpdatedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea estompării pozițiilor",
+		})
+	}
+
+	// Membrii primesc position_decayed pentru pozițiile a căror stare se schimbă sub noua politică
+	if err := decay.Refresh(h.DB, relationship.ID, time.Now(), SendToUser); err != nil {
+		log.Printf("Estompare: Eroare la relația %d: %v\n", relationship.ID, err)
+	}
+
+	// Anunță ceilalți membri despre noua configurație
+	SendToMembers(relationship, userID, "decay_policy_changed", decayPolicyResponse(relationship))
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"decay": decayPolicyResponse(relationship),
+	})
+}
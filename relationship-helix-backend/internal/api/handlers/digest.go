@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/digest"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/reminders"
+)
+
+// UpdateDigestRequest reprezintă cererea de abonare sau dezabonare de la rezumatul săptămânal
+type UpdateDigestRequest struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// GetDigest returnează abonarea utilizatorului curent la rezumatul săptămânal
+func (h *SettingsHandler) GetDigest(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	return h.digestResponse(c, userID)
+}
+
+// UpdateDigest abonează sau dezabonează utilizatorul de la rezumatul săptămânal
+func (h *SettingsHandler) UpdateDigest(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdateDigestRequest
+	if err := c.BodyParser(&req); err != nil || req.Enabled == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	_, err := h.DB.Exec(
+		`INSERT INTO digest_preferences (user_id, enabled, updated_at)
+         VALUES ($1, $2, NOW())
+         ON CONFLICT (user_id)
+         DO UPDATE SET enabled = $2, updated_at = NOW()`,
+		userID, *req.Enabled,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea abonării",
+		})
+	}
+
+	return h.digestResponse(c, userID)
+}
+
+// digestResponse returnează abonarea curentă a utilizatorului și data ultimului rezumat trimis
+func (h *SettingsHandler) digestResponse(c *fiber.Ctx, userID uint) error {
+	var lastSentAt sql.NullTime
+	settings := models.DigestSettings{
+		SendTime: reminders.FormatClock(digest.ParseSendTime(h.Config.DigestSendTime)),
+	}
+
+	err := h.DB.QueryRow(
+		`SELECT COALESCE((SELECT enabled FROM digest_preferences WHERE user_id = $1), FALSE),
+                (SELECT MAX(sent_at) FROM digest_deliveries WHERE user_id = $1 AND status = 'sent')`,
+		userID,
+	).Scan(&settings.Enabled, &lastSentAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea abonării",
+		})
+	}
+
+	if lastSentAt.Valid {
+		settings.LastSentAt = &lastSentAt.Time
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"digest": settings,
+	})
+}
+
+// GetDigestPreview returnează rezumatul săptămânal al relației, așa cum l-ar primi utilizatorul.
+// Parametri: ?week=YYYY-MM-DD (orice zi din săptămână; implicit ultima săptămână încheiată) și
+// ?format=json (implicit), html sau text.
+func (h *RelationshipHandler) GetDigestPreview(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	format := c.Query("format", "json")
+	if format != "json" && format != "html" && format != "text" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Formatul trebuie să fie json, html sau text",
+		})
+	}
+
+	now := time.Now()
+	loc := h.userLocation(userID)
+	week := digest.LastCompleted(now, loc)
+	if value := c.Query("week"); value != "" {
+		date, err := time.ParseInLocation("2006-01-02", value, loc)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Săptămâna trebuie să aibă formatul YYYY-MM-DD",
+			})
+		}
+		week = digest.WeekOf(date, loc)
+		if week.Start.After(now) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Săptămâna nu poate fi în viitor",
+			})
+		}
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	in, err := digest.Load(h.DB, relationship.ID, userID, week)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea rezumatului",
+		})
+	}
+	in.AppURL = h.Config.AppURL
+
+	report := digest.Build(in)
+	rendered, err := digest.Render(report)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea rezumatului",
+		})
+	}
+
+	switch format {
+	case "html":
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Status(fiber.StatusOK).SendString(rendered.HTML)
+	case "text":
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		return c.Status(fiber.StatusOK).SendString(rendered.Text)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"digest":  report,
+		"empty":   report.Empty(), // Un rezumat gol nu este trimis
+		"subject": rendered.Subject,
+		"text":    rendered.Text,
+		"html":    rendered.HTML,
+	})
+}
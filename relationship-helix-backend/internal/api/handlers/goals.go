@@ -0,0 +1,904 @@
+package handlers
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+)
+
+const (
+	// Limitele câmpurilor unui obiectiv sau ale unei sarcini
+	maxGoalTitleLength       = 200
+	maxGoalDescriptionLength = 2000
+
+	// Numărul maxim de obiective ale unei relații și de sarcini ale unui obiectiv
+	maxGoalsPerRelationship = 200
+	maxTasksPerGoal         = 100
+)
+
+// Acțiunile trimise în evenimentul goal_changed
+const (
+	goalEventCreated       = "created"
+	goalEventUpdated       = "updated"
+	goalEventDeleted       = "deleted"
+	goalEventCompleted     = "completed"
+	goalEventReopened      = "reopened"
+	goalEventTaskCreated   = "task_created"
+	goalEventTaskUpdated   = "task_updated"
+	goalEventTaskDeleted   = "task_deleted"
+	goalEventTaskCompleted = "task_completed"
+	goalEventTaskReopened  = "task_reopened"
+)
+
+// GoalRequest reprezintă cererea de creare sau înlocuire a unui obiectiv
+type GoalRequest struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	DueDate     *string `json:"dueDate"`    // YYYY-MM-DD; lipsă sau gol = fără termen
+	AssigneeID  *uint   `json:"assigneeId"` // Un membru al relației; lipsă sau 0 = neatribuit
+	Status      *string `json:"status"`     // Opțional; la înlocuire, lipsa păstrează starea
+}
+
+// GoalTaskRequest reprezintă cererea de creare sau înlocuire a unei sarcini
+type GoalTaskRequest struct {
+	Title      string  `json:"title"`
+	DueDate    *string `json:"dueDate"`
+	AssigneeID *uint   `json:"assigneeId"`
+	Status     *string `json:"status"`
+}
+
+// goalColumns sunt coloanele citite de scanGoal
+const goalColumns = `id, relationship_id, created_by, title, description, to_char(due_date, 'YYYY-MM-DD'), assignee_id, status, completed_by, completed_at, created_at, updated_at`
+
+// taskColumns sunt coloanele citite de scanGoalTask, din tabela goal_tasks cu aliasul t
+const taskColumns = `t.id, t.goal_id, t.created_by, t.title, to_char(t.due_date, 'YYYY-MM-DD'), t.assignee_id, t.status, t.completed_by, t.completed_at, t.sort_order, t.created_at, t.updated_at`
+
+// goalStatusAssignment actualizează starea ($2) și, pentru starea done, cine și când a terminat ($3 = utilizatorul).
+// Un obiectiv deja terminat își păstrează momentul terminării.
+const goalStatusAssignment = `status = $2,
+             completed_at = CASE WHEN $2 = 'done' THEN COALESCE(completed_at, NOW()) END,
+             completed_by = CASE WHEN $2 = 'done' THEN COALESCE(completed_by, $3) END`
+
+// scanGoal citește un obiectiv, fără sarcini
+func scanGoal(row rowScanner) (*models.Goal, error) {
+	var g models.Goal
+	var createdBy, assigneeID, completedBy sql.NullInt64
+	var dueDate sql.NullString
+	var completedAt sql.NullTime
+	err := row.Scan(&g.ID, &g.RelationshipID, &createdBy, &g.Title, &g.Description, &dueDate, &assigneeID,
+		&g.Status, &completedBy, &completedAt, &g.CreatedAt, &g.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	g.CreatedBy = nullableID(createdBy)
+	g.AssigneeID = nullableID(assigneeID)
+	g.CompletedBy = nullableID(completedBy)
+	if dueDate.Valid {
+		g.DueDate = &dueDate.String
+	}
+	if completedAt.Valid {
+		g.CompletedAt = &completedAt.Time
+	}
+	g.Tasks = []models.GoalTask{}
+	return &g, nil
+}
+
+// scanGoalTask citește o sarcină
+func scanGoalTask(row rowScanner) (*models.GoalTask, error) {
+	var t models.GoalTask
+	var createdBy, assigneeID, completedBy sql.NullInt64
+	var dueDate sql.NullString
+	var completedAt sql.NullTime
+	err := row.Scan(&t.ID, &t.GoalID, &createdBy, &t.Title, &dueDate, &assigneeID,
+		&t.Status, &completedBy, &completedAt, &t.SortOrder, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	t.CreatedBy = nullableID(createdBy)
+	t.AssigneeID = nullableID(assigneeID)
+	t.CompletedBy = nullableID(completedBy)
+	if dueDate.Valid {
+		t.DueDate = &dueDate.String
+	}
+	if completedAt.Valid {
+		t.CompletedAt = &completedAt.Time
+	}
+	return &t, nil
+}
+
+// GetGoals returnează obiectivele relației cu sarcinile lor (cele neterminate primele), opțional filtrate după ?status=
+func (h *RelationshipHandler) GetGoals(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	status := c.Query("status")
+	if status != "" && !models.IsGoalStatus(status) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul status trebuie să fie open, in_progress sau done",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goals, err := h.loadGoals(relationship.ID, 0)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea obiectivelor",
+		})
+	}
+
+	if status != "" {
+		filtered := []*models.Goal{}
+		for _, g := range goals {
+			if g.Status == status {
+				filtered = append(filtered, g)
+			}
+		}
+		goals = filtered
+	}
+
+	summary, err := h.loadGoalsSummary(relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea progresului",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"goals":   goals,
+		"summary": summary,
+	})
+}
+
+// GetGoal returnează un obiectiv cu sarcinile lui
+func (h *RelationshipHandler) GetGoal(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goal, err := h.findGoal(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"goal": goal,
+	})
+}
+
+// CreateGoal adaugă un obiectiv comun
+func (h *RelationshipHandler) CreateGoal(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req GoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	fields, message := normalizeGoalFields(relationship, req.Title, req.DueDate, req.AssigneeID, req.Status)
+	if message == "" {
+		req.Description, message = normalizeGoalDescription(req.Description)
+	}
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	var count int
+	err = h.DB.QueryRow(`SELECT COUNT(*) FROM relationship_goals WHERE relationship_id = $1`, relationship.ID).Scan(&count)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea obiectivelor existente",
+		})
+	}
+	if count >= maxGoalsPerRelationship {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Relația a atins numărul maxim de obiective",
+		})
+	}
+
+	status := models.GoalStatusOpen
+	if fields.Status != nil {
+		status = *fields.Status
+	}
+
+	var goalID uint
+	err = h.DB.QueryRow(
+		`INSERT INTO relationship_goals (relationship_id, created_by, title, description, due_date, assignee_id, status,
+                                         completed_by, completed_at, created_at, updated_at)
+         VALUES ($1, $3, $4, $5, $6, $7, $2,
+                 CASE WHEN $2 = 'done' THEN $3::integer END, CASE WHEN $2 = 'done' THEN NOW() END, NOW(), NOW())
+         RETURNING id`,
+		relationship.ID, status, userID, fields.Title, req.Description, fields.DueDate, fields.AssigneeID,
+	).Scan(&goalID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea obiectivului",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goalID, goalEventCreated, 0, fiber.StatusCreated)
+}
+
+// UpdateGoal înlocuiește titlul, descrierea, termenul și persoana responsabilă ale unui obiectiv;
+// starea se schimbă doar dacă este trimisă. Orice membru al relației poate modifica obiectivul.
+func (h *RelationshipHandler) UpdateGoal(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req GoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	fields, message := normalizeGoalFields(relationship, req.Title, req.DueDate, req.AssigneeID, req.Status)
+	if message == "" {
+		req.Description, message = normalizeGoalDescription(req.Description)
+	}
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	goal, err := h.findGoal(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	status := goal.Status
+	if fields.Status != nil {
+		status = *fields.Status
+	}
+
+	_, err = h.DB.Exec(
+		`UPDATE relationship_goals
+         SET `+goalStatusAssignment+`,
+             title = $4, description = $5, due_date = $6, assignee_id = $7, updated_at = NOW()
+         WHERE id = $1`,
+		goal.ID, status, userID, fields.Title, req.Description, fields.DueDate, fields.AssigneeID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea obiectivului",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, goalEventUpdated, 0, fiber.StatusOK)
+}
+
+// DeleteGoal șterge un obiectiv, împreună cu sarcinile lui
+func (h *RelationshipHandler) DeleteGoal(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goal, err := h.findGoal(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	if _, err := h.DB.Exec(`DELETE FROM relationship_goals WHERE id = $1`, goal.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea obiectivului",
+		})
+	}
+
+	summary, err := h.loadGoalsSummary(relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea progresului",
+		})
+	}
+
+	SendToMembers(relationship, userID, "goal_changed", fiber.Map{
+		"action":  goalEventDeleted,
+		"userId":  userID,
+		"goal":    goal,
+		"summary": summary,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"summary": summary,
+	})
+}
+
+// CompleteGoal marchează un obiectiv ca terminat
+func (h *RelationshipHandler) CompleteGoal(c *fiber.Ctx) error {
+	return h.setGoalStatus(c, models.GoalStatusDone, goalEventCompleted)
+}
+
+// ReopenGoal redeschide un obiectiv terminat
+func (h *RelationshipHandler) ReopenGoal(c *fiber.Ctx) error {
+	return h.setGoalStatus(c, models.GoalStatusOpen, goalEventReopened)
+}
+
+// setGoalStatus schimbă starea obiectivului și anunță ceilalți membri
+func (h *RelationshipHandler) setGoalStatus(c *fiber.Ctx, status, action string) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goal, err := h.findGoal(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	_, err = h.DB.Exec(
+		`UPDATE relationship_goals SET `+goalStatusAssignment+`, updated_at = NOW() WHERE id = $1`,
+		goal.ID, status, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea obiectivului",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, action, 0, fiber.StatusOK)
+}
+
+// CreateGoalTask adaugă o sarcină la sfârșitul listei unui obiectiv
+func (h *RelationshipHandler) CreateGoalTask(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req GoalTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	fields, message := normalizeGoalFields(relationship, req.Title, req.DueDate, req.AssigneeID, req.Status)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	goal, err := h.findGoal(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	if len(goal.Tasks) >= maxTasksPerGoal {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Obiectivul a atins numărul maxim de sarcini",
+		})
+	}
+
+	status := models.GoalStatusOpen
+	if fields.Status != nil {
+		status = *fields.Status
+	}
+
+	var taskID uint
+	err = h.DB.QueryRow(
+		`INSERT INTO goal_tasks (goal_id, created_by, title, due_date, assignee_id, status, completed_by, completed_at, sort_order, created_at, updated_at)
+         VALUES ($1, $3, $4, $5, $6, $2,
+                 CASE WHEN $2 = 'done' THEN $3::integer END, CASE WHEN $2 = 'done' THEN NOW() END,
+                 COALESCE((SELECT MAX(sort_order) + 1 FROM goal_tasks WHERE goal_id = $1), 0), NOW(), NOW())
+         RETURNING id`,
+		goal.ID, status, userID, fields.Title, fields.DueDate, fields.AssigneeID,
+	).Scan(&taskID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea sarcinii",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, goalEventTaskCreated, taskID, fiber.StatusCreated)
+}
+
+// UpdateGoalTask înlocuiește titlul, termenul și persoana responsabilă ale unei sarcini;
+// starea se schimbă doar dacă este trimisă
+func (h *RelationshipHandler) UpdateGoalTask(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req GoalTaskRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	fields, message := normalizeGoalFields(relationship, req.Title, req.DueDate, req.AssigneeID, req.Status)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	goal, task, err := h.findGoalTask(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	status := task.Status
+	if fields.Status != nil {
+		status = *fields.Status
+	}
+
+	_, err = h.DB.Exec(
+		`UPDATE goal_tasks
+         SET `+goalStatusAssignment+`,
+             title = $4, due_date = $5, assignee_id = $6, updated_at = NOW()
+         WHERE id = $1`,
+		task.ID, status, userID, fields.Title, fields.DueDate, fields.AssigneeID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea sarcinii",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, goalEventTaskUpdated, task.ID, fiber.StatusOK)
+}
+
+// DeleteGoalTask șterge o sarcină din lista unui obiectiv
+func (h *RelationshipHandler) DeleteGoalTask(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goal, task, err := h.findGoalTask(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	if _, err := h.DB.Exec(`DELETE FROM goal_tasks WHERE id = $1`, task.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea sarcinii",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, goalEventTaskDeleted, task.ID, fiber.StatusOK)
+}
+
+// CompleteGoalTask bifează o sarcină
+func (h *RelationshipHandler) CompleteGoalTask(c *fiber.Ctx) error {
+	return h.setGoalTaskStatus(c, models.GoalStatusDone, goalEventTaskCompleted)
+}
+
+// ReopenGoalTask debifează o sarcină
+func (h *RelationshipHandler) ReopenGoalTask(c *fiber.Ctx) error {
+	return h.setGoalTaskStatus(c, models.GoalStatusOpen, goalEventTaskReopened)
+}
+
+// setGoalTaskStatus schimbă starea sarcinii și anunță ceilalți membri
+func (h *RelationshipHandler) setGoalTaskStatus(c *fiber.Ctx, status, action string) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	goal, task, err := h.findGoalTask(c, relationship.ID)
+	if err != nil {
+		return goalLookupError(c, err)
+	}
+
+	_, err = h.DB.Exec(
+		`UPDATE goal_tasks SET `+goalStatusAssignment+`, updated_at = NOW() WHERE id = $1`,
+		task.ID, status, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea sarcinii",
+		})
+	}
+
+	return h.goalChanged(c, relationship, userID, goal.ID, action, task.ID, fiber.StatusOK)
+}
+
+// goalChanged reîncarcă obiectivul modificat, îl trimite celorlalți membri prin evenimentul goal_changed,
+// împreună cu progresul actualizat, și îl returnează în răspuns
+func (h *RelationshipHandler) goalChanged(c *fiber.Ctx, relationship *models.Relationship, userID, goalID uint, action string, taskID uint, status int) error {
+	goals, err := h.loadGoals(relationship.ID, goalID)
+	if err != nil || len(goals) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea obiectivului",
+		})
+	}
+
+	summary, err := h.loadGoalsSummary(relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea progresului",
+		})
+	}
+
+	payload := fiber.Map{
+		"action":  action,
+		"userId":  userID,
+		"goal":    goals[0],
+		"summary": summary,
+	}
+	if taskID != 0 {
+		payload["taskId"] = taskID
+	}
+	SendToMembers(relationship, userID, "goal_changed", payload)
+
+	response := fiber.Map{
+		"goal":    goals[0],
+		"summary": summary,
+	}
+	if taskID != 0 {
+		response["taskId"] = taskID
+	}
+	return c.Status(status).JSON(response)
+}
+
+// goalFields sunt câmpurile validate ale unui obiectiv sau ale unei sarcini
+type goalFields struct {
+	Title      string
+	DueDate    *string
+	AssigneeID *uint
+	Status     *string
+}
+
+// normalizeGoalFields validează câmpurile comune obiectivelor și sarcinilor
+func normalizeGoalFields(relationship *models.Relationship, title string, dueDate *string, assigneeID *uint, status *string) (*goalFields, string) {
+	fields := &goalFields{Title: strings.TrimSpace(title)}
+	if fields.Title == "" || utf8.RuneCountInString(fields.Title) > maxGoalTitleLength {
+		return nil, "Titlul este obligatoriu și poate avea cel mult 200 de caractere"
+	}
+
+	if dueDate != nil && strings.TrimSpace(*dueDate) != "" {
+		value := strings.TrimSpace(*dueDate)
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, "Termenul trebuie să aibă formatul YYYY-MM-DD"
+		}
+		fields.DueDate = &value
+	}
+
+	if assigneeID != nil && *assigneeID != 0 {
+		if !relationship.IsMember(*assigneeID) {
+			return nil, "Persoana responsabilă trebuie să fie un membru al relației"
+		}
+		fields.AssigneeID = assigneeID
+	}
+
+	if status != nil {
+		if !models.IsGoalStatus(*status) {
+			return nil, "Starea trebuie să fie open, in_progress sau done"
+		}
+		fields.Status = status
+	}
+
+	return fields, ""
+}
+
+// normalizeGoalDescription validează descrierea opțională a unui obiectiv
+func normalizeGoalDescription(description *string) (*string, string) {
+	description = trimOptional(description)
+	if description != nil && utf8.RuneCountInString(*description) > maxGoalDescriptionLength {
+		return nil, "Descrierea poate avea cel mult 2000 de caractere"
+	}
+	return description, ""
+}
+
+// loadGoals încarcă obiectivele relației (sau doar obiectivul goalID, dacă nu este 0), cu sarcinile și progresul lor.
+// Obiectivele neterminate apar primele, în ordinea termenului.
+func (h *RelationshipHandler) loadGoals(relationshipID, goalID uint) ([]*models.Goal, error) {
+	rows, err := h.DB.Query(
+		`SELECT `+goalColumns+`
+         FROM relationship_goals
+         WHERE relationship_id = $1 AND ($2 = 0 OR id = $2)
+         ORDER BY status = 'done', due_date NULLS LAST, id`,
+		relationshipID, goalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := []*models.Goal{}
+	byID := make(map[uint]*models.Goal)
+	for rows.Next() {
+		g, err := scanGoal(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		goals = append(goals, g)
+		byID[g.ID] = g
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = h.DB.Query(
+		`SELECT `+taskColumns+`
+         FROM goal_tasks t
+         JOIN relationship_goals g ON g.id = t.goal_id
+         WHERE g.relationship_id = $1 AND ($2 = 0 OR t.goal_id = $2)
+         ORDER BY t.sort_order, t.id`,
+		relationshipID, goalID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanGoalTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		if g, ok := byID[t.GoalID]; ok {
+			g.Tasks = append(g.Tasks, *t)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, g := range goals {
+		g.Progress = goalProgress(g)
+	}
+	return goals, nil
+}
+
+// goalProgress calculează progresul unui obiectiv: procentul sarcinilor bifate sau, fără sarcini, 0 ori 100
+func goalProgress(g *models.Goal) models.GoalProgress {
+	progress := models.GoalProgress{TasksTotal: len(g.Tasks)}
+	for _, t := range g.Tasks {
+		if t.Status == models.GoalStatusDone {
+			progress.TasksDone++
+		}
+	}
+
+	switch {
+	case g.Status == models.GoalStatusDone:
+		progress.Percent = 100
+	case progress.TasksTotal > 0:
+		progress.Percent = progress.TasksDone * 100 / progress.TasksTotal
+	}
+	return progress
+}
+
+// loadGoalsSummary calculează progresul obiectivelor relației; termenele depășite sunt raportate la ziua
+// curentă din fusul orar al utilizatorului
+func (h *RelationshipHandler) loadGoalsSummary(relationshipID, userID uint) (*models.GoalsSummary, error) {
+	today := time.Now().In(h.userLocation(userID)).Format("2006-01-02")
+
+	var s models.GoalsSummary
+	var goalsOverdue, goalsAssigned, tasksOverdue, tasksAssigned int
+	err := h.DB.QueryRow(
+		`SELECT COUNT(*),
+                COUNT(*) FILTER (WHERE status = 'open'),
+                COUNT(*) FILTER (WHERE status = 'in_progress'),
+                COUNT(*) FILTER (WHERE status = 'done'),
+                COUNT(*) FILTER (WHERE status <> 'done' AND due_date < $2::date),
+                COUNT(*) FILTER (WHERE status <> 'done' AND assignee_id = $3)
+         FROM relationship_goals
+         WHERE relationship_id = $1`,
+		relationshipID, today, userID,
+	).Scan(&s.Total, &s.Open, &s.InProgress, &s.Done, &goalsOverdue, &goalsAssigned)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sarcinile obiectivelor terminate nu mai sunt considerate restante
+	err = h.DB.QueryRow(
+		`SELECT COUNT(*),
+                COUNT(*) FILTER (WHERE t.status = 'done'),
+                COUNT(*) FILTER (WHERE t.status <> 'done' AND g.status <> 'done' AND t.due_date < $2::date),
+                COUNT(*) FILTER (WHERE t.status <> 'done' AND g.status <> 'done' AND t.assignee_id = $3)
+         FROM goal_tasks t
+         JOIN relationship_goals g ON g.id = t.goal_id
+         WHERE g.relationship_id = $1`,
+		relationshipID, today, userID,
+	).Scan(&s.TasksTotal, &s.TasksDone, &tasksOverdue, &tasksAssigned)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Overdue = goalsOverdue + tasksOverdue
+	s.AssignedOpen = goalsAssigned + tasksAssigned
+	if s.Total > 0 {
+		s.Percent = s.Done * 100 / s.Total
+	}
+	return &s, nil
+}
+
+// findGoal încarcă obiectivul indicat de parametrul :goalId, cu sarcinile lui
+func (h *RelationshipHandler) findGoal(c *fiber.Ctx, relationshipID uint) (*models.Goal, error) {
+	goalID, err := c.ParamsInt("goalId")
+	if err != nil || goalID <= 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	goals, err := h.loadGoals(relationshipID, uint(goalID))
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return goals[0], nil
+}
+
+// findGoalTask încarcă obiectivul :goalId și sarcina lui :taskId
+func (h *RelationshipHandler) findGoalTask(c *fiber.Ctx, relationshipID uint) (*models.Goal, *models.GoalTask, error) {
+	goal, err := h.findGoal(c, relationshipID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskID, err := c.ParamsInt("taskId")
+	if err != nil || taskID <= 0 {
+		return nil, nil, sql.ErrNoRows
+	}
+	for i := range goal.Tasks {
+		if goal.Tasks[i].ID == uint(taskID) {
+			return goal, &goal.Tasks[i], nil
+		}
+	}
+	return nil, nil, sql.ErrNoRows
+}
+
+// goalLookupError transformă eroarea de căutare a unui obiectiv sau a unei sarcini într-un răspuns HTTP
+func goalLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Obiectivul sau sarcina nu a fost găsită",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea obiectivului",
+	})
+}
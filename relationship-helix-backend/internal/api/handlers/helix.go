@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/helix"
+)
+
+const (
+	// Dimensiunea implicită a imaginii elicei, în pixeli
+	defaultHelixWidth  = 400
+	defaultHelixHeight = 600
+)
+
+// GetHelixSVG returnează imaginea dublei elice a relației, ca SVG
+func (h *RelationshipHandler) GetHelixSVG(c *fiber.Ctx) error {
+	return h.renderHelix(c, "svg")
+}
+
+// GetHelixPNG returnează imaginea dublei elice a relației, ca PNG
+func (h *RelationshipHandler) GetHelixPNG(c *fiber.Ctx) error {
+	return h.renderHelix(c, "png")
+}
+
+// renderHelix desenează elicea din pozițiile curente ale utilizatorului și ale membrului comparat (într-un cuplu,
+// partenerul). Parametri: ?width=, ?height=, ?theme=, ?memberId= și ?days= (fereastra de istoric; 0 = doar
+// pozițiile curente). Pozițiile membrilor aflați în pauză nu sunt expuse.
+func (h *RelationshipHandler) renderHelix(c *fiber.Ctx, format string) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	width, errW := strconv.Atoi(c.Query("width", strconv.Itoa(defaultHelixWidth)))
+	height, errH := strconv.Atoi(c.Query("height", strconv.Itoa(defaultHelixHeight)))
+	if errW != nil || errH != nil || width < helix.MinSize || width > helix.MaxSize || height < helix.MinSize || height > helix.MaxSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Dimensiunile trebuie să fie între " + strconv.Itoa(helix.MinSize) + " și " + strconv.Itoa(helix.MaxSize) + " pixeli",
+		})
+	}
+
+	theme, ok := helix.LookupTheme(c.Query("theme", helix.DefaultTheme))
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Tema trebuie să fie una dintre: " + strings.Join(helix.ThemeNames, ", "),
+		})
+	}
+
+	days, err := strconv.Atoi(c.Query("days", "0"))
+	if err != nil || days < 0 || days > maxHistoryDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul days trebuie să fie între 0 și " + strconv.Itoa(maxHistoryDays),
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Membrul cu care se compară utilizatorul (într-un cuplu, partenerul)
+	memberID, ok := comparedMemberID(c, relationship, userID)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul memberId trebuie să fie ID-ul altui membru al relației",
+		})
+	}
+
+	positions, err := h.loadMemberPositions(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pozițiilor",
+		})
+	}
+
+	// Fără un membru comparat sau cu membrul în pauză, curba lui este estompată, la poziția neutră
+	in := helix.Input{PartnerHidden: true}
+	for _, p := range positions {
+		switch {
+		case p.UserID == userID && p.Position != nil:
+			in.UserPosition = *p.Position
+		case p.UserID == memberID && p.Position != nil:
+			in.PartnerPosition = *p.Position
+			in.PartnerHidden = false
+		}
+	}
+
+	if days > 0 {
+		loc := h.userLocation(userID)
+		now := time.Now().In(loc)
+		in.From = time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, loc)
+		in.To = now
+
+		events, err := h.loadHistoryEvents(relationship.ID, "", in.From, userID, userID, memberID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la obținerea istoricului",
+			})
+		}
+		for _, e := range events {
+			point := helix.Point{At: e.At, Position: e.Position}
+			if e.UserID == userID {
+				in.UserHistory = append(in.UserHistory, point)
+			} else {
+				in.PartnerHistory = append(in.PartnerHistory, point)
+			}
+		}
+	}
+
+	options := helix.Options{Width: width, Height: height, Theme: theme}
+	c.Set(fiber.HeaderCacheControl, "private, max-age=60")
+
+	if format == "svg" {
+		svg, err := helix.SVG(in, options)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la generarea imaginii",
+			})
+		}
+		c.Set(fiber.HeaderContentType, "image/svg+xml")
+		return c.Status(fiber.StatusOK).SendString(svg)
+	}
+
+	data, err := helix.PNG(in, options)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea imaginii",
+		})
+	}
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Status(fiber.StatusOK).Send(data)
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/history"
+	"relationship-helix/internal/pause"
+)
+
+const (
+	// Numărul implicit și maxim de zile din istoric
+	defaultHistoryDays = 30
+	maxHistoryDays     = 365
+)
+
+// GetHistory returnează istoricul pozițiilor ambilor parteneri, grupat pe zile în fusul orar al utilizatorului
+func (h *RelationshipHandler) GetHistory(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	days, err := strconv.Atoi(c.Query("days", strconv.Itoa(defaultHistoryDays)))
+	if err != nil || days < 1 || days > maxHistoryDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul days trebuie să fie între 1 și " + strconv.Itoa(maxHistoryDays),
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Membrul cu care se compară utilizatorul (într-un cuplu, partenerul)
+	memberID, ok := comparedMemberID(c, relationship, userID)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul memberId trebuie să fie ID-ul altui membru al relației",
+		})
+	}
+
+	// Opțional, istoricul unei singure dimensiuni (valorile sunt în intervalul ei)
+	axisKey := c.Query("axis")
+	if message, err := h.checkAxisParam(relationship.ID, axisKey); message != "" || err != nil {
+		return axisParamError(c, message, err)
+	}
+
+	// Intervalul începe la miezul nopții locale, cu days-1 zile în urmă
+	loc := h.userLocation(userID)
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, loc)
+
+	events, err := h.loadHistoryEvents(relationship.ID, axisKey, from, userID, userID, memberID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"memberId": memberID,
+		"axis":     axisKey,
+		"timezone": loc.String(),
+		"days":     history.BucketByDay(events, userID, loc),
+	})
+}
+
+// loadHistoryEvents încarcă actualizările lui userID și memberID făcute începând cu from, în ordine cronologică.
+// Actualizările făcute în timpul unei pauze active de alți membri decât viewerID nu sunt expuse (viewerID 0 le
+// ascunde pe toate); actualizările anulate și anulările lor nu apar în curbă.
+func (h *RelationshipHandler) loadHistoryEvents(relationshipID uint, axisKey string, from time.Time, viewerID, userID, memberID uint) ([]history.Event, error) {
+	rows, err := h.DB.Query(
+		`SELECT e.user_id, COALESCE(a.value, e.position), e.created_at
+         FROM position_events e
+         LEFT JOIN position_event_axes a ON a.event_id = e.id AND a.axis_key = $5::text
+         WHERE e.relationship_id = $1 AND e.created_at >= $2
+           AND e.user_id IN ($3, $4)
+           AND ($5::text = '' OR a.value IS NOT NULL)
+           AND e.reverted_at IS NULL AND e.reverts_event_id IS NULL
+           AND `+pause.VisibleCondition("e", "$6")+`
+         ORDER BY e.created_at, e.id`,
+		relationshipID, from, userID, memberID, axisKey, viewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []history.Event
+	for rows.Next() {
+		var e history.Event
+		if err := rows.Scan(&e.UserID, &e.Position, &e.At); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
@@ -0,0 +1,401 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"log"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/history"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+)
+
+// maxImportRows este numărul maxim de rânduri dintr-un fișier importat
+const maxImportRows = 10000
+
+// Exportul citește istoricul în pagini, eliberând conexiunea la baza de date între ele: un client lent
+// nu ține ocupată o conexiune din pool cât timp descarcă fișierul
+const (
+	exportPageSize    = 500
+	exportPageTimeout = 10 * time.Second
+)
+
+// ExportHistory exportă, ca fișier CSV, JSON sau NDJSON (?format=, implicit csv), întregul istoric al pozițiilor
+// utilizatorului și ale membrului comparat (într-un cuplu, partenerul). Fișierul este generat pe măsură ce
+// actualizările sunt citite, în pagini de exportPageSize. Notițele private ale celuilalt membru și actualizările făcute de el în timpul
+// unei pauze active nu sunt exportate.
+func (h *RelationshipHandler) ExportHistory(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	format := c.Query("format", history.FormatCSV)
+	contentType, ok := history.ContentTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Formatul trebuie să fie unul dintre: " + strings.Join(history.Formats, ", "),
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Membrul cu care se compară utilizatorul (într-un cuplu, partenerul)
+	memberID, ok := comparedMemberID(c, relationship, userID)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul memberId trebuie să fie ID-ul altui membru al relației",
+		})
+	}
+
+	// Prima pagină este citită înainte de trimiterea antetelor, pentru ca o eroare să primească un răspuns potrivit
+	page, err := h.exportPage(relationship.ID, userID, memberID, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	filename := "helix-history-" + time.Now().In(h.userLocation(userID)).Format("2006-01-02") + "." + format
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Status(fiber.StatusOK)
+
+	// Paginile următoare sunt citite după ce handler-ul se încheie, pe măsură ce fișierul este trimis
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		encoder, err := history.NewEncoder(w, format)
+		if err != nil {
+			log.Printf("Export: Eroare la inițializare: %v\n", err)
+			return
+		}
+
+		for {
+			for _, event := range page {
+				r := event.Record
+
+				// Notițele private ale celuilalt membru nu sunt exportate
+				if r.UserID != userID && r.Visibility != models.VisibilityShared {
+					r.Note, r.Mood, r.Emoji = nil, nil, nil
+				}
+
+				if err := encoder.Encode(r); err != nil {
+					// Clientul a închis conexiunea
+					return
+				}
+			}
+
+			if len(page) < exportPageSize {
+				break
+			}
+
+			// Trimite pagina înainte de a o citi pe următoarea
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			page, err = h.exportPage(relationship.ID, userID, memberID, &page[len(page)-1])
+			if err != nil {
+				log.Printf("Export: Eroare la citirea istoricului: %v\n", err)
+				return
+			}
+		}
+
+		if err := encoder.Close(); err != nil {
+			return
+		}
+	})
+
+	return nil
+}
+
+// exportEvent este o actualizare exportată, împreună cu cheia ei de paginare
+type exportEvent struct {
+	history.Record
+	ID uint
+}
+
+// exportPage citește următoarea pagină din istoricul exportat, după actualizarea after (nil = de la început).
+// Paginarea folosește cheia (created_at, id), în ordinea exportului, iar conexiunea este eliberată la final.
+func (h *RelationshipHandler) exportPage(relationshipID, userID, memberID uint, after *exportEvent) ([]exportEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), exportPageTimeout)
+	defer cancel()
+
+	var afterAt *time.Time
+	var afterID uint
+	if after != nil {
+		afterAt, afterID = &after.At, after.ID
+	}
+
+	rows, err := h.DB.QueryContext(ctx,
+		`SELECT e.id, e.created_at, e.user_id, u.username, e.position, e.note, e.mood, e.emoji, e.visibility,
+                e.imported_at IS NOT NULL
+         FROM position_events e
+         JOIN users u ON u.id = e.user_id
+         WHERE e.relationship_id = $1 AND e.user_id IN ($2, $3)
+           AND e.reverted_at IS NULL AND e.reverts_event_id IS NULL
+           AND `+pause.VisibleCondition("e", "$2")+`
+           AND ($4::timestamptz IS NULL OR (e.created_at, e.id) > ($4, $5))
+         ORDER BY e.created_at, e.id
+         LIMIT $6`,
+		relationshipID, userID, memberID, afterAt, afterID, exportPageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := make([]exportEvent, 0, exportPageSize)
+	for rows.Next() {
+		var e exportEvent
+		err := rows.Scan(&e.ID, &e.At, &e.UserID, &e.Username, &e.Position, &e.Note, &e.Mood, &e.Emoji, &e.Visibility, &e.Imported)
+		if err != nil {
+			return nil, err
+		}
+		page = append(page, e)
+	}
+
+	return page, rows.Err()
+}
+
+// ImportHistory importă în istoricul utilizatorului curent poziții dintr-un fișier CSV, JSON sau NDJSON (corpul
+// cererii). Formatul se ia din ?format= sau din Content-Type. Fișierul este validat în întregime: dacă există
+// rânduri invalide, nimic nu este importat. Momentele deja prezente în istoric sau repetate în fișier, precum și
+// rândurile altor membri (dintr-un export al aplicației) sunt omise. Cu ?dryRun=true, răspunsul descrie doar ce
+// s-ar importa. Pozițiile importate sunt marcate ca atare, nu pot fi anulate și nu modifică poziția curentă.
+func (h *RelationshipHandler) ImportHistory(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	format := c.Query("format", importFormat(c.Get(fiber.HeaderContentType)))
+	if _, ok := history.ContentTypes[format]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Formatul trebuie să fie unul dintre: " + strings.Join(history.Formats, ", "),
+		})
+	}
+	dryRun := c.QueryBool("dryRun")
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// Blochează apartenența utilizatorului, astfel încât două importuri simultane nu pot adăuga același moment.
+	// Pozițiile importate trebuie să fie anterioare ultimei actualizări făcute în aplicație.
+	var lastUpdatedAt sql.NullTime
+	err = tx.QueryRow(
+		`SELECT (SELECT MAX(created_at) FROM position_events
+                 WHERE relationship_id = m.relationship_id AND user_id = m.user_id AND imported_at IS NULL)
+         FROM relationship_members m
+         WHERE m.relationship_id = $1 AND m.user_id = $2
+         FOR UPDATE`,
+		relationship.ID, userID,
+	).Scan(&lastUpdatedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	before := time.Now()
+	if lastUpdatedAt.Valid && lastUpdatedAt.Time.Before(before) {
+		before = lastUpdatedAt.Time
+	}
+
+	parsed, err := history.ParseImport(bytes.NewReader(c.Body()), format, history.ImportOptions{
+		UserID:   userID,
+		Location: h.userLocation(userID),
+		Before:   before,
+		MaxRows:  maxImportRows,
+	})
+	if err == history.ErrTooManyRows {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Fișierul poate avea cel mult " + strconv.Itoa(maxImportRows) + " de rânduri",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": err.Error(),
+		})
+	}
+
+	// Notițele, stările și vizibilitatea respectă aceleași reguli ca la actualizarea poziției
+	valid := make([]history.ImportRow, 0, len(parsed.Rows))
+	for _, row := range parsed.Rows {
+		req := UpdatePositionRequest{Position: row.Position, Note: row.Note, Mood: row.Mood, Emoji: row.Emoji, Visibility: row.Visibility}
+		if message := h.normalizePositionNote(&req); message != "" {
+			parsed.Errors = append(parsed.Errors, history.RowError{Row: row.Row, Message: message})
+			continue
+		}
+		row.Note, row.Mood, row.Emoji, row.Visibility = req.Note, req.Mood, req.Emoji, req.Visibility
+		valid = append(valid, row)
+	}
+
+	existing, err := importedRangeEvents(tx, relationship.ID, userID, valid)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	fresh, duplicates := history.Deduplicate(valid, existing)
+
+	result := models.HistoryImportResult{
+		DryRun:   dryRun,
+		Rows:     len(parsed.Rows) + len(parsed.Skipped) + len(parsed.Errors),
+		Imported: len(fresh),
+		Skipped:  append(parsed.Skipped, duplicates...),
+		Errors:   parsed.Errors,
+	}
+	sort.Slice(result.Skipped, func(i, j int) bool { return result.Skipped[i].Row < result.Skipped[j].Row })
+	sort.Slice(result.Errors, func(i, j int) bool { return result.Errors[i].Row < result.Errors[j].Row })
+	for _, row := range fresh {
+		at := row.At
+		if result.From == nil || at.Before(*result.From) {
+			result.From = &at
+		}
+		if result.To == nil || at.After(*result.To) {
+			result.To = &at
+		}
+	}
+
+	if len(result.Errors) > 0 && !dryRun {
+		result.Imported = 0
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Fișierul conține rânduri invalide; nicio poziție nu a fost importată",
+			"import":  result,
+		})
+	}
+
+	if dryRun || len(fresh) == 0 {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"import": result,
+		})
+	}
+
+	for _, row := range fresh {
+		_, err := tx.Exec(
+			`INSERT INTO position_events (relationship_id, user_id, position, note, mood, emoji, visibility, created_at, imported_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())`,
+			relationship.ID, userID, row.Position, row.Note, row.Mood, row.Emoji, row.Visibility, row.At,
+		)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la salvarea istoricului",
+			})
+		}
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Ceilalți membri își reîncarcă istoricul
+	SendToMembers(relationship, userID, "history_imported", fiber.Map{
+		"relationshipId": relationship.ID,
+		"userId":         userID,
+		"imported":       result.Imported,
+		"from":           result.From,
+		"to":             result.To,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"import": result,
+	})
+}
+
+// importFormat deduce formatul fișierului importat din Content-Type (implicit csv)
+func importFormat(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return history.FormatJSON
+	case "application/x-ndjson", "application/ndjson":
+		return history.FormatNDJSON
+	}
+	return history.FormatCSV
+}
+
+// importedRangeEvents încarcă momentele actualizărilor utilizatorului din intervalul acoperit de rândurile importate
+func importedRangeEvents(tx *sql.Tx, relationshipID, userID uint, rows []history.ImportRow) ([]time.Time, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	from, to := rows[0].At, rows[0].At
+	for _, row := range rows {
+		if row.At.Before(from) {
+			from = row.At
+		}
+		if row.At.After(to) {
+			to = row.At
+		}
+	}
+
+	result, err := tx.Query(
+		`SELECT created_at FROM position_events
+         WHERE relationship_id = $1 AND user_id = $2 AND created_at >= $3 AND created_at < $4`,
+		relationshipID, userID, from.Truncate(time.Second), to.Truncate(time.Second).Add(time.Second),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	var existing []time.Time
+	for result.Next() {
+		var at time.Time
+		if err := result.Scan(&at); err != nil {
+			return nil, err
+		}
+		existing = append(existing, at)
+	}
+
+	return existing, result.Err()
+}
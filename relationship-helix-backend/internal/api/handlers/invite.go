@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/qrcode"
+	"relationship-helix/internal/utils"
+)
+
+const (
+	// Dimensiunea implicită și maximă (în pixeli per modul) pentru codurile QR
+	defaultQRScale = 8
+	maxQRScale     = 20
+)
+
+// inviteLinkExpiry calculează expirarea link-ului, care nu poate depăși expirarea codului
+func (h *RelationshipHandler) inviteLinkExpiry(codeExpiresAt time.Time) time.Time {
+	linkExpiresAt := time.Now().Add(h.Config.InviteLinkExpiration)
+	if linkExpiresAt.After(codeExpiresAt) {
+		return codeExpiresAt
+	}
+	return linkExpiresAt
+}
+
+// GetInviteQR returnează codul QR (PNG sau SVG) pentru link-ul de invitație activ al utilizatorului
+// (codul dat prin ?code=, sau implicit cel mai recent)
+func (h *RelationshipHandler) GetInviteQR(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	format := c.Query("format", "png")
+	if format != "png" && format != "svg" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Formatul trebuie să fie png sau svg",
+		})
+	}
+
+	scale, err := strconv.Atoi(c.Query("size", strconv.Itoa(defaultQRScale)))
+	if err != nil || scale < 1 || scale > maxQRScale {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Dimensiunea trebuie să fie între 1 și " + strconv.Itoa(maxQRScale),
+		})
+	}
+
+	// Caută codul de invitație activ
+	var inviteCode models.InviteCode
+	err = h.DB.QueryRow(
+		`SELECT id, user_id, code, expires_at, created_at
+         FROM invite_codes
+         WHERE user_id = $1 AND expires_at > NOW() AND ($2::text = '' OR code = $2)
+         ORDER BY created_at DESC, id DESC
+         LIMIT 1`,
+		userID, c.Query("code"),
+	).Scan(&inviteCode.ID, &inviteCode.UserID, &inviteCode.Code, &inviteCode.ExpiresAt, &inviteCode.CreatedAt)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "Nu ai un cod de invitație activ",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la căutarea codului de invitație",
+		})
+	}
+
+	// Codifică link-ul semnat în codul QR
+	inviteURL := utils.BuildInviteURL(h.Config.AppURL, inviteCode.Code, h.inviteLinkExpiry(inviteCode.ExpiresAt), h.Config.InviteLinkSecret)
+	code, err := qrcode.Encode(inviteURL)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea codului QR",
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	if format == "svg" {
+		c.Set(fiber.HeaderContentType, "image/svg+xml")
+		return c.Status(fiber.StatusOK).SendString(code.SVG(scale))
+	}
+
+	image, err := code.PNG(scale)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea codului QR",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "image/png")
+	return c.Status(fiber.StatusOK).Send(image)
+}
+
+// PreviewInvite returnează doar numele celui care a trimis invitația și categoria relației, pe baza unui link semnat
+func (h *RelationshipHandler) PreviewInvite(c *fiber.Ctx) error {
+	code := c.Params("code")
+
+	// Verifică semnătura link-ului pentru a nu permite enumerarea codurilor
+	err := utils.VerifyInviteSignature(code, c.Query("exp"), c.Query("sig"), h.Config.InviteLinkSecret)
+	if err == utils.ErrInviteLinkExpired {
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{
+			"error":   true,
+			"message": "Link de invitație expirat",
+		})
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Link de invitație invalid",
+		})
+	}
+
+	// Caută numele celui care a generat codul
+	var inviterName, relationshipType string
+	var typeLabel *string
+	err = h.DB.QueryRow(
+		`SELECT u.username, ic.type, ic.type_label
+         FROM invite_codes ic
+         JOIN users u ON u.id = ic.user_id
+         WHERE ic.code = $1 AND ic.expires_at > NOW()`,
+		code,
+	).Scan(&inviterName, &relationshipType, &typeLabel)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "Cod de invitație invalid sau expirat",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la căutarea codului de invitație",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"inviterName": inviterName,
+		"type":        relationshipType,
+		"typeLabel":   typeLabel,
+	})
+}
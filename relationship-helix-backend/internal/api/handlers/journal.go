@@ -0,0 +1,762 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+)
+
+const (
+	// Numărul implicit și maxim de intrări din jurnal returnate pe pagină
+	defaultJournalLimit = 20
+	maxJournalLimit     = 100
+)
+
+// Acțiunile trimise în evenimentul journal_entry
+const (
+	journalEventCreated         = "created"
+	journalEventUpdated         = "updated"
+	journalEventDeleted         = "deleted"
+	journalEventReactionChanged = "reaction"
+)
+
+// JournalEntryRequest reprezintă cererea de creare sau editare a unei intrări din jurnal
+type JournalEntryRequest struct {
+	Body            *string `json:"body"`
+	PositionEventID *uint   `json:"positionEventId"` // La editare, 0 elimină legătura
+}
+
+// JournalReactionRequest reprezintă reacția unui membru la o intrare
+type JournalReactionRequest struct {
+	Emoji string `json:"emoji"`
+}
+
+// GetJournal returnează intrările din jurnalul relației, cele mai noi primele. Paginarea se face cu
+// ?cursor= (valoarea nextCursor din răspunsul anterior); intrările șterse apar fără conținut.
+func (h *RelationshipHandler) GetJournal(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultJournalLimit)))
+	if err != nil || limit < 1 || limit > maxJournalLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxJournalLimit),
+		})
+	}
+
+	cursor, err := strconv.ParseUint(c.Query("cursor", "0"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul cursor este invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entries, err := h.loadJournalEntries(relationship.ID, userID, 0, uint(cursor), limit)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea jurnalului",
+		})
+	}
+
+	var nextCursor *string
+	if len(entries) == limit {
+		next := strconv.FormatUint(uint64(entries[len(entries)-1].ID), 10)
+		nextCursor = &next
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"entries":    entries,
+		"nextCursor": nextCursor,
+	})
+}
+
+// GetJournalEntry returnează o intrare din jurnal
+func (h *RelationshipHandler) GetJournalEntry(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"entry": entry,
+	})
+}
+
+// CreateJournalEntry adaugă o intrare în jurnal, legată opțional de o actualizare de poziție
+func (h *RelationshipHandler) CreateJournalEntry(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req JournalEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	body, message := h.normalizeJournalBody(req.Body)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	var positionEventID *uint
+	if req.PositionEventID != nil && *req.PositionEventID != 0 {
+		if message, err := h.checkJournalPositionEvent(relationship.ID, userID, *req.PositionEventID); err != nil || message != "" {
+			return journalPositionEventError(c, message, err)
+		}
+		positionEventID = req.PositionEventID
+	}
+
+	var entryID uint
+	err = h.DB.QueryRow(
+		`INSERT INTO journal_entries (relationship_id, user_id, body, position_event_id, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, NOW(), NOW())
+         RETURNING id`,
+		relationship.ID, userID, body, positionEventID,
+	).Scan(&entryID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea intrării",
+		})
+	}
+
+	return h.journalEntryChanged(c, relationship, userID, entryID, journalEventCreated, fiber.StatusCreated)
+}
+
+// UpdateJournalEntry editează o intrare proprie; versiunea anterioară este păstrată în istoric
+func (h *RelationshipHandler) UpdateJournalEntry(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req JournalEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	if message := journalAuthorCheck(entry, userID); message != "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	body := *entry.Body
+	if req.Body != nil {
+		normalized, message := h.normalizeJournalBody(req.Body)
+		if message != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": message,
+			})
+		}
+		body = normalized
+	}
+
+	// Fără positionEventId în cerere, legătura existentă rămâne neschimbată
+	var positionEventID *uint
+	if req.PositionEventID != nil {
+		if *req.PositionEventID != 0 {
+			if message, err := h.checkJournalPositionEvent(relationship.ID, userID, *req.PositionEventID); err != nil || message != "" {
+				return journalPositionEventError(c, message, err)
+			}
+			positionEventID = req.PositionEventID
+		}
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	if err := saveJournalRevision(tx, entry, models.JournalActionEdited); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea istoricului",
+		})
+	}
+
+	_, err = tx.Exec(
+		`UPDATE journal_entries
+         SET body = $2,
+             position_event_id = CASE WHEN $4 THEN $3 ELSE position_event_id END,
+             edited_at = NOW(),
+             updated_at = NOW()
+         WHERE id = $1`,
+		entry.ID, body, positionEventID, req.PositionEventID != nil,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea intrării",
+		})
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	return h.journalEntryChanged(c, relationship, userID, entry.ID, journalEventUpdated, fiber.StatusOK)
+}
+
+// DeleteJournalEntry șterge o intrare proprie; în jurnal rămâne doar marcajul ștergerii,
+// iar conținutul anterior este păstrat în istoric, vizibil doar autorului
+func (h *RelationshipHandler) DeleteJournalEntry(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	if message := journalAuthorCheck(entry, userID); message != "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	if err := saveJournalRevision(tx, entry, models.JournalActionDeleted); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea istoricului",
+		})
+	}
+
+	_, err = tx.Exec(
+		`UPDATE journal_entries
+         SET body = NULL, position_event_id = NULL, deleted_at = NOW(), updated_at = NOW()
+         WHERE id = $1`,
+		entry.ID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea intrării",
+		})
+	}
+
+	if _, err := tx.Exec(`DELETE FROM journal_reactions WHERE entry_id = $1`, entry.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea reacțiilor",
+		})
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	return h.journalEntryChanged(c, relationship, userID, entry.ID, journalEventDeleted, fiber.StatusOK)
+}
+
+// GetJournalEntryHistory returnează versiunile anterioare ale unei intrări, cele mai noi primele
+func (h *RelationshipHandler) GetJournalEntryHistory(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	// Conținutul unei intrări șterse nu mai este vizibil celorlalți membri
+	if entry.DeletedAt != nil && entry.UserID != userID {
+		return journalLookupError(c, sql.ErrNoRows)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, action, body, position_event_id, created_at
+         FROM journal_entry_revisions
+         WHERE entry_id = $1
+         ORDER BY id DESC`,
+		entry.ID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+	defer rows.Close()
+
+	revisions := []models.JournalRevision{}
+	for rows.Next() {
+		var r models.JournalRevision
+		var positionEventID sql.NullInt64
+		if err := rows.Scan(&r.ID, &r.Action, &r.Body, &positionEventID, &r.CreatedAt); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea istoricului",
+			})
+		}
+		r.PositionEventID = nullableID(positionEventID)
+		revisions = append(revisions, r)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea istoricului",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"entry":     entry,
+		"revisions": revisions,
+	})
+}
+
+// ReactToJournalEntry setează reacția utilizatorului la intrarea altui membru (o reacție per membru)
+func (h *RelationshipHandler) ReactToJournalEntry(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req JournalReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	emoji := strings.TrimSpace(req.Emoji)
+	if !isEmoji(emoji) || emoji == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Emoji invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	if entry.DeletedAt != nil {
+		return journalLookupError(c, sql.ErrNoRows)
+	}
+
+	if entry.UserID == userID {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Nu poți reacționa la propria intrare",
+		})
+	}
+
+	_, err = h.DB.Exec(
+		`INSERT INTO journal_reactions (entry_id, user_id, emoji, created_at)
+         VALUES ($1, $2, $3, NOW())
+         ON CONFLICT (entry_id, user_id) DO UPDATE SET emoji = $3, created_at = NOW()`,
+		entry.ID, userID, emoji,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea reacției",
+		})
+	}
+
+	return h.journalEntryChanged(c, relationship, userID, entry.ID, journalEventReactionChanged, fiber.StatusOK)
+}
+
+// RemoveJournalReaction șterge reacția utilizatorului la o intrare
+func (h *RelationshipHandler) RemoveJournalReaction(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	entry, err := h.findJournalEntry(c, relationship.ID, userID)
+	if err != nil {
+		return journalLookupError(c, err)
+	}
+
+	result, err := h.DB.Exec(
+		`DELETE FROM journal_reactions WHERE entry_id = $1 AND user_id = $2`,
+		entry.ID, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea reacției",
+		})
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Reacția nu a fost găsită",
+		})
+	}
+
+	return h.journalEntryChanged(c, relationship, userID, entry.ID, journalEventReactionChanged, fiber.StatusOK)
+}
+
+// journalEntryChanged reîncarcă intrarea, anunță ceilalți membri prin evenimentul journal_entry și o returnează
+func (h *RelationshipHandler) journalEntryChanged(c *fiber.Ctx, relationship *models.Relationship, userID, entryID uint, action string, status int) error {
+	entries, err := h.loadJournalEntries(relationship.ID, userID, entryID, 0, 1)
+	if err != nil || len(entries) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea intrării",
+		})
+	}
+	entry := &entries[0]
+
+	// Legătura cu poziția este calculată din perspectiva fiecărui membru: o poziție din timpul
+	// unei pauze a autorului nu este trimisă celorlalți
+	for _, memberID := range relationship.OtherMemberIDs(userID) {
+		payload := fiber.Map{
+			"action": action,
+			"userId": userID,
+			"entry":  entry,
+		}
+		if entry.PositionEvent != nil {
+			if forMember, err := h.loadJournalEntries(relationship.ID, memberID, entryID, 0, 1); err == nil && len(forMember) == 1 {
+				payload["entry"] = &forMember[0]
+			}
+		}
+		SendToUser(relationship.ID, memberID, "journal_entry", payload)
+	}
+
+	return c.Status(status).JSON(fiber.Map{
+		"entry": entry,
+	})
+}
+
+// loadJournalEntries încarcă intrările din jurnal, văzute de utilizatorul viewerID: toate (entryID = 0)
+// sau una singură, mai vechi decât before (0 = fără limită)
+func (h *RelationshipHandler) loadJournalEntries(relationshipID, viewerID, entryID, before uint, limit int) ([]models.JournalEntry, error) {
+	rows, err := h.DB.Query(
+		`SELECT j.id, j.relationship_id, j.user_id, j.body, j.position_event_id, j.created_at, j.updated_at, j.edited_at, j.deleted_at,
+                e.id, e.user_id, e.position, e.created_at
+         FROM journal_entries j
+         LEFT JOIN position_events e ON e.id = j.position_event_id AND `+pause.VisibleCondition("e", "$2")+`
+         WHERE j.relationship_id = $1 AND ($3 = 0 OR j.id = $3) AND ($4 = 0 OR j.id < $4)
+         ORDER BY j.id DESC
+         LIMIT $5`,
+		relationshipID, viewerID, entryID, before, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.JournalEntry{}
+	index := make(map[uint]int)
+	var ids []int64
+	for rows.Next() {
+		var entry models.JournalEntry
+		var positionEventID, linkID, linkUserID, linkPosition sql.NullInt64
+		var linkCreatedAt sql.NullTime
+		err := rows.Scan(&entry.ID, &entry.RelationshipID, &entry.UserID, &entry.Body, &positionEventID,
+			&entry.CreatedAt, &entry.UpdatedAt, &entry.EditedAt, &entry.DeletedAt,
+			&linkID, &linkUserID, &linkPosition, &linkCreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.PositionEventID = nullableID(positionEventID)
+		if linkID.Valid {
+			entry.PositionEvent = &models.JournalPositionLink{
+				ID:        uint(linkID.Int64),
+				UserID:    uint(linkUserID.Int64),
+				Position:  int(linkPosition.Int64),
+				CreatedAt: linkCreatedAt.Time,
+			}
+		} else {
+			// Actualizarea ascunsă de o pauză nu este dezvăluită nici prin ID
+			entry.PositionEventID = nil
+		}
+		entry.Reactions = []models.JournalReaction{}
+
+		index[entry.ID] = len(entries)
+		ids = append(ids, int64(entry.ID))
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return entries, nil
+	}
+
+	reactionRows, err := h.DB.Query(
+		`SELECT entry_id, user_id, emoji, created_at
+         FROM journal_reactions
+         WHERE entry_id = ANY($1)
+         ORDER BY created_at`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer reactionRows.Close()
+
+	for reactionRows.Next() {
+		var entryID uint
+		var r models.JournalReaction
+		if err := reactionRows.Scan(&entryID, &r.UserID, &r.Emoji, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		if i, ok := index[entryID]; ok {
+			entries[i].Reactions = append(entries[i].Reactions, r)
+		}
+	}
+
+	return entries, reactionRows.Err()
+}
+
+// findJournalEntry încarcă intrarea indicată de parametrul :entryId din jurnalul relației
+func (h *RelationshipHandler) findJournalEntry(c *fiber.Ctx, relationshipID, userID uint) (*models.JournalEntry, error) {
+	entryID, err := c.ParamsInt("entryId")
+	if err != nil || entryID <= 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	entries, err := h.loadJournalEntries(relationshipID, userID, uint(entryID), 0, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &entries[0], nil
+}
+
+// checkJournalPositionEvent verifică dacă actualizarea de poziție aparține relației și este vizibilă utilizatorului
+func (h *RelationshipHandler) checkJournalPositionEvent(relationshipID, userID, eventID uint) (string, error) {
+	var exists bool
+	err := h.DB.QueryRow(
+		`SELECT EXISTS(
+             SELECT 1 FROM position_events e
+             WHERE e.id = $1 AND e.relationship_id = $2 AND `+pause.VisibleCondition("e", "$3")+`
+         )`,
+		eventID, relationshipID, userID,
+	).Scan(&exists)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		return "Actualizarea de poziție nu a fost găsită", nil
+	}
+	return "", nil
+}
+
+// normalizeJournalBody validează textul unei intrări; returnează un mesaj de eroare dacă nu este valid
+func (h *RelationshipHandler) normalizeJournalBody(body *string) (string, string) {
+	trimmed := trimOptional(body)
+	if trimmed == nil {
+		return "", "Textul intrării este obligatoriu"
+	}
+	if utf8.RuneCountInString(*trimmed) > h.Config.JournalMaxLength {
+		return "", "Intrarea poate avea cel mult " + strconv.Itoa(h.Config.JournalMaxLength) + " de caractere"
+	}
+	return *trimmed, ""
+}
+
+// journalAuthorCheck verifică dacă utilizatorul poate modifica intrarea
+func journalAuthorCheck(entry *models.JournalEntry, userID uint) string {
+	if entry.UserID != userID {
+		return "Doar autorul poate modifica intrarea"
+	}
+	if entry.DeletedAt != nil {
+		return "Intrarea a fost ștearsă"
+	}
+	return ""
+}
+
+// saveJournalRevision păstrează versiunea curentă a intrării înainte de editare sau ștergere
+func saveJournalRevision(tx *sql.Tx, entry *models.JournalEntry, action string) error {
+	_, err := tx.Exec(
+		`INSERT INTO journal_entry_revisions (entry_id, action, body, position_event_id, created_at)
+         SELECT id, $2, body, position_event_id, NOW() FROM journal_entries WHERE id = $1 AND body IS NOT NULL`,
+		entry.ID, action,
+	)
+	return err
+}
+
+// nullableID transformă un ID opțional citit din baza de date
+func nullableID(id sql.NullInt64) *uint {
+	if !id.Valid {
+		return nil
+	}
+	value := uint(id.Int64)
+	return &value
+}
+
+// journalPositionEventError returnează răspunsul pentru o legătură invalidă către o actualizare de poziție
+func journalPositionEventError(c *fiber.Ctx, message string, err error) error {
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea actualizării de poziție",
+		})
+	}
+
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   true,
+		"message": message,
+	})
+}
+
+// journalLookupError transformă eroarea de căutare a unei intrări într-un răspuns HTTP
+func journalLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Intrarea nu a fost găsită",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea intrării",
+	})
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/db"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/webhooks"
+)
+
+// MemberPosition este poziția unui membru, așa cum o vede utilizatorul curent
+type MemberPosition struct {
+	UserID   uint      `json:"userId"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	Position *int      `json:"position"` // nil cât timp membrul este în pauză
+	Status   string    `json:"status"`
+	Pause    fiber.Map `json:"pause"`
+
+	// Estomparea pozițiilor neactualizate: Position este poziția estompată, OriginalPosition cea setată de membru
+	Stale            bool       `json:"stale"`
+	Decayed          bool       `json:"decayed"`
+	StaleSince       *time.Time `json:"staleSince,omitempty"`
+	OriginalPosition *int       `json:"originalPosition,omitempty"`
+}
+
+// CreateGroupRequest reprezintă cererea de creare a unui grup
+type CreateGroupRequest struct {
+	Name      string  `json:"name"`
+	Type      string  `json:"type"` // implicit romantic
+	TypeLabel *string `json:"typeLabel"`
+}
+
+// loadRelationship încarcă relația cu ID-ul dat, împreună cu membrii ei
+func loadRelationship(q db.Queryer, relationshipID uint) (*models.Relationship, error) {
+	var relationship models.Relationship
+	err := q.QueryRow(
+		`SELECT id, kind, name, type, type_label, start_date, created_at, updated_at, reveal_together, reveal_window_minutes,
+                decay_mode, decay_after_days, decay_rate
+         FROM relationships
+         WHERE id = $1`,
+		relationshipID,
+	).Scan(
+		&relationship.ID,
+		&relationship.Kind,
+		&relationship.Name,
+		&relationship.Type,
+		&relationship.TypeLabel,
+		&relationship.StartDate,
+		&relationship.CreatedAt,
+		&relationship.UpdatedAt,
+		&relationship.RevealTogether,
+		&relationship.RevealWindowMinutes,
+		&relationship.DecayMode,
+		&relationship.DecayAfterDays,
+		&relationship.DecayRate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	relationship.Members, err = loadMembers(q, relationship.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &relationship, nil
+}
+
+// loadMembers încarcă membrii relației, în ordinea intrării
+func loadMembers(q db.Queryer, relationshipID uint) ([]models.Member, error) {
+	rows, err := q.Query(
+		`SELECT user_id, display_name, role, joined_at
+         FROM relationship_members
+         WHERE relationship_id = $1
+         ORDER BY joined_at, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.Member{}
+	for rows.Next() {
+		var m models.Member
+		if err := rows.Scan(&m.UserID, &m.Name, &m.Role, &m.JoinedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// addMember adaugă utilizatorul în relație și îi inițializează poziția
+func addMember(tx *sql.Tx, relationshipID, userID uint, role string) error {
+	_, err := tx.Exec(
+		`INSERT INTO relationship_members (relationship_id, user_id, display_name, role, joined_at)
+         SELECT $1, id, username, $3, NOW() FROM users WHERE id = $2`,
+		relationshipID, userID, role,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO curve_positions (relationship_id, user_id, position, created_at, updated_at)
+         VALUES ($1, $2, 0, NOW(), NOW())
+         ON CONFLICT (relationship_id, user_id) DO NOTHING`,
+		relationshipID, userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO position_events (relationship_id, user_id, position, created_at)
+         VALUES ($1, $2, 0, NOW())`,
+		relationshipID, userID,
+	)
+	return err
+}
+
+// loadMemberPositions încarcă pozițiile tuturor membrilor; pozițiile celor aflați în pauză sunt ascunse
+func (h *RelationshipHandler) loadMemberPositions(relationship *models.Relationship, userID uint) ([]MemberPosition, error) {
+	type storedPosition struct {
+		position   int
+		decayed    sql.NullInt64
+		staleSince sql.NullTime
+	}
+
+	positions := make(map[uint]storedPosition)
+	rows, err := h.DB.Query(
+		`SELECT user_id, position, decayed_position, stale_since FROM curve_positions WHERE relationship_id = $1`,
+		relationship.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var memberID uint
+		var p storedPosition
+		if err := rows.Scan(&memberID, &p.position, &p.decayed, &p.staleSince); err != nil {
+			return nil, err
+		}
+		positions[memberID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]MemberPosition, 0, len(relationship.Members))
+	for _, m := range relationship.Members {
+		stored := positions[m.UserID]
+		position := stored.position
+		mp := MemberPosition{
+			UserID:   m.UserID,
+			Name:     m.Name,
+			Role:     m.Role,
+			Position: &position,
+			Status:   models.PositionStatusActive,
+		}
+
+		// Starea de estompare este calculată de job-ul de estompare
+		if stored.staleSince.Valid {
+			mp.Stale = true
+			mp.StaleSince = &stored.staleSince.Time
+		}
+		if stored.decayed.Valid && int(stored.decayed.Int64) != position {
+			decayed := int(stored.decayed.Int64)
+			mp.Decayed = true
+			mp.Position = &decayed
+			mp.OriginalPosition = &position
+		}
+
+		// Utilizatorul își vede mereu propria poziție
+		if m.UserID != userID {
+			memberPause, err := pause.Active(h.DB, relationship.ID, m.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if pause.HidesFrom(memberPause, userID, time.Now()) {
+				mp = MemberPosition{
+					UserID: m.UserID,
+					Name:   m.Name,
+					Role:   m.Role,
+					Status: models.PositionStatusPaused,
+					Pause:  pauseSummary(memberPause),
+				}
+			}
+		}
+
+		result = append(result, mp)
+	}
+
+	return result, nil
+}
+
+// CreateGroup creează un grup nou, cu utilizatorul curent ca proprietar; membrii intră prin coduri de invitație
+func (h *RelationshipHandler) CreateGroup(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req CreateGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len([]rune(req.Name)) > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Numele grupului trebuie să aibă între 1 și 100 de caractere",
+		})
+	}
+
+	relationshipType, typeLabel, message := normalizeRelationshipType(req.Type, req.TypeLabel)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	// Verifică limita de relații din această categorie
+	limitReached, err := h.relationshipLimitReached(h.DB, userID, relationshipType)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea relațiilor existente",
+		})
+	}
+	if limitReached {
+		return relationshipLimitError(c, relationshipType)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	var relationshipID uint
+	err = tx.QueryRow(
+		`INSERT INTO relationships (kind, name, type, type_label, start_date, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, NOW(), NOW(), NOW())
+         RETURNING id`,
+		models.RelationshipKindGroup, req.Name, relationshipType, typeLabel,
+	).Scan(&relationshipID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la crearea grupului",
+		})
+	}
+
+	if err := addMember(tx, relationshipID, userID, models.MemberRoleOwner); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la adăugarea membrului",
+		})
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	relationship, err := loadRelationship(h.DB, relationshipID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea grupului creat",
+		})
+	}
+
+	enqueueWebhook(h.DB, webhooks.Event{
+		Type:           webhooks.EventRelationshipCreated,
+		RelationshipID: relationship.ID,
+		RecipientIDs:   []uint{userID},
+		Data:           relationshipWebhookData(relationship),
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"relationship":      relationship.ToResponse(userID, h.userLocation(userID)),
+		"userCurvePosition": 0,
+	})
+}
+
+// removeMember scoate utilizatorul dintr-un grup; dacă pleacă proprietarul, cel mai vechi membru îi preia rolul
+func removeMember(tx *sql.Tx, relationship *models.Relationship, userID uint) error {
+	statements := []string{
+		`DELETE FROM curve_positions WHERE relationship_id = $1 AND user_id = $2`,
+		`DELETE FROM sealed_positions WHERE relationship_id = $1 AND user_id = $2`,
+		`UPDATE position_pauses SET ended_at = NOW() WHERE relationship_id = $1 AND user_id = $2 AND ended_at IS NULL`,
+		`DELETE FROM invite_codes WHERE relationship_id = $1 AND user_id = $2`,
+		`UPDATE relationship_goals SET assignee_id = NULL WHERE relationship_id = $1 AND assignee_id = $2`,
+		`UPDATE goal_tasks SET assignee_id = NULL
+         WHERE assignee_id = $2 AND goal_id IN (SELECT id FROM relationship_goals WHERE relationship_id = $1)`,
+		// Accesul partajat a fost aprobat pentru membrii de atunci; încetează când unul dintre ei pleacă
+		`UPDATE share_grants SET revoked_at = NOW(), revoked_by = $2 WHERE relationship_id = $1 AND revoked_at IS NULL`,
+		`DELETE FROM relationship_members WHERE relationship_id = $1 AND user_id = $2`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement, relationship.ID, userID); err != nil {
+			return err
+		}
+	}
+
+	if member, ok := relationship.Member(userID); ok && member.Role == models.MemberRoleOwner {
+		_, err := tx.Exec(
+			`UPDATE relationship_members SET role = $2
+             WHERE id = (SELECT id FROM relationship_members WHERE relationship_id = $1 ORDER BY joined_at, id LIMIT 1)`,
+			relationship.ID, models.MemberRoleOwner,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// comparedMemberID returnează membrul cu care se compară utilizatorul în istoric și statistici:
+// parametrul memberId, sau implicit partenerul (primul alt membru, pentru grupuri)
+func comparedMemberID(c *fiber.Ctx, relationship *models.Relationship, userID uint) (uint, bool) {
+	value := c.Query("memberId")
+	if value == "" {
+		return relationship.PartnerID(userID), true
+	}
+
+	memberID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || uint(memberID) == userID || !relationship.IsMember(uint(memberID)) {
+		return 0, false
+	}
+	return uint(memberID), true
+}
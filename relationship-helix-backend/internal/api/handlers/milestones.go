@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/milestones"
+	"relationship-helix/internal/models"
+)
+
+const (
+	// Numărul implicit și maxim de aniversări returnate
+	defaultUpcomingMilestones = 5
+	maxUpcomingMilestones     = 50
+)
+
+// GetMilestones returnează următoarele aniversări ale relației
+func (h *RelationshipHandler) GetMilestones(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	upcoming, err := strconv.Atoi(c.Query("upcoming", strconv.Itoa(defaultUpcomingMilestones)))
+	if err != nil || upcoming < 1 || upcoming > maxUpcomingMilestones {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul upcoming trebuie să fie între 1 și " + strconv.Itoa(maxUpcomingMilestones),
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	dates, err := h.loadRelationshipDates(relationship.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea datelor speciale",
+		})
+	}
+
+	// Calculele se fac în zile calendaristice, în fusul orar al utilizatorului
+	loc := h.userLocation(userID)
+	today := milestones.Date(time.Now(), loc)
+	start := milestones.Date(relationship.StartDate, loc)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"milestones": milestones.Upcoming(start, toCustomDates(dates), today, upcoming),
+	})
+}
+
+// GetRelationshipDates returnează datele speciale definite de parteneri
+func (h *RelationshipHandler) GetRelationshipDates(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	dates, err := h.loadRelationshipDates(relationship.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea datelor speciale",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"dates": dates,
+	})
+}
+
+// CreateRelationshipDateRequest reprezintă cererea de adăugare a unei date speciale
+type CreateRelationshipDateRequest struct {
+	Title     string `json:"title" validate:"required,max=100"`
+	Date      string `json:"date" validate:"required"`
+	Recurring *bool  `json:"recurring"`
+}
+
+// CreateRelationshipDate adaugă o dată specială pentru care se calculează aniversări
+func (h *RelationshipHandler) CreateRelationshipDate(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req CreateRelationshipDateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" || len([]rune(req.Title)) > 100 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Titlul este obligatoriu și poate avea cel mult 100 de caractere",
+		})
+	}
+
+	date, err := time.Parse(startDateLayout, req.Date)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Data trebuie să aibă formatul AAAA-LL-ZZ",
+		})
+	}
+
+	recurring := true
+	if req.Recurring != nil {
+		recurring = *req.Recurring
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	relationshipDate := models.RelationshipDate{
+		RelationshipID: relationship.ID,
+		CreatedBy:      userID,
+		Title:          req.Title,
+		Date:           date,
+		Recurring:      recurring,
+	}
+
+	err = h.DB.QueryRow(
+		`INSERT INTO relationship_dates (relationship_id, created_by, title, date, recurring, created_at)
+         VALUES ($1, $2, $3, $4, $5, NOW())
+         RETURNING id, created_at`,
+		relationshipDate.RelationshipID, relationshipDate.CreatedBy, relationshipDate.Title, relationshipDate.Date, relationshipDate.Recurring,
+	).Scan(&relationshipDate.ID, &relationshipDate.CreatedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea datei speciale",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"date": relationshipDate,
+	})
+}
+
+// DeleteRelationshipDate șterge o dată specială a relației
+func (h *RelationshipHandler) DeleteRelationshipDate(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	dateID, err := c.ParamsInt("dateId")
+	if err != nil || dateID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "ID dată invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	result, err := h.DB.Exec(
+		`DELETE FROM relationship_dates WHERE id = $1 AND relationship_id = $2`,
+		dateID, relationship.ID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea datei speciale",
+		})
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Data specială nu a fost găsită",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// loadRelationshipDates încarcă datele speciale ale unei relații
+func (h *RelationshipHandler) loadRelationshipDates(relationshipID uint) ([]models.RelationshipDate, error) {
+	rows, err := h.DB.Query(
+		`SELECT id, relationship_id, created_by, title, date, recurring, created_at
+         FROM relationship_dates
+         WHERE relationship_id = $1
+         ORDER BY date`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dates := []models.RelationshipDate{}
+	for rows.Next() {
+		var d models.RelationshipDate
+		if err := rows.Scan(&d.ID, &d.RelationshipID, &d.CreatedBy, &d.Title, &d.Date, &d.Recurring, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		dates = append(dates, d)
+	}
+
+	return dates, rows.Err()
+}
+
+// toCustomDates convertește datele speciale în formatul folosit de calculatorul de aniversări
+func toCustomDates(dates []models.RelationshipDate) []milestones.CustomDate {
+	result := make([]milestones.CustomDate, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, milestones.CustomDate{
+			ID:        d.ID,
+			Title:     d.Title,
+			Date:      milestones.Date(d.Date, time.UTC),
+			Recurring: d.Recurring,
+		})
+	}
+	return result
+}
@@ -0,0 +1,392 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+)
+
+const (
+	// Numărul implicit și maxim de gesturi returnate pe pagină
+	defaultNudgesLimit = 50
+	maxNudgesLimit     = 200
+)
+
+// SendNudgeRequest reprezintă cererea de trimitere a unui gest
+type SendNudgeRequest struct {
+	Type        string `json:"type"`
+	RecipientID *uint  `json:"recipientId"` // Opțional pentru cupluri; obligatoriu în grupuri
+}
+
+// GetNudges returnează gesturile primite și trimise de utilizator în relație, cele mai noi primele.
+// ?box=received sau ?box=sent restrâng lista; paginarea se face cu ?cursor= (valoarea nextCursor).
+func (h *RelationshipHandler) GetNudges(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultNudgesLimit)))
+	if err != nil || limit < 1 || limit > maxNudgesLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxNudgesLimit),
+		})
+	}
+
+	cursor, err := strconv.ParseUint(c.Query("cursor", "0"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul cursor este invalid",
+		})
+	}
+
+	box := c.Query("box", "all")
+	if box != "all" && box != "received" && box != "sent" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul box trebuie să fie all, received sau sent",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, relationship_id, sender_id, recipient_id, type, read_at, created_at
+         FROM nudges
+         WHERE relationship_id = $1 AND ($2 = 0 OR id < $2)
+           AND (($3 <> 'sent' AND recipient_id = $4) OR ($3 <> 'received' AND sender_id = $4))
+         ORDER BY id DESC
+         LIMIT $5`,
+		relationship.ID, cursor, box, userID, limit,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea gesturilor",
+		})
+	}
+	defer rows.Close()
+
+	nudges := []models.Nudge{}
+	for rows.Next() {
+		n, err := scanNudge(rows)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea gesturilor",
+			})
+		}
+		nudges = append(nudges, *n)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea gesturilor",
+		})
+	}
+
+	var unread int
+	err = h.DB.QueryRow(
+		`SELECT COUNT(*) FROM nudges WHERE relationship_id = $1 AND recipient_id = $2 AND read_at IS NULL`,
+		relationship.ID, userID,
+	).Scan(&unread)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea gesturilor necitite",
+		})
+	}
+
+	var nextCursor *string
+	if len(nudges) == limit {
+		next := strconv.FormatUint(uint64(nudges[len(nudges)-1].ID), 10)
+		nextCursor = &next
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"nudges":      nudges,
+		"unreadCount": unread,
+		"types":       models.NudgeTypes,
+		"nextCursor":  nextCursor,
+	})
+}
+
+// SendNudge trimite un gest unui alt membru, prin WebSocket sau, dacă acesta nu este conectat, prin
+// notificare. Numărul de gesturi trimise de un membru într-o relație este limitat pe o fereastră de timp.
+func (h *RelationshipHandler) SendNudge(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req SendNudgeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	if !models.IsNudgeType(req.Type) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Tip de gest invalid",
+			"types":   models.NudgeTypes,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Într-un cuplu, destinatarul implicit este partenerul
+	var recipientID uint
+	if req.RecipientID != nil {
+		recipientID = *req.RecipientID
+	} else if relationship.IsCouple() {
+		recipientID = relationship.PartnerID(userID)
+	}
+	if recipientID == 0 || recipientID == userID || !relationship.IsMember(recipientID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul recipientId trebuie să fie ID-ul altui membru al relației",
+		})
+	}
+
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// Blochează rândul expeditorului, astfel încât cererile simultane să verifice limita pe rând
+	var memberID uint
+	err = tx.QueryRow(
+		`SELECT id FROM relationship_members WHERE relationship_id = $1 AND user_id = $2 FOR UPDATE`,
+		relationship.ID, userID,
+	).Scan(&memberID)
+
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Limita de trimitere: cel mult NudgeLimit gesturi în ultima fereastră
+	var sent int
+	var oldest sql.NullTime
+	err = tx.QueryRow(
+		`SELECT COUNT(*), MIN(created_at)
+         FROM nudges
+         WHERE relationship_id = $1 AND sender_id = $2 AND created_at > $3`,
+		relationship.ID, userID, time.Now().Add(-h.Config.NudgeWindow),
+	).Scan(&sent, &oldest)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea limitei de gesturi",
+		})
+	}
+
+	if sent >= h.Config.NudgeLimit {
+		retryAfter := time.Until(oldest.Time.Add(h.Config.NudgeWindow))
+		seconds := int(retryAfter.Seconds()) + 1
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"error":      true,
+			"message":    "Ai trimis prea multe gesturi. Încearcă din nou mai târziu",
+			"retryAfter": seconds,
+		})
+	}
+
+	nudge, err := scanNudge(tx.QueryRow(
+		`INSERT INTO nudges (relationship_id, sender_id, recipient_id, type, created_at)
+         VALUES ($1, $2, $3, $4, NOW())
+         RETURNING id, relationship_id, sender_id, recipient_id, type, read_at, created_at`,
+		relationship.ID, userID, recipientID, req.Type,
+	))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la trimiterea gestului",
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Destinatarii deconectați primesc gestul ca notificare
+	SendToUser(relationship.ID, recipientID, "nudge", nudge)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"nudge":     nudge,
+		"remaining": h.Config.NudgeLimit - sent - 1,
+	})
+}
+
+// MarkNudgeRead marchează un gest primit ca citit și trimite confirmarea expeditorului
+func (h *RelationshipHandler) MarkNudgeRead(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	nudgeID, err := strconv.ParseUint(c.Params("nudgeId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "ID-ul gestului este invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Un gest deja citit își păstrează momentul primei citiri
+	nudge, err := scanNudge(h.DB.QueryRow(
+		`UPDATE nudges
+         SET read_at = COALESCE(read_at, NOW())
+         WHERE id = $1 AND relationship_id = $2 AND recipient_id = $3
+         RETURNING id, relationship_id, sender_id, recipient_id, type, read_at, created_at`,
+		nudgeID, relationship.ID, userID,
+	))
+
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Gestul nu a fost găsit",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la marcarea gestului ca citit",
+		})
+	}
+
+	SendToUser(relationship.ID, nudge.SenderID, "nudge_read", fiber.Map{
+		"nudgeIds": []uint{nudge.ID},
+		"readerId": userID,
+		"readAt":   nudge.ReadAt,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"nudge": nudge,
+	})
+}
+
+// MarkAllNudgesRead marchează ca citite toate gesturile primite în relație și trimite confirmările expeditorilor
+func (h *RelationshipHandler) MarkAllNudgesRead(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`UPDATE nudges
+         SET read_at = NOW()
+         WHERE relationship_id = $1 AND recipient_id = $2 AND read_at IS NULL
+         RETURNING id, sender_id, read_at`,
+		relationship.ID, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la marcarea gesturilor ca citite",
+		})
+	}
+	defer rows.Close()
+
+	bySender := make(map[uint][]uint)
+	var readAt time.Time
+	count := 0
+	for rows.Next() {
+		var id, senderID uint
+		if err := rows.Scan(&id, &senderID, &readAt); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la marcarea gesturilor ca citite",
+			})
+		}
+		bySender[senderID] = append(bySender[senderID], id)
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la marcarea gesturilor ca citite",
+		})
+	}
+
+	for senderID, ids := range bySender {
+		SendToUser(relationship.ID, senderID, "nudge_read", fiber.Map{
+			"nudgeIds": ids,
+			"readerId": userID,
+			"readAt":   readAt,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"read":    count,
+	})
+}
+
+// scanNudge citește un gest în ordinea coloanelor id, relationship_id, sender_id, recipient_id, type, read_at, created_at
+func scanNudge(row rowScanner) (*models.Nudge, error) {
+	var n models.Nudge
+	var readAt sql.NullTime
+	if err := row.Scan(&n.ID, &n.RelationshipID, &n.SenderID, &n.RecipientID, &n.Type, &readAt, &n.CreatedAt); err != nil {
+		return nil, err
+	}
+	if readAt.Valid {
+		n.ReadAt = &readAt.Time
+	}
+	return &n, nil
+}
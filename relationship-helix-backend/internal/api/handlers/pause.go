@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+)
+
+// StartPauseRequest reprezintă cererea de începere a unei pauze (fără termen = până la reluarea manuală)
+type StartPauseRequest struct {
+	EndsAt          *time.Time `json:"endsAt"`
+	DurationMinutes *int       `json:"durationMinutes"`
+}
+
+// GetPause returnează pauza utilizatorului și starea pozițiilor celorlalți membri
+func (h *RelationshipHandler) GetPause(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	userPause, err := pause.Active(h.DB, relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pauzei",
+		})
+	}
+
+	members, err := h.loadMemberPositions(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pauzei",
+		})
+	}
+
+	response := fiber.Map{
+		"pause": userPause,
+	}
+
+	statuses := []fiber.Map{}
+	for _, m := range members {
+		if m.UserID == userID {
+			continue
+		}
+		statuses = append(statuses, fiber.Map{
+			"userId": m.UserID,
+			"status": m.Status,
+			"pause":  m.Pause,
+		})
+
+		// Câmpurile pentru cupluri rămân compatibile cu clienții existenți
+		if relationship.IsCouple() {
+			response["partnerStatus"] = m.Status
+			response["partnerPause"] = m.Pause
+		}
+	}
+	response["members"] = statuses
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// StartPause pornește (sau prelungește) o pauză de intimitate pentru poziția utilizatorului
+func (h *RelationshipHandler) StartPause(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea (corpul poate lipsi)
+	var req StartPauseRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Cerere invalidă",
+			})
+		}
+	}
+
+	endsAt, err := pause.ResolveEnd(time.Now(), req.EndsAt, req.DurationMinutes)
+	if err == pause.ErrAmbiguousEnd {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Specifică fie endsAt, fie durationMinutes",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Sfârșitul pauzei trebuie să fie în următoarele 30 de zile",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// O pauză expirată, dar încă neînchisă de job, nu se prelungește
+	_, err = tx.Exec(
+		`UPDATE position_pauses
+         SET ended_at = ends_at
+         WHERE relationship_id = $1 AND user_id = $2 AND ended_at IS NULL AND ends_at <= NOW()`,
+		relationship.ID, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea pauzei",
+		})
+	}
+
+	// O pauză deja activă primește doar noul termen
+	p := models.PositionPause{RelationshipID: relationship.ID, UserID: userID, EndsAt: endsAt}
+	err = tx.QueryRow(
+		`INSERT INTO position_pauses (relationship_id, user_id, started_at, ends_at)
+         VALUES ($1, $2, NOW(), $3)
+         ON CONFLICT (relationship_id, user_id) WHERE ended_at IS NULL
+         DO UPDATE SET ends_at = EXCLUDED.ends_at
+         RETURNING id, started_at`,
+		relationship.ID, userID, endsAt,
+	).Scan(&p.ID, &p.StartedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea pauzei",
+		})
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Ceilalți membri află doar că poziția este în pauză
+	SendToMembers(relationship, userID, "pause_started", fiber.Map{
+		"userId":    userID,
+		"partnerId": userID,
+		"endsAt":    p.EndsAt,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"pause": p,
+	})
+}
+
+// EndPause încheie pauza utilizatorului; ceilalți membri primesc poziția curentă
+func (h *RelationshipHandler) EndPause(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	ended, err := pause.End(h.DB, relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la încheierea pauzei",
+		})
+	}
+
+	if ended == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Nu ai o pauză activă",
+		})
+	}
+
+	payload, err := pause.EndedPayload(h.DB, ended)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea poziției",
+		})
+	}
+	SendToMembers(relationship, userID, "pause_ended", payload)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"pause": ended,
+	})
+}
+
+// pauseSummary returnează doar informațiile despre pauză pe care le pot vedea ceilalți membri
+func pauseSummary(p *models.PositionPause) fiber.Map {
+	if p == nil {
+		return nil
+	}
+	return fiber.Map{
+		"endsAt": p.EndsAt,
+	}
+}
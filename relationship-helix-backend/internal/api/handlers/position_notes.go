@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+)
+
+const (
+	// Limitele pentru emoji (o singură secvență, eventual compusă cu ZWJ)
+	maxEmojiRunes = 8
+	maxEmojiBytes = 32
+
+	// Numărul implicit și maxim de evenimente returnate pe pagină
+	defaultEventsLimit = 50
+	maxEventsLimit     = 200
+)
+
+// normalizePositionNote validează și normalizează câmpurile opționale ale unei actualizări de poziție.
+// Returnează mesajul de eroare sau un șir gol dacă cererea este validă.
+func (h *RelationshipHandler) normalizePositionNote(req *UpdatePositionRequest) string {
+	req.Note = trimOptional(req.Note)
+	if req.Note != nil && utf8.RuneCountInString(*req.Note) > h.Config.NoteMaxLength {
+		return "Notița poate avea cel mult " + strconv.Itoa(h.Config.NoteMaxLength) + " de caractere"
+	}
+
+	req.Mood = trimOptional(req.Mood)
+	if req.Mood != nil && !h.Config.IsMoodTag(*req.Mood) {
+		return "Stare necunoscută. Valori permise: " + strings.Join(h.Config.MoodTags, ", ")
+	}
+
+	req.Emoji = trimOptional(req.Emoji)
+	if req.Emoji != nil && !isEmoji(*req.Emoji) {
+		return "Emoji invalid"
+	}
+
+	switch req.Visibility {
+	case "":
+		req.Visibility = models.VisibilityShared
+	case models.VisibilityShared, models.VisibilityPrivate:
+	default:
+		return "Vizibilitatea trebuie să fie shared sau private"
+	}
+
+	return ""
+}
+
+// GetMoodTags returnează vocabularul de stări și lungimea maximă a notițelor
+func (h *RelationshipHandler) GetMoodTags(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"moods":         h.Config.MoodTags,
+		"noteMaxLength": h.Config.NoteMaxLength,
+	})
+}
+
+// GetPositionEvents returnează actualizările de poziție cu notițele vizibile utilizatorului (cele mai noi întâi)
+func (h *RelationshipHandler) GetPositionEvents(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultEventsLimit)))
+	if err != nil || limit < 1 || limit > maxEventsLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxEventsLimit),
+		})
+	}
+
+	// Paginare după ID: se returnează evenimentele mai vechi decât before
+	before, err := strconv.Atoi(c.Query("before", "0"))
+	if err != nil || before < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul before este invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Actualizările făcute de ceilalți membri în timpul unei pauze active nu sunt expuse
+	rows, err := h.DB.Query(
+		`SELECT e.id, e.relationship_id, e.user_id, e.position, e.note, e.mood, e.emoji, e.visibility, e.created_at,
+                e.reverted_at, e.reverts_event_id, e.imported_at
+         FROM position_events e
+         WHERE e.relationship_id = $1 AND ($2 = 0 OR e.id < $2)
+           AND `+pause.VisibleCondition("e", "$4")+`
+         ORDER BY e.id DESC
+         LIMIT $3`,
+		relationship.ID, before, limit, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+	defer rows.Close()
+
+	events := []models.PositionEvent{}
+	for rows.Next() {
+		var e models.PositionEvent
+		var revertedAt sql.NullTime
+		var revertsEventID sql.NullInt64
+		var importedAt sql.NullTime
+		err := rows.Scan(&e.ID, &e.RelationshipID, &e.UserID, &e.Position, &e.Note, &e.Mood, &e.Emoji, &e.Visibility, &e.CreatedAt, &revertedAt, &revertsEventID, &importedAt)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea istoricului",
+			})
+		}
+		if revertedAt.Valid {
+			e.RevertedAt = &revertedAt.Time
+		}
+		e.RevertsEventID = nullableID(revertsEventID)
+		if importedAt.Valid {
+			e.ImportedAt = &importedAt.Time
+		}
+
+		// Notițele private ale partenerului nu sunt expuse
+		if !e.IsVisibleTo(userID) {
+			e.Note, e.Mood, e.Emoji = nil, nil, nil
+		}
+		events = append(events, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea istoricului",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"events": events,
+	})
+}
+
+// trimOptional elimină spațiile și transformă șirurile goale în nil
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// isEmoji verifică (aproximativ) că valoarea este un singur emoji, fără text obișnuit
+func isEmoji(value string) bool {
+	if len(value) > maxEmojiBytes || utf8.RuneCountInString(value) > maxEmojiRunes {
+		return false
+	}
+	for _, r := range value {
+		if r < 0x2000 {
+			return false
+		}
+	}
+	return true
+}
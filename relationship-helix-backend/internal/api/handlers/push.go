@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/push"
+	"relationship-helix/internal/webpush"
+)
+
+// PushHandler gestionează abonamentele Web Push și preferințele de notificare
+type PushHandler struct {
+	DB     *sql.DB
+	Config *config.Config
+
+	// Cheia publică VAPID (goală dacă notificările push nu sunt configurate)
+	PublicKey string
+}
+
+// NewPushHandler creează un nou handler pentru notificările push
+func NewPushHandler(db *sql.DB, cfg *config.Config) *PushHandler {
+	h := &PushHandler{
+		DB:     db,
+		Config: cfg,
+	}
+	if vapid, err := webpush.NewVAPID(cfg.VAPIDPrivateKey, cfg.VAPIDSubject); err == nil {
+		h.PublicKey = vapid.PublicKey()
+	}
+	return h
+}
+
+// SubscribeRequest reprezintă un abonament push, în formatul PushSubscription.toJSON() din browser
+type SubscribeRequest struct {
+	Endpoint       string `json:"endpoint"`
+	ExpirationTime *int64 `json:"expirationTime"` // milisecunde de la epoch, sau null
+	Keys           struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// UnsubscribeRequest reprezintă cererea de ștergere a unui abonament
+type UnsubscribeRequest struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// UpdatePushPreferencesRequest conține tipurile de evenimente activate sau dezactivate
+type UpdatePushPreferencesRequest struct {
+	Preferences map[string]bool `json:"preferences"`
+}
+
+// PushSubscriptionResponse este un abonament al utilizatorului, fără chei
+type PushSubscriptionResponse struct {
+	ID            uint       `json:"id"`
+	Endpoint      string     `json:"endpoint"`
+	UserAgent     *string    `json:"userAgent"`
+	ExpiresAt     *time.Time `json:"expiresAt"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt"`
+	CreatedAt     time.Time  `json:"createdAt"`
+}
+
+// GetVAPIDPublicKey returnează cheia publică folosită de browser la abonare (applicationServerKey)
+func (h *PushHandler) GetVAPIDPublicKey(c *fiber.Ctx) error {
+	if h.PublicKey == "" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error":   true,
+			"message": "Notificările push nu sunt configurate",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"publicKey": h.PublicKey,
+	})
+}
+
+// GetSubscriptions returnează abonamentele push ale utilizatorului curent
+func (h *PushHandler) GetSubscriptions(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, endpoint, user_agent, expires_at, last_success_at, created_at
+         FROM push_subscriptions
+         WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW())
+         ORDER BY created_at DESC`,
+		userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea abonamentelor",
+		})
+	}
+	defer rows.Close()
+
+	subscriptions := []PushSubscriptionResponse{}
+	for rows.Next() {
+		var s PushSubscriptionResponse
+		if err := rows.Scan(&s.ID, &s.Endpoint, &s.UserAgent, &s.ExpiresAt, &s.LastSuccessAt, &s.CreatedAt); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea abonamentelor",
+			})
+		}
+		subscriptions = append(subscriptions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea abonamentelor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"subscriptions": subscriptions,
+	})
+}
+
+// Subscribe înregistrează abonamentul push al browserului; un endpoint existent al utilizatorului este actualizat,
+// iar unul care aparține altui utilizator este refuzat
+func (h *PushHandler) Subscribe(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	if h.PublicKey == "" {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error":   true,
+			"message": "Notificările push nu sunt configurate",
+		})
+	}
+
+	// Parsează cererea
+	var req SubscribeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	sub := webpush.Subscription{Endpoint: req.Endpoint, P256dh: req.Keys.P256dh, Auth: req.Keys.Auth}
+	// Serviciile push reale folosesc HTTPS; HTTP este acceptat doar pentru serviciile de test locale
+	insecure := !strings.HasPrefix(sub.Endpoint, "https://") && !h.Config.PushAllowPrivate
+	if err := sub.Validate(); err != nil || insecure {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Abonament push invalid",
+		})
+	}
+
+	var expiresAt *time.Time
+	if req.ExpirationTime != nil {
+		t := time.UnixMilli(*req.ExpirationTime)
+		if !t.After(time.Now()) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Abonamentul push a expirat",
+			})
+		}
+		expiresAt = &t
+	}
+
+	var userAgent *string
+	if ua := c.Get(fiber.HeaderUserAgent); ua != "" {
+		if len([]rune(ua)) > 255 {
+			ua = string([]rune(ua)[:255])
+		}
+		userAgent = &ua
+	}
+
+	var subscription PushSubscriptionResponse
+	err := h.DB.QueryRow(
+		`INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth, user_agent, expires_at, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+         ON CONFLICT (endpoint)
+         DO UPDATE SET p256dh = $3, auth = $4, user_agent = $5, expires_at = $6, failure_count = 0, updated_at = NOW()
+         WHERE push_subscriptions.user_id = $1
+         RETURNING id, endpoint, user_agent, expires_at, last_success_at, created_at`,
+		userID, sub.Endpoint, sub.P256dh, sub.Auth, userAgent, expiresAt,
+	).Scan(&subscription.ID, &subscription.Endpoint, &subscription.UserAgent, &subscription.ExpiresAt, &subscription.LastSuccessAt, &subscription.CreatedAt)
+
+	// Niciun rând actualizat: endpoint-ul este înregistrat de alt utilizator
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Abonamentul push este înregistrat de alt utilizator",
+		})
+	}
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea abonamentului",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"subscription": subscription,
+	})
+}
+
+// Unsubscribe șterge abonamentul push cu endpoint-ul dat al utilizatorului curent
+func (h *PushHandler) Unsubscribe(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UnsubscribeRequest
+	if err := c.BodyParser(&req); err != nil || req.Endpoint == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Endpoint-ul abonamentului este obligatoriu",
+		})
+	}
+
+	result, err := h.DB.Exec(
+		`DELETE FROM push_subscriptions WHERE user_id = $1 AND endpoint = $2`,
+		userID, req.Endpoint,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea abonamentului",
+		})
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Abonamentul nu a fost găsit",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// GetPreferences returnează tipurile de evenimente pentru care utilizatorul primește notificări push
+func (h *PushHandler) GetPreferences(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	return h.preferencesResponse(c, userID)
+}
+
+// UpdatePreferences activează sau dezactivează notificările push pe tipuri de evenimente
+func (h *PushHandler) UpdatePreferences(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdatePushPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	for eventType := range req.Preferences {
+		if !push.IsEventType(eventType) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Tip de eveniment necunoscut: " + eventType,
+			})
+		}
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	for eventType, enabled := range req.Preferences {
+		_, err := tx.Exec(
+			`INSERT INTO push_preferences (user_id, event_type, enabled)
+             VALUES ($1, $2, $3)
+             ON CONFLICT (user_id, event_type) DO UPDATE SET enabled = $3`,
+			userID, eventType, enabled,
+		)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la salvarea preferințelor",
+			})
+		}
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	return h.preferencesResponse(c, userID)
+}
+
+// preferencesResponse returnează preferințele push curente ale utilizatorului
+func (h *PushHandler) preferencesResponse(c *fiber.Ctx, userID uint) error {
+	preferences, err := push.Preferences(h.DB, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea preferințelor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"preferences": preferences,
+		"eventTypes":  push.EventTypes(),
+	})
+}
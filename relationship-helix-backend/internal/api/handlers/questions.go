@@ -0,0 +1,460 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/questions"
+)
+
+const (
+	// Lungimea maximă a unui răspuns la întrebarea zilei
+	maxQuestionAnswerLength = 1000
+
+	// Numărul implicit și maxim de întrebări returnate pe pagină în arhivă
+	defaultQuestionsLimit = 30
+	maxQuestionsLimit     = 100
+
+	// Formatul datei întrebării
+	questionDateLayout = "2006-01-02"
+)
+
+// QuestionAnswerRequest reprezintă răspunsul unui membru la întrebarea zilei
+type QuestionAnswerRequest struct {
+	Answer string `json:"answer"`
+}
+
+// dailyQuestionRow este atribuirea unei întrebări pentru o zi, așa cum este salvată în baza de date
+type dailyQuestionRow struct {
+	ID         uint
+	Date       string
+	QuestionID string
+	RevealedAt *time.Time
+}
+
+// GetQuestion returnează întrebarea zilei pentru relație. :date este "today" (ziua curentă în fusul orar
+// al utilizatorului) sau o dată YYYY-MM-DD din trecut. Întrebarea zilei curente este atribuită la prima
+// vizualizare; zilele trecute fără întrebare atribuită nu apar în arhivă.
+func (h *RelationshipHandler) GetQuestion(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	date, today, message := questionDateParam(c, h.userLocation(userID))
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	row, err := h.findDailyQuestion(relationship.ID, date, today)
+	if err != nil {
+		return dailyQuestionLookupError(c, err)
+	}
+
+	// Un membru care a plecat poate fi ultimul care nu a răspuns
+	if err := h.revealDailyQuestion(relationship, row, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la dezvăluirea răspunsurilor",
+		})
+	}
+
+	question, err := h.loadDailyQuestions(relationship, userID, questionLanguage(c), []dailyQuestionRow{*row})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea răspunsurilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(question[0])
+}
+
+// AnswerQuestion salvează sau modifică răspunsul utilizatorului la întrebarea zilei. Răspunsul poate fi
+// modificat doar până la dezvăluire; când au răspuns toți membrii, răspunsurile devin vizibile tuturor.
+func (h *RelationshipHandler) AnswerQuestion(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req QuestionAnswerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	answer := strings.TrimSpace(req.Answer)
+	if answer == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Răspunsul nu poate fi gol",
+		})
+	}
+	if utf8.RuneCountInString(answer) > maxQuestionAnswerLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Răspunsul poate avea cel mult " + strconv.Itoa(maxQuestionAnswerLength) + " caractere",
+		})
+	}
+
+	date, today, message := questionDateParam(c, h.userLocation(userID))
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	row, err := h.findDailyQuestion(relationship.ID, date, today)
+	if err != nil {
+		return dailyQuestionLookupError(c, err)
+	}
+
+	// Condiția pe revealed_at evită modificarea unui răspuns dezvăluit între timp
+	result, err := h.DB.Exec(
+		`INSERT INTO daily_answers (daily_question_id, user_id, answer, created_at, updated_at)
+         SELECT $1, $2, $3, NOW(), NOW()
+         FROM daily_questions
+         WHERE id = $1 AND revealed_at IS NULL
+         ON CONFLICT (daily_question_id, user_id)
+         DO UPDATE SET answer = EXCLUDED.answer, updated_at = NOW()`,
+		row.ID, userID, answer,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea răspunsului",
+		})
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Răspunsurile au fost deja dezvăluite și nu mai pot fi modificate",
+		})
+	}
+
+	// Ceilalți membri află doar că utilizatorul a răspuns, nu și răspunsul
+	SendToMembers(relationship, userID, "question_answered", fiber.Map{
+		"userId": userID,
+		"date":   row.Date,
+	})
+
+	if err := h.revealDailyQuestion(relationship, row, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la dezvăluirea răspunsurilor",
+		})
+	}
+
+	question, err := h.loadDailyQuestions(relationship, userID, questionLanguage(c), []dailyQuestionRow{*row})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea răspunsurilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(question[0])
+}
+
+// GetQuestionArchive returnează întrebările atribuite relației, cele mai recente primele, cu răspunsurile
+// dezvăluite. Paginarea se face cu ?cursor= (valoarea nextCursor, o dată YYYY-MM-DD).
+func (h *RelationshipHandler) GetQuestionArchive(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultQuestionsLimit)))
+	if err != nil || limit < 1 || limit > maxQuestionsLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxQuestionsLimit),
+		})
+	}
+
+	var cursor *string
+	if value := c.Query("cursor"); value != "" {
+		if _, err := time.Parse(questionDateLayout, value); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Parametrul cursor este invalid",
+			})
+		}
+		cursor = &value
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, to_char(question_date, 'YYYY-MM-DD'), question_id, revealed_at
+         FROM daily_questions
+         WHERE relationship_id = $1 AND ($2::date IS NULL OR question_date < $2::date)
+         ORDER BY question_date DESC
+         LIMIT $3`,
+		relationship.ID, cursor, limit,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea arhivei",
+		})
+	}
+	defer rows.Close()
+
+	list := []dailyQuestionRow{}
+	for rows.Next() {
+		row, err := scanDailyQuestion(rows)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea arhivei",
+			})
+		}
+		list = append(list, *row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea arhivei",
+		})
+	}
+
+	archive, err := h.loadDailyQuestions(relationship, userID, questionLanguage(c), list)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea răspunsurilor",
+		})
+	}
+
+	var nextCursor *string
+	if len(list) == limit {
+		nextCursor = &list[len(list)-1].Date
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"questions":  archive,
+		"nextCursor": nextCursor,
+	})
+}
+
+// questionDateParam citește parametrul :date ("today" sau YYYY-MM-DD) și returnează data cerută și data
+// curentă în fusul orar al utilizatorului, sau mesajul de eroare pentru o dată invalidă sau viitoare
+func questionDateParam(c *fiber.Ctx, loc *time.Location) (string, string, string) {
+	today := time.Now().In(loc).Format(questionDateLayout)
+
+	date := c.Params("date")
+	if date == "today" {
+		return today, today, ""
+	}
+	if _, err := time.Parse(questionDateLayout, date); err != nil {
+		return "", "", "Data trebuie să fie today sau în formatul YYYY-MM-DD"
+	}
+	if date > today {
+		return "", "", "Întrebările zilelor viitoare nu sunt disponibile"
+	}
+	return date, today, ""
+}
+
+// findDailyQuestion încarcă întrebarea relației pentru data dată. Întrebarea zilei curente este atribuită
+// dacă nu există încă, astfel încât arhiva păstrează întrebarea văzută de membri.
+func (h *RelationshipHandler) findDailyQuestion(relationshipID uint, date, today string) (*dailyQuestionRow, error) {
+	if date == today {
+		day, _ := time.Parse(questionDateLayout, date)
+		question := questions.ForDate(relationshipID, day)
+		_, err := h.DB.Exec(
+			`INSERT INTO daily_questions (relationship_id, question_date, question_id, created_at)
+             VALUES ($1, $2, $3, NOW())
+             ON CONFLICT (relationship_id, question_date) DO NOTHING`,
+			relationshipID, date, question.ID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return scanDailyQuestion(h.DB.QueryRow(
+		`SELECT id, to_char(question_date, 'YYYY-MM-DD'), question_id, revealed_at
+         FROM daily_questions
+         WHERE relationship_id = $1 AND question_date = $2`,
+		relationshipID, date,
+	))
+}
+
+// revealDailyQuestion dezvăluie răspunsurile când au răspuns toți membrii actuali ai relației
+// și anunță membrii; nu face nimic dacă răspunsurile sunt deja dezvăluite
+func (h *RelationshipHandler) revealDailyQuestion(relationship *models.Relationship, row *dailyQuestionRow, userID uint) error {
+	members := relationship.MemberIDs()
+	if row.RevealedAt != nil || len(members) < 2 {
+		return nil
+	}
+
+	ids := make([]int64, len(members))
+	for i, id := range members {
+		ids[i] = int64(id)
+	}
+
+	var revealedAt time.Time
+	err := h.DB.QueryRow(
+		`UPDATE daily_questions
+         SET revealed_at = NOW()
+         WHERE id = $1 AND revealed_at IS NULL
+           AND (SELECT COUNT(*) FROM daily_answers WHERE daily_question_id = $1 AND user_id = ANY($2)) >= $3
+         RETURNING revealed_at`,
+		row.ID, pq.Array(ids), len(members),
+	).Scan(&revealedAt)
+
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	row.RevealedAt = &revealedAt
+
+	SendToMembers(relationship, userID, "question_revealed", fiber.Map{
+		"date":       row.Date,
+		"questionId": row.QuestionID,
+	})
+	return nil
+}
+
+// loadDailyQuestions completează întrebările cu textul în limba cerută și cu răspunsurile vizibile utilizatorului
+func (h *RelationshipHandler) loadDailyQuestions(relationship *models.Relationship, userID uint, lang string, list []dailyQuestionRow) ([]models.DailyQuestion, error) {
+	result := make([]models.DailyQuestion, len(list))
+	index := make(map[uint]int, len(list))
+	ids := make([]int64, len(list))
+	for i, row := range list {
+		question, _ := questions.Find(row.QuestionID)
+		result[i] = models.DailyQuestion{
+			ID:             row.ID,
+			RelationshipID: relationship.ID,
+			Date:           row.Date,
+			QuestionID:     row.QuestionID,
+			Category:       question.Category,
+			Text:           question.Localized(lang),
+			Language:       lang,
+			Revealed:       row.RevealedAt != nil,
+			RevealedAt:     row.RevealedAt,
+			AnsweredBy:     []uint{},
+			Answers:        []models.DailyQuestionAnswer{},
+		}
+		index[row.ID] = i
+		ids[i] = int64(row.ID)
+	}
+
+	if len(list) == 0 {
+		return result, nil
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT daily_question_id, user_id, answer, created_at, updated_at
+         FROM daily_answers
+         WHERE daily_question_id = ANY($1)
+         ORDER BY created_at`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var questionID uint
+		var a models.DailyQuestionAnswer
+		if err := rows.Scan(&questionID, &a.UserID, &a.Answer, &a.CreatedAt, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		i, ok := index[questionID]
+		if !ok {
+			continue
+		}
+		result[i].AnsweredBy = append(result[i].AnsweredBy, a.UserID)
+
+		// Înainte de dezvăluire, utilizatorul își vede doar propriul răspuns
+		if result[i].Revealed || a.UserID == userID {
+			result[i].Answers = append(result[i].Answers, a)
+		}
+	}
+
+	return result, rows.Err()
+}
+
+// scanDailyQuestion citește o atribuire de întrebare dintr-un rând
+func scanDailyQuestion(row rowScanner) (*dailyQuestionRow, error) {
+	var q dailyQuestionRow
+	var revealedAt sql.NullTime
+	if err := row.Scan(&q.ID, &q.Date, &q.QuestionID, &revealedAt); err != nil {
+		return nil, err
+	}
+	if revealedAt.Valid {
+		q.RevealedAt = &revealedAt.Time
+	}
+	return &q, nil
+}
+
+// questionLanguage alege limba întrebării din ?lang= sau din antetul Accept-Language
+func questionLanguage(c *fiber.Ctx) string {
+	if lang := c.Query("lang"); lang != "" {
+		return questions.ParseLanguage(lang)
+	}
+	return questions.ParseLanguage(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+// dailyQuestionLookupError transformă eroarea de la findDailyQuestion în răspunsul HTTP potrivit
+func dailyQuestionLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Nu există nicio întrebare pentru această zi",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea întrebării",
+	})
+}
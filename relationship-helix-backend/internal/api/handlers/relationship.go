@@ -6,9 +6,12 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"relationship-helix/internal/axes"
 	"relationship-helix/internal/config"
 	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
 	"relationship-helix/internal/utils"
+	"relationship-helix/internal/webhooks"
 )
 
 // RelationshipHandler gestionează rutele de relații
@@ -25,7 +28,7 @@ func NewRelationshipHandler(db *sql.DB, cfg *config.Config) *RelationshipHandler
 	}
 }
 
-// GetRelationship returnează relația utilizatorului curent
+// GetRelationship returnează relația indicată, cu pozițiile tuturor membrilor
 func (h *RelationshipHandler) GetRelationship(c *fiber.Ctx) error {
 	// Obține ID-ul utilizatorului din context
 	userID, ok := c.Locals("userID").(uint)
@@ -36,87 +39,99 @@ func (h *RelationshipHandler) GetRelationship(c *fiber.Ctx) error {
 		})
 	}
 	
-	// Caută relația utilizatorului
-	var relationship models.Relationship
-	err := h.DB.QueryRow(
-		`SELECT id, user1_id, user2_id, user1_name, user2_name, start_date, created_at, updated_at 
-         FROM relationships 
-         WHERE user1_id = $1 OR user2_id = $1`,
-		userID,
-	).Scan(
-		&relationship.ID, 
-		&relationship.User1ID, 
-		&relationship.User2ID, 
-		&relationship.User1Name, 
-		&relationship.User2Name, 
-		&relationship.StartDate, 
-		&relationship.CreatedAt, 
-		&relationship.UpdatedAt,
-	)
+	// Caută relația
+	relationship, err := h.findRelationship(c, userID)
 	
-	// Dacă nu există nicio relație
-	if err == sql.ErrNoRows {
+	// Pe ruta veche GET /api/relationship, lipsa unei relații nu este o eroare
+	if err == sql.ErrNoRows && c.Locals("defaultRelationshipID") != nil {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"relationship": nil,
 		})
 	}
 	
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+	
+	// Obține pozițiile tuturor membrilor (cei aflați în pauză apar doar cu starea neutră „paused”)
+	members, err := h.loadMemberPositions(relationship, userID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la obținerea relației",
+			"message": "Eroare la obținerea pozițiilor",
 		})
 	}
 	
-	// Obține pozițiile curbelor
-	var userPosition, partnerPosition int
-	
-	// Poziția utilizatorului
-	err = h.DB.QueryRow(
-		`SELECT position 
-         FROM curve_positions 
-         WHERE relationship_id = $1 AND user_id = $2`,
-		relationship.ID, userID,
-	).Scan(&userPosition)
-	
-	if err != nil && err != sql.ErrNoRows {
+	userPause, err := pause.Active(h.DB, relationship.ID, userID)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la obținerea poziției utilizatorului",
+			"message": "Eroare la obținerea pauzei",
 		})
 	}
 	
-	// Poziția partenerului
-	var partnerID uint
-	if relationship.User1ID == userID {
-		partnerID = relationship.User2ID
-	} else {
-		partnerID = relationship.User1ID
+	// Dimensiunile apropierii și valorile curente pe fiecare
+	axesState, err := h.loadAxesState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea dimensiunilor",
+		})
 	}
 	
-	err = h.DB.QueryRow(
-		`SELECT position 
-         FROM curve_positions 
-         WHERE relationship_id = $1 AND user_id = $2`,
-		relationship.ID, partnerID,
-	).Scan(&partnerPosition)
+	// Starea dezvăluirii simultane (pozițiile sigilate ale celorlalți nu sunt expuse)
+	reveal, err := h.loadRevealState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pozițiilor sigilate",
+		})
+	}
 	
-	if err != nil && err != sql.ErrNoRows {
+	// Progresul obiectivelor comune
+	goals, err := h.loadGoalsSummary(relationship.ID, userID)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la obținerea poziției partenerului",
+			"message": "Eroare la obținerea progresului",
 		})
 	}
 	
+	response := fiber.Map{
+		"relationship": relationship.ToResponse(userID, h.userLocation(userID)),
+		"members":      members,
+		"pause":        userPause,
+		"axes":         axesState,
+		"reveal":       reveal,
+		"goals":        goals,
+	}
+	
+	// Câmpurile pentru cupluri rămân compatibile cu clienții existenți
+	for _, m := range members {
+		if m.UserID == userID {
+			response["userCurvePosition"] = m.Position
+			response["userStale"] = m.Stale
+			response["userDecayed"] = m.Decayed
+		} else if relationship.IsCouple() {
+			response["partnerCurvePosition"] = m.Position
+			response["partnerStatus"] = m.Status
+			response["partnerPause"] = m.Pause
+			response["partnerStale"] = m.Stale
+			response["partnerDecayed"] = m.Decayed
+		}
+	}
+	
 	// Returnează relația și pozițiile
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"relationship":         relationship.ToResponse(userID),
-		"userCurvePosition":    userPosition,
-		"partnerCurvePosition": partnerPosition,
-	})
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-// GenerateInviteCode generează un cod de invitație
+// GenerateInviteCodeRequest reprezintă cererea de generare a unei invitații pentru o relație nouă
+type GenerateInviteCodeRequest struct {
+	Type      string  `json:"type"` // implicit romantic
+	TypeLabel *string `json:"typeLabel"`
+}
+
+// GenerateInviteCode generează un cod de invitație pentru un cuplu nou din categoria aleasă
 func (h *RelationshipHandler) GenerateInviteCode(c *fiber.Ctx) error {
 	// Obține ID-ul utilizatorului din context
 	userID, ok := c.Locals("userID").(uint)
@@ -127,30 +142,75 @@ func (h *RelationshipHandler) GenerateInviteCode(c *fiber.Ctx) error {
 		})
 	}
 	
-	// Verifică dacă utilizatorul are deja o relație
-	var exists bool
-	err := h.DB.QueryRow(
-		`SELECT EXISTS(
-            SELECT 1 FROM relationships 
-            WHERE user1_id = $1 OR user2_id = $1
-         )`,
-		userID,
-	).Scan(&exists)
+	// Parsează cererea (corpul este opțional)
+	var req GenerateInviteCodeRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Cerere invalidă",
+			})
+		}
+	}
 	
+	relationshipType, typeLabel, message := normalizeRelationshipType(req.Type, req.TypeLabel)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+	
+	// Verifică limita de relații din această categorie
+	limitReached, err := h.relationshipLimitReached(h.DB, userID, relationshipType)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
 			"message": "Eroare la verificarea relațiilor existente",
 		})
 	}
+	if limitReached {
+		return relationshipLimitError(c, relationshipType)
+	}
+	
+	return h.saveInviteCode(c, userID, nil, relationshipType, typeLabel)
+}
+
+// GenerateGroupInviteCode generează un cod de invitație care adaugă membri noi în grupul indicat
+func (h *RelationshipHandler) GenerateGroupInviteCode(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+	
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+	
+	if relationship.IsCouple() {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Un cuplu nu poate primi membri noi",
+		})
+	}
 	
-	if exists {
+	if len(relationship.Members) >= h.Config.GroupMaxMembers {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
 			"error":   true,
-			"message": "Ai deja o relație activă",
+			"message": "Grupul a atins numărul maxim de membri",
 		})
 	}
 	
+	return h.saveInviteCode(c, userID, &relationship.ID, relationship.Type, relationship.TypeLabel)
+}
+
+// saveInviteCode generează și salvează codul, înlocuind invitația anterioară a utilizatorului pentru aceeași țintă
+func (h *RelationshipHandler) saveInviteCode(c *fiber.Ctx, userID uint, groupID *uint, relationshipType string, typeLabel *string) error {
 	// Generează codul de invitație
 	code, err := utils.GenerateInviteCode()
 	if err != nil {
@@ -163,10 +223,13 @@ func (h *RelationshipHandler) GenerateInviteCode(c *fiber.Ctx) error {
 	// Calculează data de expirare
 	expiresAt := time.Now().Add(h.Config.InviteCodeExpiration)
 	
-	// Șterge orice cod de invitație existent
+	// Șterge codul existent pentru același grup, respectiv pentru un cuplu nou din aceeași categorie
 	_, err = h.DB.Exec(
-		`DELETE FROM invite_codes WHERE user_id = $1`,
-		userID,
+		`DELETE FROM invite_codes
+         WHERE user_id = $1
+           AND relationship_id IS NOT DISTINCT FROM $2
+           AND ($2::integer IS NOT NULL OR type = $3)`,
+		userID, groupID, relationshipType,
 	)
 	
 	if err != nil {
@@ -178,9 +241,9 @@ func (h *RelationshipHandler) GenerateInviteCode(c *fiber.Ctx) error {
 	
 	// Salvează codul de invitație
 	_, err = h.DB.Exec(
-		`INSERT INTO invite_codes (user_id, code, expires_at, created_at) 
-         VALUES ($1, $2, $3, NOW())`,
-		userID, code, expiresAt,
+		`INSERT INTO invite_codes (user_id, relationship_id, code, type, type_label, expires_at, created_at) 
+         VALUES ($1, $2, $3, $4, $5, $6, NOW())`,
+		userID, groupID, code, relationshipType, typeLabel, expiresAt,
 	)
 	
 	if err != nil {
@@ -190,10 +253,17 @@ func (h *RelationshipHandler) GenerateInviteCode(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Construiește link-ul semnat pentru invitație
+	inviteURL := utils.BuildInviteURL(h.Config.AppURL, code, h.inviteLinkExpiry(expiresAt), h.Config.InviteLinkSecret)
+	
 	// Returnează codul de invitație
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"inviteCode": code,
-		"expiresAt":  expiresAt,
+		"inviteCode":     code,
+		"inviteUrl":      inviteURL,
+		"expiresAt":      expiresAt,
+		"relationshipId": groupID,
+		"type":           relationshipType,
+		"typeLabel":      typeLabel,
 	})
 }
 
@@ -202,7 +272,7 @@ type UseInviteCodeRequest struct {
 	InviteCode string `json:"inviteCode" validate:"required"`
 }
 
-// UseInviteCode utilizează un cod de invitație pentru a crea o relație
+// UseInviteCode utilizează un cod de invitație pentru a crea un cuplu sau pentru a intra într-un grup
 func (h *RelationshipHandler) UseInviteCode(c *fiber.Ctx) error {
 	// Obține ID-ul utilizatorului din context
 	userID, ok := c.Locals("userID").(uint)
@@ -230,30 +300,6 @@ func (h *RelationshipHandler) UseInviteCode(c *fiber.Ctx) error {
 		})
 	}
 	
-	// Verifică dacă utilizatorul are deja o relație
-	var hasRelationship bool
-	err := h.DB.QueryRow(
-		`SELECT EXISTS(
-            SELECT 1 FROM relationships 
-            WHERE user1_id = $1 OR user2_id = $1
-         )`,
-		userID,
-	).Scan(&hasRelationship)
-	
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la verificarea relațiilor existente",
-		})
-	}
-	
-	if hasRelationship {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"error":   true,
-			"message": "Ai deja o relație activă",
-		})
-	}
-	
 	// Începe o tranzacție
 	tx, err := h.DB.Begin()
 	if err != nil {
@@ -267,11 +313,11 @@ func (h *RelationshipHandler) UseInviteCode(c *fiber.Ctx) error {
 	// Caută codul de invitație
 	var inviteCode models.InviteCode
 	err = tx.QueryRow(
-		`SELECT id, user_id, code, expires_at, created_at 
+		`SELECT id, user_id, relationship_id, code, type, type_label, expires_at, created_at 
          FROM invite_codes 
          WHERE code = $1 AND expires_at > NOW()`,
 		req.InviteCode,
-	).Scan(&inviteCode.ID, &inviteCode.UserID, &inviteCode.Code, &inviteCode.ExpiresAt, &inviteCode.CreatedAt)
+	).Scan(&inviteCode.ID, &inviteCode.UserID, &inviteCode.RelationshipID, &inviteCode.Code, &inviteCode.Type, &inviteCode.TypeLabel, &inviteCode.ExpiresAt, &inviteCode.CreatedAt)
 	
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -295,144 +341,211 @@ func (h *RelationshipHandler) UseInviteCode(c *fiber.Ctx) error {
 		})
 	}
 	
-	// Verifică dacă partenerul are deja o relație
-	var partnerHasRelationship bool
-	err = tx.QueryRow(
-		`SELECT EXISTS(
-            SELECT 1 FROM relationships 
-            WHERE user1_id = $1 OR user2_id = $1
-         )`,
-		inviteCode.UserID,
-	).Scan(&partnerHasRelationship)
-	
+	// Verifică limita de relații din categoria invitației
+	limitReached, err := h.relationshipLimitReached(tx, userID, inviteCode.Type)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la verificarea relațiilor partenerului",
+			"message": "Eroare la verificarea relațiilor existente",
 		})
 	}
-	
-	if partnerHasRelationship {
-		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
-			"error":   true,
-			"message": "Partenerul are deja o relație activă",
-		})
+	if limitReached {
+		return relationshipLimitError(c, inviteCode.Type)
 	}
 	
-	// Obține informații despre utilizatorul curent și partener
-	var currentUsername, partnerUsername string
-	
-	err = tx.QueryRow(
-		`SELECT username FROM users WHERE id = $1`,
-		userID,
-	).Scan(&currentUsername)
-	
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la obținerea informațiilor utilizatorului",
-		})
+	var relationshipID uint
+	if inviteCode.RelationshipID != nil {
+		// Invitație într-un grup: blochează grupul pentru a nu depăși numărul maxim de membri
+		relationshipID = *inviteCode.RelationshipID
+		
+		var memberCount int
+		var alreadyMember bool
+		err = tx.QueryRow(
+			`SELECT (SELECT COUNT(*) FROM relationship_members WHERE relationship_id = r.id),
+                    EXISTS(SELECT 1 FROM relationship_members WHERE relationship_id = r.id AND user_id = $2)
+             FROM relationships r
+             WHERE r.id = $1
+             FOR UPDATE`,
+			relationshipID, userID,
+		).Scan(&memberCount, &alreadyMember)
+		
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la obținerea grupului",
+			})
+		}
+		
+		if alreadyMember {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Faci deja parte din acest grup",
+			})
+		}
+		
+		if memberCount >= h.Config.GroupMaxMembers {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Grupul a atins numărul maxim de membri",
+			})
+		}
+		
+		if err := addMember(tx, relationshipID, userID, models.MemberRoleMember); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la adăugarea membrului",
+			})
+		}
+	} else {
+		// Limita poate fi atinsă și de cel care a invitat, după generarea codului
+		partnerLimitReached, err := h.relationshipLimitReached(tx, inviteCode.UserID, inviteCode.Type)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la verificarea relațiilor partenerului",
+			})
+		}
+		
+		if partnerLimitReached {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Partenerul a atins numărul maxim de relații din categoria " + inviteCode.Type,
+				"type":    inviteCode.Type,
+			})
+		}
+		
+		// Doi utilizatori formează cel mult un cuplu din aceeași categorie
+		var coupleExists bool
+		err = tx.QueryRow(
+			`SELECT EXISTS(
+                SELECT 1
+                FROM relationships r
+                JOIN relationship_members m1 ON m1.relationship_id = r.id AND m1.user_id = $1
+                JOIN relationship_members m2 ON m2.relationship_id = r.id AND m2.user_id = $2
+                WHERE r.kind = $3 AND r.type = $4
+             )`,
+			userID, inviteCode.UserID, models.RelationshipKindCouple, inviteCode.Type,
+		).Scan(&coupleExists)
+		
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la verificarea relațiilor existente",
+			})
+		}
+		
+		if coupleExists {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   true,
+				"message": "Aveți deja o relație din această categorie",
+			})
+		}
+		
+		// Creează cuplul
+		err = tx.QueryRow(
+			`INSERT INTO relationships (kind, type, type_label, start_date, created_at, updated_at) 
+             VALUES ($1, $2, $3, NOW(), NOW(), NOW()) 
+             RETURNING id`,
+			models.RelationshipKindCouple, inviteCode.Type, inviteCode.TypeLabel,
+		).Scan(&relationshipID)
+		
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la crearea relației",
+			})
+		}
+		
+		// Cel care a invitat devine proprietar; pozițiile ambilor pornesc de la 0
+		if err := addMember(tx, relationshipID, inviteCode.UserID, models.MemberRoleOwner); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la adăugarea membrilor",
+			})
+		}
+		
+		if err := addMember(tx, relationshipID, userID, models.MemberRoleMember); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la adăugarea membrilor",
+			})
+		}
+		
+		// Codul pentru un cuplu se folosește o singură dată; cel pentru un grup rămâne valabil până expiră
+		_, err = tx.Exec(
+			`DELETE FROM invite_codes WHERE id = $1`,
+			inviteCode.ID,
+		)
+		
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la ștergerea codului de invitație",
+			})
+		}
 	}
 	
-	err = tx.QueryRow(
-		`SELECT username FROM users WHERE id = $1`,
-		inviteCode.UserID,
-	).Scan(&partnerUsername)
-	
-	if err != nil {
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la obținerea informațiilor partenerului",
+			"message": "Eroare la finalizarea tranzacției",
 		})
 	}
 	
-	// Creează relația
-	var relationshipID uint
-	err = tx.QueryRow(
-		`INSERT INTO relationships (user1_id, user2_id, user1_name, user2_name, start_date, created_at, updated_at) 
-         VALUES ($1, $2, $3, $4, NOW(), NOW(), NOW()) 
-         RETURNING id`,
-		inviteCode.UserID, userID, partnerUsername, currentUsername,
-	).Scan(&relationshipID)
-	
+	// Obține relația creată
+	relationship, err := loadRelationship(h.DB, relationshipID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la crearea relației",
+			"message": "Eroare la obținerea relației create",
 		})
 	}
 	
-	// Inițializează pozițiile curbelor
-	_, err = tx.Exec(
-		`INSERT INTO curve_positions (relationship_id, user_id, position, created_at, updated_at) 
-         VALUES ($1, $2, 0, NOW(), NOW()), ($1, $3, 0, NOW(), NOW())`,
-		relationshipID, userID, inviteCode.UserID,
-	)
+	// Anunță ceilalți membri
+	member, _ := relationship.Member(userID)
+	SendToMembers(relationship, userID, "member_joined", member)
 	
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la inițializarea pozițiilor curbelor",
+	if relationship.IsCouple() {
+		enqueueWebhook(h.DB, webhooks.Event{
+			Type:           webhooks.EventRelationshipCreated,
+			RelationshipID: relationship.ID,
+			RecipientIDs:   relationship.MemberIDs(),
+			Data:           relationshipWebhookData(relationship),
 		})
 	}
 	
-	// Șterge codul de invitație
-	_, err = tx.Exec(
-		`DELETE FROM invite_codes WHERE id = $1`,
-		inviteCode.ID,
-	)
-	
+	members, err := h.loadMemberPositions(relationship, userID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la ștergerea codului de invitație",
+			"message": "Eroare la obținerea pozițiilor",
 		})
 	}
 	
-	// Commit tranzacția
-	if err := tx.Commit(); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la finalizarea tranzacției",
-		})
+	response := fiber.Map{
+		"relationship":      relationship.ToResponse(userID, h.userLocation(userID)),
+		"members":           members,
+		"userCurvePosition": 0,
 	}
-	
-	// Obține relația creată
-	var relationship models.Relationship
-	err = h.DB.QueryRow(
-		`SELECT id, user1_id, user2_id, user1_name, user2_name, start_date, created_at, updated_at 
-         FROM relationships 
-         WHERE id = $1`,
-		relationshipID,
-	).Scan(
-		&relationship.ID, 
-		&relationship.User1ID, 
-		&relationship.User2ID, 
-		&relationship.User1Name, 
-		&relationship.User2Name, 
-		&relationship.StartDate, 
-		&relationship.CreatedAt, 
-		&relationship.UpdatedAt,
-	)
-	
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la obținerea relației create",
-		})
+	if relationship.IsCouple() {
+		response["partnerCurvePosition"] = 0
 	}
 	
 	// Returnează relația creată
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
-		"relationship":         relationship.ToResponse(userID),
-		"userCurvePosition":    0,
-		"partnerCurvePosition": 0,
-	})
+	return c.Status(fiber.StatusCreated).JSON(response)
 }
 
 // UpdatePositionRequest reprezintă cererea de actualizare a poziției
 type UpdatePositionRequest struct {
-	Position int `json:"position" validate:"required,min=0,max=100"`
+	Position   int     `json:"position" validate:"required,min=0,max=100"`
+	Note       *string `json:"note"`
+	Mood       *string `json:"mood"`
+	Emoji      *string `json:"emoji"`
+	Visibility string  `json:"visibility"` // "shared" (implicit) sau "private"
+	
+	// Valorile pe dimensiuni (opțional); dacă există, position devine poziția compusă
+	Axes map[string]int `json:"axes"`
 }
 
 // UpdatePosition actualizează poziția curbei utilizatorului
@@ -463,35 +576,71 @@ func (h *RelationshipHandler) UpdatePosition(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Validează notița, starea și emoji-ul opționale
+	if message := h.normalizePositionNote(&req); message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+	
 	// Obține relația utilizatorului
-	var relationship models.Relationship
-	err := h.DB.QueryRow(
-		`SELECT id, user1_id, user2_id 
-         FROM relationships 
-         WHERE user1_id = $1 OR user2_id = $1`,
-		userID,
-	).Scan(&relationship.ID, &relationship.User1ID, &relationship.User2ID)
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
 	
+	// Calculează poziția compusă din valorile pe dimensiuni
+	message, err := h.resolveAxes(relationship.ID, userID, &req)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error":   true,
-				"message": "Nu ai o relație activă",
-			})
-		}
-		
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la obținerea relației",
+			"message": "Eroare la calcularea poziției compuse",
+		})
+	}
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+	
+	// În modul de dezvăluire simultană, poziția este sigilată până răspund toți membrii
+	if relationship.RevealTogether {
+		return h.sealPosition(c, relationship, userID, &req)
+	}
+	
+	// Poziția, istoricul și dimensiunile se salvează împreună
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+	
+	// Poziția anterioară este păstrată în istoric, pentru anularea actualizării
+	var previousPosition sql.NullInt64
+	var previousUpdatedAt sql.NullTime
+	err = tx.QueryRow(
+		`SELECT position, updated_at FROM curve_positions WHERE relationship_id = $1 AND user_id = $2 FOR UPDATE`,
+		relationship.ID, userID,
+	).Scan(&previousPosition, &previousUpdatedAt)
+	
+	if err != nil && err != sql.ErrNoRows {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea poziției",
 		})
 	}
 	
 	// Actualizează poziția curbei
-	_, err = h.DB.Exec(
+	_, err = tx.Exec(
 		`INSERT INTO curve_positions (relationship_id, user_id, position, created_at, updated_at) 
          VALUES ($1, $2, $3, NOW(), NOW()) 
          ON CONFLICT (relationship_id, user_id) 
-         DO UPDATE SET position = $3, updated_at = NOW()`,
+         DO UPDATE SET position = $3, updated_at = NOW(), decayed_position = NULL, stale_since = NULL`,
 		relationship.ID, userID, req.Position,
 	)
 	
@@ -502,33 +651,81 @@ func (h *RelationshipHandler) UpdatePosition(c *fiber.Ctx) error {
 		})
 	}
 	
-	// Determină ID-ul partenerului
-	var partnerID uint
-	if relationship.User1ID == userID {
-		partnerID = relationship.User2ID
-	} else {
-		partnerID = relationship.User1ID
+	// Salvează actualizarea în istoric
+	var eventID uint
+	err = tx.QueryRow(
+		`INSERT INTO position_events (relationship_id, user_id, position, note, mood, emoji, visibility, previous_position, previous_updated_at, created_at) 
+         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+         RETURNING id`,
+		relationship.ID, userID, req.Position, req.Note, req.Mood, req.Emoji, req.Visibility, previousPosition, previousUpdatedAt,
+	).Scan(&eventID)
+	
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea istoricului",
+		})
 	}
 	
-	// Trimite notificare prin WebSocket
+	// Salvează valorile pe dimensiuni
+	if err := axes.SaveValues(tx, relationship.ID, userID, eventID, req.Axes); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea dimensiunilor",
+		})
+	}
+	
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+	
+	// Trimite notificare prin WebSocket tuturor celorlalți membri
 	update := models.PositionUpdate{
 		RelationshipID: relationship.ID,
 		UserID:         userID,
-		PartnerID:      partnerID,
+		RecipientIDs:   relationship.MemberIDs(),
 		Position:       req.Position,
+		Axes:           req.Axes,
 	}
 	
-	// Trimite actualizarea poziției
-	BroadcastPositionUpdate(update)
+	// Notițele private nu ajung la ceilalți membri
+	if req.Visibility == models.VisibilityShared {
+		update.Note = req.Note
+		update.Mood = req.Mood
+		update.Emoji = req.Emoji
+	}
 	
-	// Returnează succes
-	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+	// În timpul unei pauze, actualizarea este salvată, dar ceilalți membri nu primesc poziția
+	userPause, err := pause.Active(h.DB, relationship.ID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pauzei",
+		})
+	}
+	update.Paused = userPause != nil
+	
+	// Trimite actualizarea poziției (eventual după închiderea ferestrei de anulare)
+	h.publishPositionUpdate(eventID, update)
+	
+	response := fiber.Map{
 		"success":  true,
 		"position": req.Position,
-	})
+		"axes":     req.Axes,
+		"eventId":  eventID,
+	}
+	if h.Config.PositionUndoWindow > 0 {
+		response["undoUntil"] = time.Now().Add(h.Config.PositionUndoWindow)
+	}
+	
+	// Returnează succes
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-// DeleteRelationship șterge relația utilizatorului
+// DeleteRelationship încheie relația indicată: un cuplu este șters, iar dintr-un grup utilizatorul doar iese
 func (h *RelationshipHandler) DeleteRelationship(c *fiber.Ctx) error {
 	// Obține ID-ul utilizatorului din context
 	userID, ok := c.Locals("userID").(uint)
@@ -539,6 +736,12 @@ func (h *RelationshipHandler) DeleteRelationship(c *fiber.Ctx) error {
 		})
 	}
 	
+	// Obține relația utilizatorului
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+	
 	// Începe o tranzacție
 	tx, err := h.DB.Begin()
 	if err != nil {
@@ -549,63 +752,96 @@ func (h *RelationshipHandler) DeleteRelationship(c *fiber.Ctx) error {
 	}
 	defer tx.Rollback()
 	
-	// Obține relația utilizatorului
-	var relationshipID uint
-	err = tx.QueryRow(
-		`SELECT id FROM relationships WHERE user1_id = $1 OR user2_id = $1`,
-		userID,
-	).Scan(&relationshipID)
+	// Un grup continuă fără utilizator, cât timp mai are alți membri
+	leaving := !relationship.IsCouple() && len(relationship.Members) > 1
 	
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+	if leaving {
+		if err := removeMember(tx, relationship, userID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error":   true,
-				"message": "Nu ai o relație activă",
+				"message": "Eroare la ieșirea din grup",
 			})
 		}
+	} else {
+		// Șterge pozițiile curbelor
+		_, err = tx.Exec(
+			`DELETE FROM curve_positions WHERE relationship_id = $1`,
+			relationship.ID,
+		)
 		
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la obținerea relației",
-		})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la ștergerea pozițiilor curbelor",
+			})
+		}
+		
+		// Șterge relația (membrii și restul datelor sunt șterse în cascadă)
+		_, err = tx.Exec(
+			`DELETE FROM relationships WHERE id = $1`,
+			relationship.ID,
+		)
+		
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la ștergerea relației",
+			})
+		}
 	}
 	
-	// Șterge pozițiile curbelor
-	_, err = tx.Exec(
-		`DELETE FROM curve_positions WHERE relationship_id = $1`,
-		relationshipID,
-	)
-	
-	if err != nil {
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   true,
-			"message": "Eroare la ștergerea pozițiilor curbelor",
+			"message": "Eroare la finalizarea tranzacției",
 		})
 	}
 	
-	// Șterge relația
-	_, err = tx.Exec(
-		`DELETE FROM relationships WHERE id = $1`,
-		relationshipID,
-	)
-	
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la ștergerea relației",
+	if leaving {
+		SendToMembers(relationship, userID, "member_left", fiber.Map{
+			"userId": userID,
 		})
 	}
 	
-	// Commit tranzacția
-	if err := tx.Commit(); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   true,
-			"message": "Eroare la finalizarea tranzacției",
-		})
+	// Pentru ceilalți membri ai unui grup relația continuă; doar cel care iese primește evenimentul
+	data := relationshipWebhookData(relationship)
+	data["endedBy"] = userID
+	data["reason"] = "deleted"
+	recipients := relationship.MemberIDs()
+	if leaving {
+		data["reason"] = "left"
+		recipients = []uint{userID}
 	}
+	enqueueWebhook(h.DB, webhooks.Event{
+		Type:           webhooks.EventRelationshipEnded,
+		RelationshipID: relationship.ID,
+		RecipientIDs:   recipients,
+		Data:           data,
+	})
 	
 	// Returnează succes
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
 	})
+}
+
+// relationshipLookupError transformă eroarea de încărcare a relației în răspunsul HTTP potrivit
+func relationshipLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Relația nu a fost găsită",
+		})
+	}
+	
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea relației",
+	})
+}
+
+// userLocation returnează fusul orar în care se calculează zilele pentru utilizator
+func (h *RelationshipHandler) userLocation(userID uint) *time.Location {
+	return loadUserLocation(h.DB, h.Config, userID)
 }
\ No newline at end of file
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/db"
+	"relationship-helix/internal/models"
+)
+
+// maxTypeLabelLength este lungimea maximă a etichetei pentru categoria custom
+const maxTypeLabelLength = 50
+
+// RelationshipSummary este o intrare din lista relațiilor utilizatorului
+type RelationshipSummary struct {
+	Relationship      models.RelationshipResponse `json:"relationship"`
+	Members           []MemberPosition            `json:"members"`
+	UserCurvePosition int                         `json:"userCurvePosition"`
+}
+
+// ListRelationships returnează toate relațiile utilizatorului curent, opțional filtrate după categorie (?type=)
+func (h *RelationshipHandler) ListRelationships(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationshipType := c.Query("type")
+	if relationshipType != "" && !models.IsRelationshipType(relationshipType) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Categoria relației trebuie să fie una dintre: " + strings.Join(models.RelationshipTypes, ", "),
+		})
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT m.relationship_id
+         FROM relationship_members m
+         JOIN relationships r ON r.id = m.relationship_id
+         WHERE m.user_id = $1 AND ($2::text = '' OR r.type = $2)
+         ORDER BY m.joined_at, m.id`,
+		userID, relationshipType,
+	)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea relațiilor",
+		})
+	}
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea relațiilor",
+			})
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea relațiilor",
+		})
+	}
+
+	loc := h.userLocation(userID)
+	relationships := make([]RelationshipSummary, 0, len(ids))
+	for _, id := range ids {
+		relationship, err := loadRelationship(h.DB, id)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la obținerea relațiilor",
+			})
+		}
+
+		members, err := h.loadMemberPositions(relationship, userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la obținerea pozițiilor",
+			})
+		}
+
+		summary := RelationshipSummary{
+			Relationship: relationship.ToResponse(userID, loc),
+			Members:      members,
+		}
+		for _, m := range members {
+			if m.UserID == userID && m.Position != nil {
+				summary.UserCurvePosition = *m.Position
+			}
+		}
+		relationships = append(relationships, summary)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"relationships": relationships,
+		"limits":        h.Config.RelationshipLimits,
+	})
+}
+
+// DefaultRelationship este middleware-ul rutelor vechi /api/relationship, folosite de clienții care cunosc
+// o singură relație: alege relația în care utilizatorul a intrat primul, în locul parametrului :id
+func (h *RelationshipHandler) DefaultRelationship(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	var relationshipID uint
+	err := h.DB.QueryRow(
+		`SELECT relationship_id
+         FROM relationship_members
+         WHERE user_id = $1
+         ORDER BY joined_at, id
+         LIMIT 1`,
+		userID,
+	).Scan(&relationshipID)
+
+	if err != nil && err != sql.ErrNoRows {
+		return relationshipLookupError(c, err)
+	}
+
+	// Fără relație, ID-ul rămâne 0 și findRelationship returnează sql.ErrNoRows
+	c.Locals("defaultRelationshipID", relationshipID)
+	return c.Next()
+}
+
+// findRelationship încarcă relația indicată de parametrul :id (sau relația implicită, pe rutele vechi),
+// dacă utilizatorul face parte din ea (sql.ErrNoRows dacă ID-ul nu este valid, relația nu există sau
+// utilizatorul nu este membru)
+func (h *RelationshipHandler) findRelationship(c *fiber.Ctx, userID uint) (*models.Relationship, error) {
+	if defaultID, ok := c.Locals("defaultRelationshipID").(uint); ok && c.Params("id") == "" {
+		if defaultID == 0 {
+			return nil, sql.ErrNoRows
+		}
+		return h.memberRelationship(defaultID, userID)
+	}
+
+	relationshipID, err := strconv.ParseUint(c.Params("id"), 10, 64)
+	if err != nil || relationshipID == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return h.memberRelationship(uint(relationshipID), userID)
+}
+
+// memberRelationship încarcă relația, dacă utilizatorul face parte din ea
+func (h *RelationshipHandler) memberRelationship(relationshipID uint, userID uint) (*models.Relationship, error) {
+
+	relationship, err := loadRelationship(h.DB, relationshipID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Relațiile altor utilizatori nu se deosebesc de cele inexistente
+	if !relationship.IsMember(userID) {
+		return nil, sql.ErrNoRows
+	}
+
+	return relationship, nil
+}
+
+// normalizeRelationshipType validează categoria și eticheta ei; returnează un mesaj de eroare dacă nu sunt valide.
+// Categoria lipsă devine romantic, iar eticheta este păstrată doar pentru categoria custom.
+func normalizeRelationshipType(relationshipType string, label *string) (string, *string, string) {
+	if relationshipType == "" {
+		relationshipType = models.RelationshipTypeRomantic
+	}
+
+	if !models.IsRelationshipType(relationshipType) {
+		return "", nil, "Categoria relației trebuie să fie una dintre: " + strings.Join(models.RelationshipTypes, ", ")
+	}
+
+	if relationshipType != models.RelationshipTypeCustom {
+		return relationshipType, nil, ""
+	}
+
+	if label == nil || strings.TrimSpace(*label) == "" {
+		return "", nil, "Eticheta este obligatorie pentru categoria custom"
+	}
+
+	trimmed := strings.TrimSpace(*label)
+	if len([]rune(trimmed)) > maxTypeLabelLength {
+		return "", nil, "Eticheta poate avea cel mult " + strconv.Itoa(maxTypeLabelLength) + " de caractere"
+	}
+
+	return relationshipType, &trimmed, ""
+}
+
+// relationshipLimitReached verifică dacă utilizatorul a atins numărul maxim de relații din categoria dată
+func (h *RelationshipHandler) relationshipLimitReached(q db.Queryer, userID uint, relationshipType string) (bool, error) {
+	limit := h.Config.RelationshipLimit(relationshipType)
+	if limit <= 0 {
+		return false, nil
+	}
+
+	var count int
+	err := q.QueryRow(
+		`SELECT COUNT(*)
+         FROM relationship_members m
+         JOIN relationships r ON r.id = m.relationship_id
+         WHERE m.user_id = $1 AND r.type = $2`,
+		userID, relationshipType,
+	).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+
+	return count >= limit, nil
+}
+
+// relationshipLimitError returnează răspunsul pentru limita de relații atinsă
+func relationshipLimitError(c *fiber.Ctx, relationshipType string) error {
+	return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+		"error":   true,
+		"message": "Ai atins numărul maxim de relații din categoria " + relationshipType,
+		"type":    relationshipType,
+	})
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/reminders"
+)
+
+// maxSnoozeMinutes este durata maximă a unei amânări (o zi)
+const maxSnoozeMinutes = 24 * 60
+
+// UpdateRemindersRequest reprezintă cererea de actualizare a mementoului zilnic (câmpurile lipsă nu se modifică).
+// Intervalul de liniște se setează cu ambele capete, sau se elimină cu valori goale.
+type UpdateRemindersRequest struct {
+	Enabled    *bool   `json:"enabled"`
+	Time       *string `json:"time"`
+	QuietStart *string `json:"quietStart"`
+	QuietEnd   *string `json:"quietEnd"`
+}
+
+// SnoozeReminderRequest reprezintă cererea de amânare a mementoului
+type SnoozeReminderRequest struct {
+	Minutes *int `json:"minutes"`
+}
+
+// GetReminders returnează preferințele de memento zilnic ale utilizatorului curent
+func (h *SettingsHandler) GetReminders(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	return h.remindersResponse(c, userID)
+}
+
+// UpdateReminders actualizează ora mementoului, intervalul de liniște sau renunțarea la mementouri
+func (h *SettingsHandler) UpdateReminders(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdateRemindersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	settings, err := reminders.Load(h.DB, userID, reminders.ParseDefault(h.Config.ReminderDefaultTime))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea mementourilor",
+		})
+	}
+
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+
+	if req.Time != nil {
+		remindAt, err := reminders.ParseClock(*req.Time)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Ora mementoului trebuie să aibă formatul HH:MM",
+			})
+		}
+		settings.RemindAt = remindAt
+	}
+
+	if req.QuietStart != nil || req.QuietEnd != nil {
+		if req.QuietStart == nil || req.QuietEnd == nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Intervalul de liniște necesită atât începutul, cât și sfârșitul",
+			})
+		}
+
+		if *req.QuietStart == "" && *req.QuietEnd == "" {
+			settings.QuietStart, settings.QuietEnd = nil, nil
+		} else {
+			start, startErr := reminders.ParseClock(*req.QuietStart)
+			end, endErr := reminders.ParseClock(*req.QuietEnd)
+			if startErr != nil || endErr != nil || start == end {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   true,
+					"message": "Intervalul de liniște trebuie să aibă două ore diferite, în formatul HH:MM",
+				})
+			}
+			settings.QuietStart, settings.QuietEnd = &start, &end
+		}
+	}
+
+	_, err = h.DB.Exec(
+		`INSERT INTO checkin_reminders (user_id, enabled, remind_at, quiet_start, quiet_end, updated_at)
+         VALUES ($1, $2, $3, $4, $5, NOW())
+         ON CONFLICT (user_id)
+         DO UPDATE SET enabled = $2, remind_at = $3, quiet_start = $4, quiet_end = $5, updated_at = NOW()`,
+		userID, settings.Enabled, settings.RemindAt, settings.QuietStart, settings.QuietEnd,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea mementourilor",
+		})
+	}
+
+	return h.remindersResponse(c, userID)
+}
+
+// SnoozeReminder amână mementoul cu numărul de minute dat (implicit din configurație)
+func (h *SettingsHandler) SnoozeReminder(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea (corpul este opțional)
+	var req SnoozeReminderRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Cerere invalidă",
+			})
+		}
+	}
+
+	minutes := h.Config.ReminderSnoozeMinutes
+	if req.Minutes != nil {
+		minutes = *req.Minutes
+	}
+
+	if minutes < 1 || minutes > maxSnoozeMinutes {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Amânarea trebuie să fie între 1 și " + strconv.Itoa(maxSnoozeMinutes) + " de minute",
+		})
+	}
+
+	snoozedUntil := time.Now().Add(time.Duration(minutes) * time.Minute)
+	_, err := h.DB.Exec(
+		`INSERT INTO checkin_reminders (user_id, snoozed_until, updated_at)
+         VALUES ($1, $2, NOW())
+         ON CONFLICT (user_id)
+         DO UPDATE SET snoozed_until = $2, updated_at = NOW()`,
+		userID, snoozedUntil,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la amânarea mementoului",
+		})
+	}
+
+	return h.remindersResponse(c, userID)
+}
+
+// CancelSnooze anulează amânarea mementoului
+func (h *SettingsHandler) CancelSnooze(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	_, err := h.DB.Exec(
+		`UPDATE checkin_reminders SET snoozed_until = NULL, updated_at = NOW() WHERE user_id = $1`,
+		userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la anularea amânării",
+		})
+	}
+
+	return h.remindersResponse(c, userID)
+}
+
+// remindersResponse returnează preferințele de memento curente ale utilizatorului
+func (h *SettingsHandler) remindersResponse(c *fiber.Ctx, userID uint) error {
+	settings, err := reminders.Load(h.DB, userID, reminders.ParseDefault(h.Config.ReminderDefaultTime))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea mementourilor",
+		})
+	}
+
+	response := models.ReminderSettings{
+		Enabled:    settings.Enabled,
+		Time:       reminders.FormatClock(settings.RemindAt),
+		LastSentAt: settings.LastSentAt,
+	}
+	if settings.QuietStart != nil && settings.QuietEnd != nil {
+		start, end := reminders.FormatClock(*settings.QuietStart), reminders.FormatClock(*settings.QuietEnd)
+		response.QuietStart, response.QuietEnd = &start, &end
+	}
+
+	// O amânare expirată nu mai este relevantă
+	if settings.SnoozedUntil != nil && settings.SnoozedUntil.After(time.Now()) {
+		response.SnoozedUntil = settings.SnoozedUntil
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"reminders": response,
+	})
+}
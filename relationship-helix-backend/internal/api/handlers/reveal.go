@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/reveal"
+)
+
+// Limitele ferestrei de dezvăluire, în minute
+const (
+	minRevealWindowMinutes = 5
+	maxRevealWindowMinutes = 7 * 24 * 60
+)
+
+// RevealState descrie starea dezvăluirii simultane din perspectiva utilizatorului
+type RevealState struct {
+	Enabled            bool       `json:"enabled"`
+	WindowMinutes      int        `json:"windowMinutes"`
+	UserSealedPosition *int       `json:"userSealedPosition"`
+	PartnerSubmitted   bool       `json:"partnerSubmitted"` // Cel puțin un alt membru a răspuns
+	SubmittedCount     int        `json:"submittedCount"`
+	MemberCount        int        `json:"memberCount"`
+	Deadline           *time.Time `json:"deadline"`
+}
+
+// loadRevealState încarcă pozițiile sigilate ale relației; valorile celorlalți membri nu sunt expuse
+func (h *RelationshipHandler) loadRevealState(relationship *models.Relationship, userID uint) (*RevealState, error) {
+	state := &RevealState{
+		Enabled:       relationship.RevealTogether,
+		WindowMinutes: relationship.RevealWindowMinutes,
+		MemberCount:   len(relationship.Members),
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT user_id, position, created_at FROM sealed_positions WHERE relationship_id = $1`,
+		relationship.ID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var first *time.Time
+	for rows.Next() {
+		var sealedUserID uint
+		var position int
+		var createdAt time.Time
+		if err := rows.Scan(&sealedUserID, &position, &createdAt); err != nil {
+			return nil, err
+		}
+
+		if sealedUserID == userID {
+			state.UserSealedPosition = &position
+		} else {
+			state.PartnerSubmitted = true
+		}
+		state.SubmittedCount++
+		if first == nil || createdAt.Before(*first) {
+			first = &createdAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Termenul curge de la prima poziție sigilată
+	if first != nil {
+		deadline := reveal.Deadline(*first, relationship.RevealWindowMinutes)
+		state.Deadline = &deadline
+	}
+
+	return state, nil
+}
+
+// sealPosition salvează poziția utilizatorului fără să o trimită celorlalți membri.
+// Când toți membrii au răspuns, pozițiile sunt dezvăluite imediat.
+func (h *RelationshipHandler) sealPosition(c *fiber.Ctx, relationship *models.Relationship, userID uint, req *UpdatePositionRequest) error {
+	// O nouă poziție o înlocuiește pe cea sigilată anterior, dar termenul rămâne același
+	var axesJSON []byte
+	if len(req.Axes) > 0 {
+		axesJSON, _ = json.Marshal(req.Axes)
+	}
+
+	_, err := h.DB.Exec(
+		`INSERT INTO sealed_positions (relationship_id, user_id, position, note, mood, emoji, visibility, axes, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW(), NOW())
+         ON CONFLICT (relationship_id, user_id)
+         DO UPDATE SET position = $3, note = $4, mood = $5, emoji = $6, visibility = $7, axes = $8, updated_at = NOW()`,
+		relationship.ID, userID, req.Position, req.Note, req.Mood, req.Emoji, req.Visibility, axesJSON,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la sigilarea poziției",
+		})
+	}
+
+	state, err := h.loadRevealState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pozițiilor sigilate",
+		})
+	}
+
+	// Toți membrii au răspuns (sau termenul a expirat între două verificări ale job-ului): pozițiile se dezvăluie împreună
+	if state.Deadline != nil && reveal.Due(state.SubmittedCount, state.MemberCount, *state.Deadline, time.Now()) {
+		if _, err := reveal.Reveal(h.DB, relationship.ID, SendToUser); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la dezvăluirea pozițiilor",
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"success":  true,
+			"position": req.Position,
+			"revealed": true,
+		})
+	}
+
+	// Ceilalți membri află doar că există un răspuns, nu și valoarea lui
+	SendToMembers(relationship, userID, "position_sealed", fiber.Map{
+		"userId":         userID,
+		"submittedCount": state.SubmittedCount,
+		"memberCount":    state.MemberCount,
+		"deadline":       state.Deadline,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success":  true,
+		"position": req.Position,
+		"sealed":   true,
+		"deadline": state.Deadline,
+	})
+}
+
+// RevealModeRequest reprezintă cererea de configurare a dezvăluirii simultane
+type RevealModeRequest struct {
+	Enabled       bool `json:"enabled"`
+	WindowMinutes *int `json:"windowMinutes"`
+}
+
+// SetRevealMode activează sau dezactivează dezvăluirea simultană pentru relație
+func (h *RelationshipHandler) SetRevealMode(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req RevealModeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	windowMinutes := relationship.RevealWindowMinutes
+	if req.WindowMinutes != nil {
+		if *req.WindowMinutes < minRevealWindowMinutes || *req.WindowMinutes > maxRevealWindowMinutes {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Fereastra de dezvăluire trebuie să fie între 5 minute și 7 zile",
+			})
+		}
+		windowMinutes = *req.WindowMinutes
+	}
+
+	err = h.DB.QueryRow(
+		`UPDATE relationships
+         SET reveal_together = $2, reveal_window_minutes = $3, updated_at = NOW()
+         WHERE id = $1
+         RETURNING reveal_together, reveal_window_minutes, updated_at`,
+		relationship.ID, req.Enabled, windowMinutes,
+	).Scan(&relationship.RevealTogether, &relationship.RevealWindowMinutes, &relationship.UpdatedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea modului de dezvăluire",
+		})
+	}
+
+	// La dezactivare, pozițiile aflate încă sub sigiliu sunt dezvăluite imediat
+	if !relationship.RevealTogether {
+		if _, err := reveal.Reveal(h.DB, relationship.ID, SendToUser); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la dezvăluirea pozițiilor",
+			})
+		}
+	}
+
+	state, err := h.loadRevealState(relationship, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea pozițiilor sigilate",
+		})
+	}
+
+	// Anunță ceilalți membri despre noua configurație
+	SendToMembers(relationship, userID, "reveal_mode_changed", fiber.Map{
+		"enabled":       relationship.RevealTogether,
+		"windowMinutes": relationship.RevealWindowMinutes,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"reveal": state,
+	})
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/utils"
+)
+
+// SettingsHandler gestionează preferințele utilizatorului
+type SettingsHandler struct {
+	DB     *sql.DB
+	Config *config.Config
+}
+
+// NewSettingsHandler creează un nou handler de preferințe
+func NewSettingsHandler(db *sql.DB, cfg *config.Config) *SettingsHandler {
+	return &SettingsHandler{
+		DB:     db,
+		Config: cfg,
+	}
+}
+
+// GetSettings returnează preferințele utilizatorului curent
+func (h *SettingsHandler) GetSettings(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	settings, err := h.loadSettings(userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "Utilizatorul nu a fost găsit",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea preferințelor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"settings": settings,
+	})
+}
+
+// UpdateSettingsRequest reprezintă cererea de actualizare a preferințelor (câmpurile lipsă nu se modifică)
+type UpdateSettingsRequest struct {
+	Timezone *string `json:"timezone"`
+}
+
+// UpdateSettings actualizează preferințele utilizatorului curent
+func (h *SettingsHandler) UpdateSettings(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdateSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	if req.Timezone != nil {
+		// Un fus orar gol revine la fusul orar implicit
+		if *req.Timezone != "" && !utils.IsValidTimezone(*req.Timezone) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": "Fus orar invalid",
+			})
+		}
+
+		_, err := h.DB.Exec(
+			`UPDATE users SET timezone = NULLIF($2, ''), updated_at = NOW() WHERE id = $1`,
+			userID, *req.Timezone,
+		)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la actualizarea fusului orar",
+			})
+		}
+	}
+
+	settings, err := h.loadSettings(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea preferințelor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"settings": settings,
+	})
+}
+
+// loadSettings încarcă preferințele utilizatorului din baza de date
+func (h *SettingsHandler) loadSettings(userID uint) (*models.UserSettings, error) {
+	var settings models.UserSettings
+	err := h.DB.QueryRow(
+		`SELECT COALESCE(timezone, '') FROM users WHERE id = $1`,
+		userID,
+	).Scan(&settings.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.EffectiveTimezone = h.Config.Location(settings.Timezone).String()
+	return &settings, nil
+}
+
+// loadUserLocation returnează fusul orar al utilizatorului, sau pe cel implicit dacă nu este setat
+func loadUserLocation(db *sql.DB, cfg *config.Config, userID uint) *time.Location {
+	var timezone sql.NullString
+	if err := db.QueryRow(`SELECT timezone FROM users WHERE id = $1`, userID).Scan(&timezone); err != nil {
+		return cfg.DefaultLocation()
+	}
+	return cfg.Location(timezone.String)
+}
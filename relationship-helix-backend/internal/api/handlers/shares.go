@@ -0,0 +1,865 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/history"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/stats"
+)
+
+const (
+	// Lungimea maximă a etichetei unui acces partajat
+	maxShareLabelLength = 100
+
+	// Durata implicită și maximă (în zile) a unui acces partajat
+	defaultShareDays = 30
+	maxShareDays     = 90
+
+	// Numărul maxim de accese partajate neexpirate și nerevocate ale unei relații
+	maxOpenShareGrants = 10
+
+	// Numărul implicit și maxim de accesări returnate pe pagină din jurnal
+	defaultShareAccessLimit = 50
+	maxShareAccessLimit     = 200
+)
+
+// ShareGrantRequest reprezintă cererea de creare a unui acces partajat
+type ShareGrantRequest struct {
+	Label         string `json:"label"`
+	ExpiresInDays *int   `json:"expiresInDays"`
+}
+
+// shareGrantColumns sunt coloanele citite de scanShareGrant
+const shareGrantColumns = `g.id, g.relationship_id, g.created_by, g.label, g.expires_at, g.revoked_at, g.revoked_by, g.created_at,
+       (SELECT MAX(l.accessed_at) FROM share_access_log l WHERE l.grant_id = g.id)`
+
+// GetShareGrants returnează accesele partajate ale relației, cu aprobările și starea fiecăruia
+func (h *RelationshipHandler) GetShareGrants(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	grants, err := h.loadShareGrants(relationship, 0)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea acceselor partajate",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"shares": grants,
+	})
+}
+
+// CreateShareGrant creează un acces partajat, aprobat deja de creator. Token-ul este returnat o singură dată;
+// accesul devine activ după ce îl aprobă toți ceilalți membri.
+func (h *RelationshipHandler) CreateShareGrant(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req ShareGrantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	label := strings.TrimSpace(req.Label)
+	if label == "" || utf8.RuneCountInString(label) > maxShareLabelLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eticheta trebuie să aibă între 1 și " + strconv.Itoa(maxShareLabelLength) + " caractere",
+		})
+	}
+
+	days := defaultShareDays
+	if req.ExpiresInDays != nil {
+		days = *req.ExpiresInDays
+	}
+	if days < 1 || days > maxShareDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Durata accesului trebuie să fie între 1 și " + strconv.Itoa(maxShareDays) + " zile",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	var open int
+	err = h.DB.QueryRow(
+		`SELECT COUNT(*) FROM share_grants WHERE relationship_id = $1 AND revoked_at IS NULL AND expires_at > NOW()`,
+		relationship.ID,
+	).Scan(&open)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea acceselor partajate",
+		})
+	}
+
+	if open >= maxOpenShareGrants {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Relația are deja " + strconv.Itoa(maxOpenShareGrants) + " accese partajate active sau în așteptare",
+		})
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la generarea link-ului",
+		})
+	}
+	token := hex.EncodeToString(buf)
+
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	var grantID uint
+	err = tx.QueryRow(
+		`INSERT INTO share_grants (relationship_id, created_by, label, token_hash, expires_at, created_at)
+         VALUES ($1, $2, $3, $4, $5, NOW())
+         RETURNING id`,
+		relationship.ID, userID, label, shareTokenHash(token), time.Now().AddDate(0, 0, days),
+	).Scan(&grantID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea accesului partajat",
+		})
+	}
+
+	if _, err := tx.Exec(`INSERT INTO share_grant_approvals (grant_id, user_id) VALUES ($1, $2)`, grantID, userID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea aprobării",
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	grants, err := h.loadShareGrants(relationship, grantID)
+	if err != nil || len(grants) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea accesului partajat",
+		})
+	}
+
+	// Ceilalți membri trebuie să aprobe accesul
+	SendToMembers(relationship, userID, "share_requested", fiber.Map{
+		"userId": userID,
+		"share":  grants[0],
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"share": grants[0],
+		"token": token,
+		"url":   h.Config.PublicURL + "/api/shared/" + token,
+	})
+}
+
+// ApproveShareGrant înregistrează aprobarea utilizatorului pentru accesul partajat
+func (h *RelationshipHandler) ApproveShareGrant(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	grant, err := h.findShareGrant(c, relationship)
+	if err != nil {
+		return shareGrantLookupError(c, err)
+	}
+
+	if grant.Status == models.ShareStatusRevoked || grant.Status == models.ShareStatusExpired {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Accesul partajat a fost revocat sau a expirat",
+		})
+	}
+
+	_, err = h.DB.Exec(
+		`INSERT INTO share_grant_approvals (grant_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		grant.ID, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea aprobării",
+		})
+	}
+
+	grants, err := h.loadShareGrants(relationship, grant.ID)
+	if err != nil || len(grants) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea accesului partajat",
+		})
+	}
+
+	SendToMembers(relationship, userID, "share_approved", fiber.Map{
+		"userId": userID,
+		"share":  grants[0],
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"share": grants[0],
+	})
+}
+
+// RevokeShareGrant revocă accesul partajat; orice membru îl poate revoca (sau refuza, cât timp așteaptă aprobarea)
+func (h *RelationshipHandler) RevokeShareGrant(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	grant, err := h.findShareGrant(c, relationship)
+	if err != nil {
+		return shareGrantLookupError(c, err)
+	}
+
+	_, err = h.DB.Exec(
+		`UPDATE share_grants SET revoked_at = NOW(), revoked_by = $2 WHERE id = $1 AND revoked_at IS NULL`,
+		grant.ID, userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la revocarea accesului partajat",
+		})
+	}
+
+	grants, err := h.loadShareGrants(relationship, grant.ID)
+	if err != nil || len(grants) == 0 {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea accesului partajat",
+		})
+	}
+
+	if grant.Status != models.ShareStatusRevoked {
+		SendToMembers(relationship, userID, "share_revoked", fiber.Map{
+			"userId": userID,
+			"share":  grants[0],
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"share": grants[0],
+	})
+}
+
+// GetShareAccessLog returnează accesările făcute prin accesul partajat, cele mai noi primele.
+// Paginarea se face cu ?cursor= (valoarea nextCursor).
+func (h *RelationshipHandler) GetShareAccessLog(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultShareAccessLimit)))
+	if err != nil || limit < 1 || limit > maxShareAccessLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxShareAccessLimit),
+		})
+	}
+
+	cursor, err := strconv.ParseUint(c.Query("cursor", "0"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul cursor este invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	grant, err := h.findShareGrant(c, relationship)
+	if err != nil {
+		return shareGrantLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, grant_id, resource, ip_address, user_agent, accessed_at
+         FROM share_access_log
+         WHERE grant_id = $1 AND ($2 = 0 OR id < $2)
+         ORDER BY id DESC
+         LIMIT $3`,
+		grant.ID, cursor, limit,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea jurnalului de accesări",
+		})
+	}
+	defer rows.Close()
+
+	accesses := []models.ShareAccess{}
+	for rows.Next() {
+		var a models.ShareAccess
+		var ip, userAgent sql.NullString
+		if err := rows.Scan(&a.ID, &a.GrantID, &a.Resource, &ip, &userAgent, &a.AccessedAt); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea jurnalului de accesări",
+			})
+		}
+		if ip.Valid {
+			a.IPAddress = &ip.String
+		}
+		if userAgent.Valid {
+			a.UserAgent = &userAgent.String
+		}
+		accesses = append(accesses, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea jurnalului de accesări",
+		})
+	}
+
+	var nextCursor *string
+	if len(accesses) == limit {
+		next := strconv.FormatUint(uint64(accesses[len(accesses)-1].ID), 10)
+		nextCursor = &next
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"share":      grant,
+		"accesses":   accesses,
+		"nextCursor": nextCursor,
+	})
+}
+
+// GetSharedOverview returnează, pentru persoana cu acces partajat, datele generale ale relației (public,
+// protejat prin token-ul din URL)
+func (h *RelationshipHandler) GetSharedOverview(c *fiber.Ctx) error {
+	grant, relationship, err := h.openSharedAccess(c, models.ShareResourceOverview)
+	if err != nil {
+		return sharedAccessError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"label":     grant.Label,
+		"expiresAt": grant.ExpiresAt,
+		"relationship": fiber.Map{
+			"kind":      relationship.Kind,
+			"name":      relationship.Name,
+			"type":      relationship.Type,
+			"typeLabel": relationship.TypeLabel,
+			"startDate": relationship.StartDate,
+			"members":   relationship.Members,
+		},
+		"resources": []string{models.ShareResourceHistory, models.ShareResourceStats, models.ShareResourceNotes},
+	})
+}
+
+// GetSharedHistory returnează istoricul pozițiilor a doi membri (implicit primii doi), grupat pe zile în fusul
+// orar dat prin ?timezone= (implicit cel al serverului)
+func (h *RelationshipHandler) GetSharedHistory(c *fiber.Ctx) error {
+	days, err := strconv.Atoi(c.Query("days", strconv.Itoa(defaultHistoryDays)))
+	if err != nil || days < 1 || days > maxHistoryDays {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul days trebuie să fie între 1 și " + strconv.Itoa(maxHistoryDays),
+		})
+	}
+
+	_, relationship, err := h.openSharedAccess(c, models.ShareResourceHistory)
+	if err != nil {
+		return sharedAccessError(c, err)
+	}
+
+	userID, memberID, ok := sharedMemberPair(c, relationship)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrii userId și memberId trebuie să fie ID-urile a doi membri diferiți ai relației",
+		})
+	}
+
+	axisKey := c.Query("axis")
+	if message, err := h.checkAxisParam(relationship.ID, axisKey); message != "" || err != nil {
+		return axisParamError(c, message, err)
+	}
+
+	loc := h.Config.Location(c.Query("timezone"))
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, loc)
+
+	// Persoana cu acces partajat nu vede actualizările din timpul unei pauze active
+	events, err := h.loadHistoryEvents(relationship.ID, axisKey, from, 0, userID, memberID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"userId":   userID,
+		"memberId": memberID,
+		"axis":     axisKey,
+		"timezone": loc.String(),
+		"days":     history.BucketByDay(events, userID, loc),
+	})
+}
+
+// GetSharedStats returnează statisticile a doi membri (implicit primii doi) pentru persoana cu acces partajat
+func (h *RelationshipHandler) GetSharedStats(c *fiber.Ctx) error {
+	window := c.Query("window", "30d")
+	duration, ok := statsWindows[window]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Fereastra trebuie să fie una dintre: 7d, 30d, 90d, 365d, all",
+		})
+	}
+
+	_, relationship, err := h.openSharedAccess(c, models.ShareResourceStats)
+	if err != nil {
+		return sharedAccessError(c, err)
+	}
+
+	userID, memberID, ok := sharedMemberPair(c, relationship)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrii userId și memberId trebuie să fie ID-urile a doi membri diferiți ai relației",
+		})
+	}
+
+	axisKey := c.Query("axis")
+	if message, err := h.checkAxisParam(relationship.ID, axisKey); message != "" || err != nil {
+		return axisParamError(c, message, err)
+	}
+
+	to := time.Now()
+	from := relationship.CreatedAt
+	if duration > 0 && to.Add(-duration).After(from) {
+		from = to.Add(-duration)
+	}
+
+	events, err := h.loadStatsEvents(relationship.ID, axisKey, from, to, 0, userID, memberID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	report := stats.Compute(events, userID, memberID, from, to, stats.Options{
+		Location: h.Config.Location(c.Query("timezone")),
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"window":   window,
+		"userId":   userID,
+		"memberId": memberID,
+		"axis":     axisKey,
+		"bands":    stats.DefaultBands,
+		"stats":    report,
+	})
+}
+
+// GetSharedNotes returnează notițele partajate ale membrilor, cele mai noi primele; notițele private nu sunt
+// niciodată expuse. Paginarea se face după ID, cu ?before=.
+func (h *RelationshipHandler) GetSharedNotes(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultEventsLimit)))
+	if err != nil || limit < 1 || limit > maxEventsLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxEventsLimit),
+		})
+	}
+
+	before, err := strconv.Atoi(c.Query("before", "0"))
+	if err != nil || before < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul before este invalid",
+		})
+	}
+
+	_, relationship, err := h.openSharedAccess(c, models.ShareResourceNotes)
+	if err != nil {
+		return sharedAccessError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT e.id, e.relationship_id, e.user_id, e.position, e.note, e.mood, e.emoji, e.visibility, e.created_at
+         FROM position_events e
+         WHERE e.relationship_id = $1 AND ($2 = 0 OR e.id < $2)
+           AND e.visibility = $4
+           AND (e.note IS NOT NULL OR e.mood IS NOT NULL OR e.emoji IS NOT NULL)
+           AND e.reverted_at IS NULL AND e.reverts_event_id IS NULL
+           AND `+pause.VisibleCondition("e", "0")+`
+         ORDER BY e.id DESC
+         LIMIT $3`,
+		relationship.ID, before, limit, models.VisibilityShared,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea notițelor",
+		})
+	}
+	defer rows.Close()
+
+	notes := []models.PositionEvent{}
+	for rows.Next() {
+		var e models.PositionEvent
+		if err := rows.Scan(&e.ID, &e.RelationshipID, &e.UserID, &e.Position, &e.Note, &e.Mood, &e.Emoji, &e.Visibility, &e.CreatedAt); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea notițelor",
+			})
+		}
+		notes = append(notes, e)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea notițelor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"notes": notes,
+	})
+}
+
+// loadShareGrants încarcă accesele partajate ale relației (sau doar accesul grantID, dacă nu este 0),
+// cele mai noi primele, cu aprobările și starea calculată față de membrii actuali
+func (h *RelationshipHandler) loadShareGrants(relationship *models.Relationship, grantID uint) ([]models.ShareGrant, error) {
+	rows, err := h.DB.Query(
+		`SELECT `+shareGrantColumns+`
+         FROM share_grants g
+         WHERE g.relationship_id = $1 AND ($2 = 0 OR g.id = $2)
+         ORDER BY g.id DESC`,
+		relationship.ID, grantID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	grants := []models.ShareGrant{}
+	index := make(map[uint]int)
+	var ids []int64
+	for rows.Next() {
+		grant, err := scanShareGrant(rows)
+		if err != nil {
+			return nil, err
+		}
+		index[grant.ID] = len(grants)
+		ids = append(ids, int64(grant.ID))
+		grants = append(grants, *grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return grants, nil
+	}
+
+	approvalRows, err := h.DB.Query(
+		`SELECT grant_id, user_id, approved_at
+         FROM share_grant_approvals
+         WHERE grant_id = ANY($1)
+         ORDER BY approved_at`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer approvalRows.Close()
+
+	for approvalRows.Next() {
+		var id uint
+		var a models.ShareApproval
+		if err := approvalRows.Scan(&id, &a.UserID, &a.ApprovedAt); err != nil {
+			return nil, err
+		}
+		if i, ok := index[id]; ok {
+			grants[i].Approvals = append(grants[i].Approvals, a)
+		}
+	}
+	if err := approvalRows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range grants {
+		grants[i].Status = shareStatus(&grants[i], relationship, time.Now())
+	}
+	return grants, nil
+}
+
+// findShareGrant încarcă accesul partajat indicat de parametrul :shareId din relație
+func (h *RelationshipHandler) findShareGrant(c *fiber.Ctx, relationship *models.Relationship) (*models.ShareGrant, error) {
+	shareID, err := c.ParamsInt("shareId")
+	if err != nil || shareID <= 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	grants, err := h.loadShareGrants(relationship, uint(shareID))
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &grants[0], nil
+}
+
+// openSharedAccess verifică token-ul din URL și înregistrează accesarea resursei în jurnal.
+// Returnează sql.ErrNoRows dacă token-ul nu există, a fost revocat sau a expirat, și errShareNotApproved
+// dacă accesul încă așteaptă aprobarea tuturor membrilor.
+func (h *RelationshipHandler) openSharedAccess(c *fiber.Ctx, resource string) (*models.ShareGrant, *models.Relationship, error) {
+	var relationshipID, grantID uint
+	err := h.DB.QueryRow(
+		`SELECT relationship_id, id FROM share_grants WHERE token_hash = $1`,
+		shareTokenHash(c.Params("token")),
+	).Scan(&relationshipID, &grantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	relationship, err := loadRelationship(h.DB, relationshipID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	grants, err := h.loadShareGrants(relationship, grantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(grants) == 0 {
+		return nil, nil, sql.ErrNoRows
+	}
+	grant := &grants[0]
+
+	switch grant.Status {
+	case models.ShareStatusPending:
+		return nil, nil, errShareNotApproved
+	case models.ShareStatusActive:
+	default:
+		return nil, nil, sql.ErrNoRows
+	}
+
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	_, err = h.DB.Exec(
+		`INSERT INTO share_access_log (grant_id, resource, ip_address, user_agent, accessed_at)
+         VALUES ($1, $2, $3, NULLIF($4, ''), NOW())`,
+		grant.ID, resource, c.IP(), strings.ToValidUTF8(userAgent, ""),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Datele sunt personale; nu trebuie păstrate în cache-uri intermediare
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return grant, relationship, nil
+}
+
+// shareStatus calculează starea accesului partajat: activ doar dacă l-au aprobat toți membrii actuali
+func shareStatus(grant *models.ShareGrant, relationship *models.Relationship, now time.Time) string {
+	if grant.RevokedAt != nil {
+		return models.ShareStatusRevoked
+	}
+	if !now.Before(grant.ExpiresAt) {
+		return models.ShareStatusExpired
+	}
+
+	approved := make(map[uint]bool, len(grant.Approvals))
+	for _, a := range grant.Approvals {
+		approved[a.UserID] = true
+	}
+	for _, memberID := range relationship.MemberIDs() {
+		if !approved[memberID] {
+			return models.ShareStatusPending
+		}
+	}
+	return models.ShareStatusActive
+}
+
+// sharedMemberPair returnează membrii comparați în istoric și statistici: parametrii userId și memberId,
+// sau implicit primii doi membri ai relației
+func sharedMemberPair(c *fiber.Ctx, relationship *models.Relationship) (uint, uint, bool) {
+	members := relationship.MemberIDs()
+	var pair [2]uint
+	for i, param := range []string{"userId", "memberId"} {
+		value := c.Query(param)
+		if value == "" {
+			if i < len(members) {
+				pair[i] = members[i]
+			}
+			continue
+		}
+
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || !relationship.IsMember(uint(id)) {
+			return 0, 0, false
+		}
+		pair[i] = uint(id)
+	}
+
+	if pair[0] == pair[1] {
+		return 0, 0, false
+	}
+	return pair[0], pair[1], true
+}
+
+// scanShareGrant citește un acces partajat dintr-un rând cu coloanele shareGrantColumns
+func scanShareGrant(row rowScanner) (*models.ShareGrant, error) {
+	var g models.ShareGrant
+	var createdBy, revokedBy sql.NullInt64
+	var revokedAt, lastAccessAt sql.NullTime
+	err := row.Scan(&g.ID, &g.RelationshipID, &createdBy, &g.Label, &g.ExpiresAt, &revokedAt, &revokedBy, &g.CreatedAt, &lastAccessAt)
+	if err != nil {
+		return nil, err
+	}
+	g.CreatedBy = nullableID(createdBy)
+	g.RevokedBy = nullableID(revokedBy)
+	if revokedAt.Valid {
+		g.RevokedAt = &revokedAt.Time
+	}
+	if lastAccessAt.Valid {
+		g.LastAccessAt = &lastAccessAt.Time
+	}
+	g.Approvals = []models.ShareApproval{}
+	return &g, nil
+}
+
+// shareTokenHash returnează amprenta SHA-256 a token-ului, singura formă în care acesta este salvat
+func shareTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// errShareNotApproved indică un acces partajat care așteaptă aprobarea tuturor membrilor
+var errShareNotApproved = errors.New("acces partajat neaprobat")
+
+// shareGrantLookupError transformă eroarea de la findShareGrant în răspunsul HTTP potrivit
+func shareGrantLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Accesul partajat nu a fost găsit",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea accesului partajat",
+	})
+}
+
+// sharedAccessError transformă eroarea de la openSharedAccess în răspunsul HTTP potrivit
+func sharedAccessError(c *fiber.Ctx, err error) error {
+	if err == errShareNotApproved {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Accesul partajat nu a fost încă aprobat de toți membrii relației",
+		})
+	}
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Link-ul nu este valid, a expirat sau a fost revocat",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea datelor partajate",
+	})
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/models"
+)
+
+// startDateLayout este formatul acceptat pentru data de început (doar ziua calendaristică)
+const startDateLayout = "2006-01-02"
+
+// ProposeStartDateRequest reprezintă cererea de propunere a unei noi date de început
+type ProposeStartDateRequest struct {
+	StartDate string `json:"startDate" validate:"required"`
+}
+
+// ProposeStartDate creează o propunere de modificare a datei de început, care trebuie aprobată de un alt membru
+func (h *RelationshipHandler) ProposeStartDate(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req ProposeStartDateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	// Data propusă începe la miezul nopții, în fusul orar al celui care o propune
+	proposedDate, err := time.ParseInLocation(startDateLayout, req.StartDate, h.userLocation(userID))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Data de început trebuie să aibă formatul AAAA-LL-ZZ",
+		})
+	}
+
+	if proposedDate.After(time.Now()) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Data de început nu poate fi în viitor",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// O nouă propunere o înlocuiește pe cea aflată în așteptare
+	_, err = tx.Exec(
+		`UPDATE start_date_proposals
+         SET status = $2
+         WHERE relationship_id = $1 AND status = $3`,
+		relationship.ID, models.ProposalStatusCancelled, models.ProposalStatusPending,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la anularea propunerii existente",
+		})
+	}
+
+	proposal := models.StartDateProposal{
+		RelationshipID: relationship.ID,
+		ProposedBy:     userID,
+		ProposedDate:   proposedDate,
+		PreviousDate:   relationship.StartDate,
+		Status:         models.ProposalStatusPending,
+	}
+
+	err = tx.QueryRow(
+		`INSERT INTO start_date_proposals (relationship_id, proposed_by, proposed_date, previous_date, status, created_at)
+         VALUES ($1, $2, $3, $4, $5, NOW())
+         RETURNING id, created_at`,
+		proposal.RelationshipID, proposal.ProposedBy, proposal.ProposedDate, proposal.PreviousDate, proposal.Status,
+	).Scan(&proposal.ID, &proposal.CreatedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea propunerii",
+		})
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Anunță ceilalți membri că au o propunere de aprobat
+	SendToMembers(relationship, userID, "start_date_proposed", proposal)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"proposal": proposal,
+	})
+}
+
+// GetStartDateProposals returnează istoricul propunerilor de modificare a datei de început
+func (h *RelationshipHandler) GetStartDateProposals(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, relationship_id, proposed_by, proposed_date, previous_date, status, responded_by, responded_at, created_at
+         FROM start_date_proposals
+         WHERE relationship_id = $1
+         ORDER BY created_at DESC, id DESC`,
+		relationship.ID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea propunerilor",
+		})
+	}
+	defer rows.Close()
+
+	proposals := []models.StartDateProposal{}
+	for rows.Next() {
+		proposal, err := scanStartDateProposal(rows)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea propunerilor",
+			})
+		}
+		proposals = append(proposals, *proposal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea propunerilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"proposals": proposals,
+	})
+}
+
+// ApproveStartDate aprobă propunerea altui membru și actualizează data de început
+func (h *RelationshipHandler) ApproveStartDate(c *fiber.Ctx) error {
+	return h.respondToStartDateProposal(c, true)
+}
+
+// RejectStartDate respinge propunerea altui membru
+func (h *RelationshipHandler) RejectStartDate(c *fiber.Ctx) error {
+	return h.respondToStartDateProposal(c, false)
+}
+
+// respondToStartDateProposal aplică răspunsul unui alt membru la o propunere aflată în așteptare
+func (h *RelationshipHandler) respondToStartDateProposal(c *fiber.Ctx, approve bool) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	proposalID, err := c.ParamsInt("proposalId")
+	if err != nil || proposalID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "ID propunere invalid",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	proposal, err := scanStartDateProposal(tx.QueryRow(
+		`SELECT id, relationship_id, proposed_by, proposed_date, previous_date, status, responded_by, responded_at, created_at
+         FROM start_date_proposals
+         WHERE id = $1 AND relationship_id = $2
+         FOR UPDATE`,
+		proposalID, relationship.ID,
+	))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   true,
+				"message": "Propunerea nu a fost găsită",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea propunerii",
+		})
+	}
+
+	if proposal.Status != models.ProposalStatusPending {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Propunerea nu mai este în așteptare",
+		})
+	}
+
+	if proposal.ProposedBy == userID {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Propunerea trebuie confirmată de un alt membru",
+		})
+	}
+
+	status := models.ProposalStatusRejected
+	if approve {
+		status = models.ProposalStatusApproved
+	}
+
+	err = tx.QueryRow(
+		`UPDATE start_date_proposals
+         SET status = $2, responded_by = $3, responded_at = NOW()
+         WHERE id = $1
+         RETURNING responded_at`,
+		proposal.ID, status, userID,
+	).Scan(&proposal.RespondedAt)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea propunerii",
+		})
+	}
+	proposal.Status = status
+	proposal.RespondedBy = &userID
+
+	if approve {
+		err = tx.QueryRow(
+			`UPDATE relationships
+             SET start_date = $2, updated_at = NOW()
+             WHERE id = $1
+             RETURNING start_date, updated_at`,
+			relationship.ID, proposal.ProposedDate,
+		).Scan(&relationship.StartDate, &relationship.UpdatedAt)
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la actualizarea datei de început",
+			})
+		}
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// Anunță membrul care a făcut propunerea
+	SendToUser(relationship.ID, proposal.ProposedBy, "start_date_"+status, fiber.Map{
+		"proposal":     proposal,
+		"relationship": relationship.ToResponse(proposal.ProposedBy, h.userLocation(proposal.ProposedBy)),
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"proposal":     proposal,
+		"relationship": relationship.ToResponse(userID, h.userLocation(userID)),
+	})
+}
+
+// rowScanner este implementat atât de *sql.Row, cât și de *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanStartDateProposal citește o propunere dintr-un rând returnat de baza de date
+func scanStartDateProposal(row rowScanner) (*models.StartDateProposal, error) {
+	var proposal models.StartDateProposal
+	var respondedBy sql.NullInt64
+	var respondedAt sql.NullTime
+
+	err := row.Scan(
+		&proposal.ID,
+		&proposal.RelationshipID,
+		&proposal.ProposedBy,
+		&proposal.ProposedDate,
+		&proposal.PreviousDate,
+		&proposal.Status,
+		&respondedBy,
+		&respondedAt,
+		&proposal.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if respondedBy.Valid {
+		id := uint(respondedBy.Int64)
+		proposal.RespondedBy = &id
+	}
+	if respondedAt.Valid {
+		proposal.RespondedAt = &respondedAt.Time
+	}
+
+	return &proposal, nil
+}
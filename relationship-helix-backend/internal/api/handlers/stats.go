@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"math"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/axes"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/stats"
+)
+
+// statsWindows sunt ferestrele de timp disponibile pentru statistici ("all" = de la începutul relației)
+var statsWindows = map[string]time.Duration{
+	"7d":   7 * 24 * time.Hour,
+	"30d":  30 * 24 * time.Hour,
+	"90d":  90 * 24 * time.Hour,
+	"365d": 365 * 24 * time.Hour,
+	"all":  0,
+}
+
+// GetStats returnează mediile, volatilitatea, timpul pe intervale, timpii de răspuns și scorul de convergență
+func (h *RelationshipHandler) GetStats(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	window := c.Query("window", "30d")
+	duration, ok := statsWindows[window]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Fereastra trebuie să fie una dintre: 7d, 30d, 90d, 365d, all",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	// Membrul cu care se compară utilizatorul (într-un cuplu, partenerul)
+	memberID, ok := comparedMemberID(c, relationship, userID)
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul memberId trebuie să fie ID-ul altui membru al relației",
+		})
+	}
+
+	// Opțional, statisticile unei singure dimensiuni (valorile sunt normalizate pe scala 0-100)
+	axisKey := c.Query("axis")
+	if message, err := h.checkAxisParam(relationship.ID, axisKey); message != "" || err != nil {
+		return axisParamError(c, message, err)
+	}
+
+	to := time.Now()
+	from := relationship.CreatedAt
+	if duration > 0 && to.Add(-duration).After(from) {
+		from = to.Add(-duration)
+	}
+
+	events, err := h.loadStatsEvents(relationship.ID, axisKey, from, to, userID, userID, memberID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea istoricului",
+		})
+	}
+
+	report := stats.Compute(events, userID, memberID, from, to, stats.Options{
+		Location: h.userLocation(userID),
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"window":   window,
+		"memberId": memberID,
+		"axis":     axisKey,
+		"bands":    stats.DefaultBands,
+		"stats":    report,
+	})
+}
+
+// loadStatsEvents încarcă evenimentele din fereastră, plus ultima poziție a fiecărui partener dinaintea ei.
+// Se încarcă doar evenimentele lui userID și memberID; cele făcute în timpul unei pauze active de alți membri decât
+// viewerID sunt excluse (viewerID 0 le exclude pe toate).
+// Dacă axisKey nu este gol, se folosesc valorile acelei dimensiuni, normalizate pe scala 0-100.
+func (h *RelationshipHandler) loadStatsEvents(relationshipID uint, axisKey string, from, to time.Time, viewerID, userID, memberID uint) ([]stats.Event, error) {
+	var axis axes.Axis
+	if axisKey != "" {
+		list, err := axes.Load(h.DB, relationshipID)
+		if err != nil {
+			return nil, err
+		}
+		axis, _ = axes.Find(list, axisKey)
+	}
+
+	rows, err := h.DB.Query(
+		`WITH visible AS (
+             SELECT e.id, e.user_id, COALESCE(a.value, e.position) AS position, e.created_at
+             FROM position_events e
+             LEFT JOIN position_event_axes a ON a.event_id = e.id AND a.axis_key = $6::text
+             WHERE e.relationship_id = $1
+               AND e.user_id IN ($4, $5)
+               AND ($6::text = '' OR a.value IS NOT NULL)
+               AND e.reverted_at IS NULL AND e.reverts_event_id IS NULL
+               AND `+pause.VisibleCondition("e", "$7")+`
+         )
+         SELECT user_id, position, created_at
+         FROM visible
+         WHERE created_at >= $2 AND created_at <= $3
+         UNION ALL
+         (SELECT DISTINCT ON (user_id) user_id, position, created_at
+          FROM visible
+          WHERE created_at < $2
+          ORDER BY user_id, created_at DESC, id DESC)`,
+		relationshipID, from, to, userID, memberID, axisKey, viewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []stats.Event
+	for rows.Next() {
+		var e stats.Event
+		if err := rows.Scan(&e.UserID, &e.Position, &e.At); err != nil {
+			return nil, err
+		}
+		if axisKey != "" {
+			e.Position = int(math.Round(axes.Normalize(axis, e.Position)))
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
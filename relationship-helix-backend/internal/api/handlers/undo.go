@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"relationship-helix/internal/axes"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/webhooks"
+)
+
+// Actualizările de poziție amânate până la închiderea ferestrei de anulare, după ID-ul evenimentului din istoric.
+// La repornirea serverului, actualizările amânate nu mai sunt trimise; membrii văd poziția la următoarea încărcare.
+var (
+	pendingUpdates      = make(map[uint]*time.Timer)
+	pendingUpdatesMutex sync.Mutex
+)
+
+// publishPositionUpdate trimite actualizarea celorlalți membri și webhook-urilor, imediat sau, dacă este
+// configurată amânarea, după fereastra de anulare
+func (h *RelationshipHandler) publishPositionUpdate(eventID uint, update models.PositionUpdate) {
+	if !h.Config.DelayPositionBroadcast || h.Config.PositionUndoWindow <= 0 {
+		h.sendPositionUpdate(update)
+		return
+	}
+
+	pendingUpdatesMutex.Lock()
+	defer pendingUpdatesMutex.Unlock()
+
+	pendingUpdates[eventID] = time.AfterFunc(h.Config.PositionUndoWindow, func() {
+		pendingUpdatesMutex.Lock()
+		_, ok := pendingUpdates[eventID]
+		delete(pendingUpdates, eventID)
+		pendingUpdatesMutex.Unlock()
+
+		// Actualizarea a fost anulată între timp
+		if ok {
+			h.sendPositionUpdate(update)
+		}
+	})
+}
+
+// cancelPendingUpdate oprește trimiterea amânată a actualizării; returnează false dacă aceasta a fost deja trimisă
+func cancelPendingUpdate(eventID uint) bool {
+	pendingUpdatesMutex.Lock()
+	defer pendingUpdatesMutex.Unlock()
+
+	timer, ok := pendingUpdates[eventID]
+	if !ok {
+		return false
+	}
+	delete(pendingUpdates, eventID)
+	timer.Stop()
+	return true
+}
+
+// sendPositionUpdate trimite actualizarea prin WebSocket și webhook-urilor membrilor
+func (h *RelationshipHandler) sendPositionUpdate(update models.PositionUpdate) {
+	BroadcastPositionUpdate(update)
+
+	// Webhook-urile celorlalți membri nu primesc pozițiile din timpul pauzei
+	recipients := update.RecipientIDs
+	if update.Paused {
+		recipients = []uint{update.UserID}
+	}
+	enqueueWebhook(h.DB, webhooks.Event{
+		Type:           webhooks.EventPositionUpdate,
+		RelationshipID: update.RelationshipID,
+		RecipientIDs:   recipients,
+		Data:           update,
+	})
+}
+
+// UndoPosition anulează ultima actualizare de poziție a utilizatorului, dacă fereastra de anulare nu s-a închis.
+// Poziția anterioară este restabilită, iar anularea apare în istoric ca o actualizare nouă.
+func (h *RelationshipHandler) UndoPosition(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	if h.Config.PositionUndoWindow <= 0 {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   true,
+			"message": "Anularea actualizărilor de poziție este dezactivată",
+		})
+	}
+
+	relationship, err := h.findRelationship(c, userID)
+	if err != nil {
+		return relationshipLookupError(c, err)
+	}
+
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	// Doar ultima actualizare a utilizatorului poate fi anulată
+	var eventID uint
+	var previousPosition sql.NullInt64
+	var previousUpdatedAt, revertedAt sql.NullTime
+	var revertsEventID sql.NullInt64
+	var createdAt time.Time
+	err = tx.QueryRow(
+		`SELECT id, previous_position, previous_updated_at, reverted_at, reverts_event_id, created_at
+         FROM position_events
+         WHERE relationship_id = $1 AND user_id = $2
+         ORDER BY id DESC
+         LIMIT 1
+         FOR UPDATE`,
+		relationship.ID, userID,
+	).Scan(&eventID, &previousPosition, &previousUpdatedAt, &revertedAt, &revertsEventID, &createdAt)
+
+	if err != nil && err != sql.ErrNoRows {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea ultimei actualizări",
+		})
+	}
+
+	// Anulările și actualizările fără poziție anterioară (ex. cele dezvăluite simultan) nu pot fi anulate
+	if err == sql.ErrNoRows || !previousPosition.Valid || revertedAt.Valid || revertsEventID.Valid ||
+		time.Since(createdAt) > h.Config.PositionUndoWindow {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Nu există nicio actualizare recentă care poate fi anulată",
+		})
+	}
+
+	position := int(previousPosition.Int64)
+
+	if _, err := tx.Exec(`UPDATE position_events SET reverted_at = NOW() WHERE id = $1`, eventID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la anularea actualizării",
+		})
+	}
+
+	// Anularea este o actualizare nouă în istoric, fără notiță
+	var reversalID uint
+	err = tx.QueryRow(
+		`INSERT INTO position_events (relationship_id, user_id, position, visibility, reverts_event_id, created_at)
+         VALUES ($1, $2, $3, $4, $5, NOW())
+         RETURNING id`,
+		relationship.ID, userID, position, models.VisibilityShared, eventID,
+	).Scan(&reversalID)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea istoricului",
+		})
+	}
+
+	// Poziția revine la valoarea și momentul actualizării anterioare (momentul contează pentru estompare)
+	_, err = tx.Exec(
+		`UPDATE curve_positions
+         SET position = $3, updated_at = COALESCE($4, updated_at), decayed_position = NULL, stale_since = NULL
+         WHERE relationship_id = $1 AND user_id = $2`,
+		relationship.ID, userID, position, previousUpdatedAt,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la restabilirea poziției",
+		})
+	}
+
+	restoredAxes, err := restoreAxisValues(tx, relationship.ID, userID, eventID, reversalID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la restabilirea dimensiunilor",
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+
+	// O actualizare încă amânată nu a ajuns la ceilalți membri, deci nu trebuie nici retrasă
+	if !cancelPendingUpdate(eventID) {
+		userPause, err := pause.Active(h.DB, relationship.ID, userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la obținerea pauzei",
+			})
+		}
+
+		payload := fiber.Map{
+			"userId":          userID,
+			"partnerId":       userID,
+			"status":          models.PositionStatusActive,
+			"position":        position,
+			"eventId":         reversalID,
+			"revertedEventId": eventID,
+		}
+		if len(restoredAxes) > 0 {
+			payload["axes"] = restoredAxes
+		}
+
+		// În timpul unei pauze, ceilalți membri nu au primit poziția, deci nici anularea ei
+		recipients := []uint{userID}
+		if userPause == nil {
+			SendToMembers(relationship, userID, "position_reverted", payload)
+			recipients = relationship.MemberIDs()
+		}
+		enqueueWebhook(h.DB, webhooks.Event{
+			Type:           webhooks.EventPositionReverted,
+			RelationshipID: relationship.ID,
+			RecipientIDs:   recipients,
+			Data:           payload,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success":         true,
+		"position":        position,
+		"axes":            restoredAxes,
+		"eventId":         reversalID,
+		"revertedEventId": eventID,
+	})
+}
+
+// restoreAxisValues readuce dimensiunile modificate de actualizarea anulată la valorile anterioare și le salvează
+// în istoric la evenimentul de anulare. Dimensiunile fără valoare anterioară sunt șterse.
+func restoreAxisValues(tx *sql.Tx, relationshipID, userID, eventID, reversalID uint) (map[string]int, error) {
+	rows, err := tx.Query(`SELECT axis_key FROM position_event_axes WHERE event_id = $1`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	restored := make(map[string]int)
+	for _, key := range keys {
+		var value int
+		err := tx.QueryRow(
+			`SELECT a.value
+             FROM position_event_axes a
+             JOIN position_events e ON e.id = a.event_id
+             WHERE e.relationship_id = $1 AND e.user_id = $2 AND a.axis_key = $3
+               AND e.id < $4 AND e.reverted_at IS NULL
+             ORDER BY e.id DESC
+             LIMIT 1`,
+			relationshipID, userID, key, eventID,
+		).Scan(&value)
+
+		if err == sql.ErrNoRows {
+			_, err = tx.Exec(
+				`DELETE FROM axis_positions WHERE relationship_id = $1 AND user_id = $2 AND axis_key = $3`,
+				relationshipID, userID, key,
+			)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		restored[key] = value
+	}
+
+	if err := axes.SaveValues(tx, relationshipID, userID, reversalID, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
@@ -0,0 +1,648 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/webhooks"
+)
+
+// maxWebhooksPerUser limitează numărul de webhook-uri ale unui utilizator
+const maxWebhooksPerUser = 10
+
+// Limitele pentru secretul ales de utilizator
+const (
+	minWebhookSecretLength = 16
+	maxWebhookSecretLength = 128
+)
+
+// Limitele listei de livrări
+const (
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 200
+)
+
+// WebhookHandler gestionează webhook-urile utilizatorului și jurnalul lor de livrări
+type WebhookHandler struct {
+	DB     *sql.DB
+	Config *config.Config
+}
+
+// NewWebhookHandler creează un nou handler pentru webhook-uri
+func NewWebhookHandler(db *sql.DB, cfg *config.Config) *WebhookHandler {
+	return &WebhookHandler{
+		DB:     db,
+		Config: cfg,
+	}
+}
+
+// CreateWebhookRequest reprezintă cererea de înregistrare a unui webhook
+type CreateWebhookRequest struct {
+	URL            string   `json:"url"`
+	Secret         string   `json:"secret"` // Opțional; generat de server dacă lipsește
+	Events         []string `json:"events"`
+	RelationshipID *uint    `json:"relationshipId"` // Opțional; lipsă = toate relațiile
+}
+
+// UpdateWebhookRequest reprezintă modificarea unui webhook; câmpurile lipsă rămân neschimbate
+type UpdateWebhookRequest struct {
+	URL            *string  `json:"url"`
+	Events         []string `json:"events"`
+	RelationshipID *uint    `json:"relationshipId"` // 0 = toate relațiile
+	Active         *bool    `json:"active"`
+	RotateSecret   bool     `json:"rotateSecret"`
+}
+
+// webhookColumns sunt coloanele citite de scanWebhook
+const webhookColumns = `id, user_id, relationship_id, url, events, active, failure_count, disabled_at, created_at, updated_at`
+
+// scanWebhook citește un webhook (fără secret)
+func scanWebhook(row interface{ Scan(...interface{}) error }) (*models.Webhook, error) {
+	var w models.Webhook
+	var relationshipID sql.NullInt64
+	err := row.Scan(&w.ID, &w.UserID, &relationshipID, &w.URL, pq.Array(&w.Events), &w.Active, &w.FailureCount, &w.DisabledAt, &w.CreatedAt, &w.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if relationshipID.Valid {
+		id := uint(relationshipID.Int64)
+		w.RelationshipID = &id
+	}
+	return &w, nil
+}
+
+// GetWebhookEvents returnează tipurile de evenimente la care se poate abona un webhook
+func (h *WebhookHandler) GetWebhookEvents(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"events": webhooks.EventTypes,
+	})
+}
+
+// ListWebhooks returnează webhook-urile utilizatorului curent
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT `+webhookColumns+` FROM webhooks WHERE user_id = $1 ORDER BY created_at, id`,
+		userID,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea webhook-urilor",
+		})
+	}
+	defer rows.Close()
+
+	list := []*models.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea webhook-urilor",
+			})
+		}
+		list = append(list, w)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea webhook-urilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"webhooks": list,
+	})
+}
+
+// CreateWebhook înregistrează un webhook; secretul este returnat doar în acest răspuns
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	if message := h.validateURL(req.URL); message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	events, message := normalizeWebhookEvents(req.Events)
+	if message != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": message,
+		})
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := webhooks.GenerateSecret()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la generarea secretului",
+			})
+		}
+		secret = generated
+	} else if len(secret) < minWebhookSecretLength || len(secret) > maxWebhookSecretLength {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Secretul trebuie să aibă între " + strconv.Itoa(minWebhookSecretLength) + " și " + strconv.Itoa(maxWebhookSecretLength) + " de caractere",
+		})
+	}
+
+	if req.RelationshipID != nil {
+		if err := h.checkMembership(*req.RelationshipID, userID); err != nil {
+			return relationshipLookupError(c, err)
+		}
+	}
+
+	var count int
+	if err := h.DB.QueryRow(`SELECT COUNT(*) FROM webhooks WHERE user_id = $1`, userID).Scan(&count); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la verificarea webhook-urilor",
+		})
+	}
+	if count >= maxWebhooksPerUser {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Poți avea cel mult " + strconv.Itoa(maxWebhooksPerUser) + " webhook-uri",
+		})
+	}
+
+	w, err := scanWebhook(h.DB.QueryRow(
+		`INSERT INTO webhooks (user_id, relationship_id, url, secret, events, created_at, updated_at)
+         VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+         RETURNING `+webhookColumns,
+		userID, req.RelationshipID, req.URL, secret, pq.Array(events),
+	))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la salvarea webhook-ului",
+		})
+	}
+	w.Secret = secret
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"webhook": w,
+	})
+}
+
+// GetWebhook returnează un webhook al utilizatorului curent
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	w, err := h.findWebhook(c, userID)
+	if err != nil {
+		return webhookLookupError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"webhook": w,
+	})
+}
+
+// UpdateWebhook modifică un webhook; reactivarea resetează contorul de eșecuri,
+// iar dezactivarea anulează livrările în așteptare
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	// Parsează cererea
+	var req UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Cerere invalidă",
+		})
+	}
+
+	w, err := h.findWebhook(c, userID)
+	if err != nil {
+		return webhookLookupError(c, err)
+	}
+
+	if req.URL != nil {
+		if message := h.validateURL(*req.URL); message != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": message,
+			})
+		}
+		w.URL = *req.URL
+	}
+
+	if req.Events != nil {
+		events, message := normalizeWebhookEvents(req.Events)
+		if message != "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   true,
+				"message": message,
+			})
+		}
+		w.Events = events
+	}
+
+	if req.RelationshipID != nil {
+		if *req.RelationshipID == 0 {
+			w.RelationshipID = nil
+		} else {
+			if err := h.checkMembership(*req.RelationshipID, userID); err != nil {
+				return relationshipLookupError(c, err)
+			}
+			w.RelationshipID = req.RelationshipID
+		}
+	}
+
+	var secret string
+	if req.RotateSecret {
+		secret, err = webhooks.GenerateSecret()
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la generarea secretului",
+			})
+		}
+	}
+
+	// Începe o tranzacție
+	tx, err := h.DB.Begin()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la inițierea tranzacției",
+		})
+	}
+	defer tx.Rollback()
+
+	updated, err := scanWebhook(tx.QueryRow(
+		`UPDATE webhooks
+         SET url = $2,
+             events = $3,
+             relationship_id = $4,
+             secret = COALESCE(NULLIF($5, ''), secret),
+             active = COALESCE($6, active),
+             failure_count = CASE WHEN $6 AND NOT active THEN 0 ELSE failure_count END,
+             disabled_at = CASE WHEN $6 IS NULL THEN disabled_at WHEN $6 THEN NULL ELSE COALESCE(disabled_at, NOW()) END,
+             updated_at = NOW()
+         WHERE id = $1
+         RETURNING `+webhookColumns,
+		w.ID, w.URL, pq.Array(w.Events), w.RelationshipID, secret, req.Active,
+	))
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la actualizarea webhook-ului",
+		})
+	}
+
+	if !updated.Active {
+		if err := webhooks.CancelPending(tx, updated.ID, "webhook dezactivat"); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la actualizarea livrărilor",
+			})
+		}
+	}
+
+	// Commit tranzacția
+	if err := tx.Commit(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la finalizarea tranzacției",
+		})
+	}
+	updated.Secret = secret
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"webhook": updated,
+	})
+}
+
+// DeleteWebhook șterge un webhook împreună cu jurnalul lui de livrări
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	webhookID, err := c.ParamsInt("id")
+	if err != nil || webhookID <= 0 {
+		return webhookLookupError(c, sql.ErrNoRows)
+	}
+
+	result, err := h.DB.Exec(`DELETE FROM webhooks WHERE id = $1 AND user_id = $2`, webhookID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la ștergerea webhook-ului",
+		})
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return webhookLookupError(c, sql.ErrNoRows)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// GetDeliveries returnează jurnalul de livrări al unui webhook, cele mai noi primele;
+// ?status= filtrează după stare, iar ?before= (ID-ul unei livrări) paginează
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	status := c.Query("status")
+	if status != "" && status != webhooks.StatusPending && status != webhooks.StatusSucceeded && status != webhooks.StatusFailed {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Stare invalidă",
+		})
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(defaultDeliveriesLimit)))
+	if err != nil || limit < 1 || limit > maxDeliveriesLimit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul limit trebuie să fie între 1 și " + strconv.Itoa(maxDeliveriesLimit),
+		})
+	}
+
+	before, err := strconv.Atoi(c.Query("before", "0"))
+	if err != nil || before < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "Parametrul before este invalid",
+		})
+	}
+
+	w, err := h.findWebhook(c, userID)
+	if err != nil {
+		return webhookLookupError(c, err)
+	}
+
+	rows, err := h.DB.Query(
+		`SELECT id, webhook_id, event_type, relationship_id, payload, status, attempts, next_attempt_at,
+                last_status_code, last_error, replay_of, created_at, delivered_at
+         FROM webhook_deliveries
+         WHERE webhook_id = $1 AND ($2::text = '' OR status = $2) AND ($3 = 0 OR id < $3)
+         ORDER BY id DESC
+         LIMIT $4`,
+		w.ID, status, before, limit,
+	)
+
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la obținerea livrărilor",
+		})
+	}
+	defer rows.Close()
+
+	deliveries := []models.WebhookDelivery{}
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var replayOf sql.NullInt64
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.RelationshipID, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+			&d.LastStatusCode, &d.LastError, &replayOf, &d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   true,
+				"message": "Eroare la citirea livrărilor",
+			})
+		}
+		if replayOf.Valid {
+			id := uint(replayOf.Int64)
+			d.ReplayOf = &id
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la citirea livrărilor",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"deliveries": deliveries,
+	})
+}
+
+// ReplayDelivery retrimite o livrare din jurnal, ca o livrare nouă cu același conținut
+func (h *WebhookHandler) ReplayDelivery(c *fiber.Ctx) error {
+	// Obține ID-ul utilizatorului din context
+	userID, ok := c.Locals("userID").(uint)
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error":   true,
+			"message": "Neautentificat",
+		})
+	}
+
+	deliveryID, err := c.ParamsInt("deliveryId")
+	if err != nil || deliveryID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   true,
+			"message": "ID livrare invalid",
+		})
+	}
+
+	w, err := h.findWebhook(c, userID)
+	if err != nil {
+		return webhookLookupError(c, err)
+	}
+
+	if !w.Active {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   true,
+			"message": "Webhook-ul este dezactivat",
+		})
+	}
+
+	var d models.WebhookDelivery
+	var replayOf sql.NullInt64
+	err = h.DB.QueryRow(
+		`INSERT INTO webhook_deliveries (webhook_id, event_type, relationship_id, payload, status, attempts, next_attempt_at, replay_of, created_at)
+         SELECT webhook_id, event_type, relationship_id, payload, $3, 0, NOW(), id, NOW()
+         FROM webhook_deliveries
+         WHERE id = $1 AND webhook_id = $2
+         RETURNING id, webhook_id, event_type, relationship_id, payload, status, attempts, next_attempt_at, replay_of, created_at`,
+		deliveryID, w.ID, webhooks.StatusPending,
+	).Scan(&d.ID, &d.WebhookID, &d.EventType, &d.RelationshipID, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt, &replayOf, &d.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Livrarea nu a fost găsită",
+		})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   true,
+			"message": "Eroare la retrimiterea livrării",
+		})
+	}
+	if replayOf.Valid {
+		id := uint(replayOf.Int64)
+		d.ReplayOf = &id
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"delivery": d,
+	})
+}
+
+// findWebhook încarcă webhook-ul indicat de parametrul :id, dacă aparține utilizatorului
+func (h *WebhookHandler) findWebhook(c *fiber.Ctx, userID uint) (*models.Webhook, error) {
+	webhookID, err := c.ParamsInt("id")
+	if err != nil || webhookID <= 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return scanWebhook(h.DB.QueryRow(
+		`SELECT `+webhookColumns+` FROM webhooks WHERE id = $1 AND user_id = $2`,
+		webhookID, userID,
+	))
+}
+
+// checkMembership verifică dacă utilizatorul face parte din relație (sql.ErrNoRows altfel)
+func (h *WebhookHandler) checkMembership(relationshipID, userID uint) error {
+	var exists bool
+	return h.DB.QueryRow(
+		`SELECT TRUE FROM relationship_members WHERE relationship_id = $1 AND user_id = $2`,
+		relationshipID, userID,
+	).Scan(&exists)
+}
+
+// validateURL returnează un mesaj de eroare dacă URL-ul nu poate fi folosit ca webhook
+func (h *WebhookHandler) validateURL(value string) string {
+	if len(value) > 2048 || webhooks.ValidateURL(value) != nil {
+		return "URL invalid"
+	}
+	// HTTP este acceptat doar pentru testarea locală
+	if h.Config.Environment == "production" && !strings.HasPrefix(value, "https://") {
+		return "URL-ul webhook-ului trebuie să folosească HTTPS"
+	}
+	return ""
+}
+
+// normalizeWebhookEvents validează lista de evenimente și elimină duplicatele
+func normalizeWebhookEvents(events []string) ([]string, string) {
+	if len(events) == 0 {
+		return nil, "Alege cel puțin un eveniment"
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(events))
+	for _, e := range events {
+		if !webhooks.IsEventType(e) {
+			return nil, "Tip de eveniment necunoscut: " + e
+		}
+		if !seen[e] {
+			seen[e] = true
+			result = append(result, e)
+		}
+	}
+	return result, ""
+}
+
+// webhookLookupError transformă eroarea de căutare a unui webhook într-un răspuns HTTP
+func webhookLookupError(c *fiber.Ctx, err error) error {
+	if err == sql.ErrNoRows {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   true,
+			"message": "Webhook-ul nu a fost găsit",
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   true,
+		"message": "Eroare la obținerea webhook-ului",
+	})
+}
+
+// enqueueWebhook programează livrarea evenimentului către webhook-urile destinatarilor;
+// o eroare nu anulează acțiunea care a produs evenimentul
+func enqueueWebhook(db webhooks.Execer, event webhooks.Event) {
+	if err := webhooks.Enqueue(db, event); err != nil {
+		log.Printf("Webhook-uri: Eroare la programarea evenimentului %s pentru relația %d: %v\n", event.Type, event.RelationshipID, err)
+	}
+}
+
+// relationshipWebhookData descrie relația în evenimentele relationship_created și relationship_ended
+func relationshipWebhookData(relationship *models.Relationship) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        relationship.ID,
+		"kind":      relationship.Kind,
+		"type":      relationship.Type,
+		"typeLabel": relationship.TypeLabel,
+		"name":      relationship.Name,
+		"startDate": relationship.StartDate,
+		"memberIds": relationship.MemberIDs(),
+	}
+}
@@ -12,12 +12,44 @@ import (
 )
 
 // Map pentru a ține evidența conexiunilor WebSocket
-// Map[relationshipID]Map[userID]*websocket.Conn
+// Map[relationshipID]Map[userID]*wsClient
 var (
 	clientsMutex sync.RWMutex
-	clients      = make(map[uint]map[uint]*websocket.Conn)
+	clients      = make(map[uint]map[uint]*wsClient)
 )
 
+// wsClient este o conexiune WebSocket; conexiunea nu acceptă scrieri concurente, așa că
+// mesajele trimise din handler-e și job-uri diferite sunt serializate prin writeMutex
+type wsClient struct {
+	conn       *websocket.Conn
+	writeMutex sync.Mutex
+}
+
+// send scrie un mesaj text pe conexiune
+func (c *wsClient) send(data []byte) error {
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// OfflineFunc livrează un eveniment unui utilizator care nu este conectat prin WebSocket la relație
+type OfflineFunc func(relationshipID, userID uint, eventType string, payload interface{})
+
+// offlineDelivery este apelată pentru destinatarii deconectați (ex. notificări push); nil = dezactivată
+var offlineDelivery OfflineFunc
+
+// SetOfflineDelivery configurează livrarea evenimentelor către utilizatorii deconectați
+func SetOfflineDelivery(f OfflineFunc) {
+	offlineDelivery = f
+}
+
+// deliverOffline trimite evenimentul pe canalul pentru utilizatori deconectați, dacă acesta este configurat
+func deliverOffline(relationshipID, userID uint, eventType string, payload interface{}) {
+	if offlineDelivery != nil {
+		offlineDelivery(relationshipID, userID, eventType, payload)
+	}
+}
+
 // HandleWebsocketConnection gestionează o conexiune WebSocket
 func HandleWebsocketConnection(c *websocket.Conn) {
 	// Obține ID-ul utilizatorului și ID-ul relației din URL
@@ -46,11 +78,12 @@ func HandleWebsocketConnection(c *websocket.Conn) {
 	relIDUint := uint(relID)
 	
 	// Adaugă clientul la hartă
+	client := &wsClient{conn: c}
 	clientsMutex.Lock()
 	if _, ok := clients[relIDUint]; !ok {
-		clients[relIDUint] = make(map[uint]*websocket.Conn)
+		clients[relIDUint] = make(map[uint]*wsClient)
 	}
-	clients[relIDUint][userID] = c
+	clients[relIDUint][userID] = client
 	clientsMutex.Unlock()
 	
 	// Mesaj de conectare
@@ -65,9 +98,11 @@ func HandleWebsocketConnection(c *websocket.Conn) {
 		}
 	}
 	
-	// Eliminare client la deconectare
+	// Eliminare client la deconectare (doar dacă nu a fost înlocuit între timp de o conexiune nouă)
 	clientsMutex.Lock()
-	delete(clients[relIDUint], userID)
+	if clients[relIDUint][userID] == client {
+		delete(clients[relIDUint], userID)
+	}
 	// Dacă nu mai există clienți pentru această relație, șterge și intrarea
 	if len(clients[relIDUint]) == 0 {
 		delete(clients, relIDUint)
@@ -82,19 +117,42 @@ func BroadcastPositionUpdate(update models.PositionUpdate) {
 	clientsMutex.RLock()
 	defer clientsMutex.RUnlock()
 	
-	// Verifică dacă există conexiuni pentru această relație
-	relationshipClients, ok := clients[update.RelationshipID]
-	if !ok {
-		return
+	// Conexiunile pentru această relație (membrii deconectați primesc actualizarea pe alt canal)
+	relationshipClients := clients[update.RelationshipID]
+	
+	// Construiește payload-ul, cu notița partajată dacă există
+	updatePayload := map[string]interface{}{
+		"userId":    update.UserID,
+		"partnerId": update.UserID,
+		"status":    models.PositionStatusActive,
+		"position":  update.Position,
+	}
+	if update.Note != nil {
+		updatePayload["note"] = *update.Note
+	}
+	if update.Mood != nil {
+		updatePayload["mood"] = *update.Mood
+	}
+	if update.Emoji != nil {
+		updatePayload["emoji"] = *update.Emoji
+	}
+	if len(update.Axes) > 0 {
+		updatePayload["axes"] = update.Axes
+	}
+	
+	// În timpul unei pauze, partenerul vede doar starea neutră, fără poziție sau notiță
+	if update.Paused {
+		updatePayload = map[string]interface{}{
+			"userId":    update.UserID,
+			"partnerId": update.UserID,
+			"status":    models.PositionStatusPaused,
+		}
 	}
 	
 	// Construiește mesajul JSON
 	message := map[string]interface{}{
-		"type": "position_update",
-		"payload": map[string]interface{}{
-			"partnerId": update.UserID,
-			"position":  update.Position,
-		},
+		"type":    "position_update",
+		"payload": updatePayload,
 	}
 	
 	payload, err := json.Marshal(message)
@@ -103,9 +161,74 @@ func BroadcastPositionUpdate(update models.PositionUpdate) {
 		return
 	}
 	
-	// Trimite mesajul doar partenerului
-	if conn, ok := relationshipClients[update.PartnerID]; ok {
-		if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+	// Trimite mesajul tuturor membrilor conectați, în afară de cel care a făcut actualizarea
+	for _, userID := range update.RecipientIDs {
+		if userID == update.UserID {
+			continue
+		}
+		client, ok := relationshipClients[userID]
+		if !ok {
+			// În timpul unei pauze, membrii deconectați nu sunt anunțați deloc
+			if !update.Paused {
+				deliverOffline(update.RelationshipID, userID, "position_update", updatePayload)
+			}
+			continue
+		}
+		if err := client.send(payload); err != nil {
+			log.Printf("WebSocket: Eroare la trimiterea mesajului: %v\n", err)
+		}
+	}
+}
+
+// SendToUser trimite un eveniment de tipul dat unui utilizator conectat la relație, sau pe canalul pentru utilizatori deconectați
+func SendToUser(relationshipID, userID uint, eventType string, payload interface{}) {
+	message := map[string]interface{}{
+		"type":    eventType,
+		"payload": payload,
+	}
+	
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("WebSocket: Eroare la serializarea mesajului: %v\n", err)
+		return
+	}
+	
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+	
+	client, ok := clients[relationshipID][userID]
+	if !ok {
+		deliverOffline(relationshipID, userID, eventType, payload)
+		return
+	}
+	if err := client.send(data); err != nil {
+		log.Printf("WebSocket: Eroare la trimiterea mesajului: %v\n", err)
+	}
+}
+
+// SendToMembers trimite un eveniment tuturor membrilor relației, în afară de exceptUserID
+func SendToMembers(relationship *models.Relationship, exceptUserID uint, eventType string, payload interface{}) {
+	message := map[string]interface{}{
+		"type":    eventType,
+		"payload": payload,
+	}
+	
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("WebSocket: Eroare la serializarea mesajului: %v\n", err)
+		return
+	}
+	
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+	
+	for _, userID := range relationship.OtherMemberIDs(exceptUserID) {
+		client, ok := clients[relationship.ID][userID]
+		if !ok {
+			deliverOffline(relationship.ID, userID, eventType, payload)
+			continue
+		}
+		if err := client.send(data); err != nil {
 			log.Printf("WebSocket: Eroare la trimiterea mesajului: %v\n", err)
 		}
 	}
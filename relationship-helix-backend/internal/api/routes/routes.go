@@ -15,6 +15,9 @@ func SetupRoutes(app *fiber.App, db *sql.DB, cfg *config.Config) {
 	// Creează handler-ele
 	authHandler := handlers.NewAuthHandler(db, cfg)
 	relationshipHandler := handlers.NewRelationshipHandler(db, cfg)
+	settingsHandler := handlers.NewSettingsHandler(db, cfg)
+	pushHandler := handlers.NewPushHandler(db, cfg)
+	webhookHandler := handlers.NewWebhookHandler(db, cfg)
 	
 	// Grupul de rute API
 	api := app.Group("/api")
@@ -27,11 +30,204 @@ func SetupRoutes(app *fiber.App, db *sql.DB, cfg *config.Config) {
 	// Rute protejate prin autentificare
 	auth.Get("/me", middleware.AuthMiddleware(cfg.JWTSecret), authHandler.GetMe)
 	
-	// Rute pentru relații (protejate)
-	relationship := api.Group("/relationship", middleware.AuthMiddleware(cfg.JWTSecret))
+	// Previzualizarea invitațiilor (publică, protejată prin semnătura link-ului)
+	invite := api.Group("/invite")
+	invite.Get("/:code/preview", relationshipHandler.PreviewInvite)
+	
+	// Fluxul iCalendar al relației (public, protejat prin token-ul din link)
+	api.Get("/calendar/:file", relationshipHandler.ServeCalendarFeed)
+	
+	// Accesul partajat doar pentru citire (public, protejat prin token-ul din link și aprobarea membrilor)
+	shared := api.Group("/shared/:token")
+	shared.Get("/", relationshipHandler.GetSharedOverview)
+	shared.Get("/history", relationshipHandler.GetSharedHistory)
+	shared.Get("/stats", relationshipHandler.GetSharedStats)
+	shared.Get("/notes", relationshipHandler.GetSharedNotes)
+	
+	// Rute pentru preferințele utilizatorului (protejate)
+	settings := api.Group("/settings", middleware.AuthMiddleware(cfg.JWTSecret))
+	settings.Get("/", settingsHandler.GetSettings)
+	settings.Put("/", settingsHandler.UpdateSettings)
+	settings.Get("/reminders", settingsHandler.GetReminders)
+	settings.Put("/reminders", settingsHandler.UpdateReminders)
+	settings.Post("/reminders/snooze", settingsHandler.SnoozeReminder)
+	settings.Delete("/reminders/snooze", settingsHandler.CancelSnooze)
+	settings.Get("/digest", settingsHandler.GetDigest)
+	settings.Put("/digest", settingsHandler.UpdateDigest)
+	
+	// Rute pentru notificările push (cheia publică VAPID este publică)
+	api.Get("/push/vapid-public-key", pushHandler.GetVAPIDPublicKey)
+	pushRoutes := api.Group("/push", middleware.AuthMiddleware(cfg.JWTSecret))
+	pushRoutes.Get("/subscriptions", pushHandler.GetSubscriptions)
+	pushRoutes.Post("/subscriptions", pushHandler.Subscribe)
+	pushRoutes.Delete("/subscriptions", pushHandler.Unsubscribe)
+	pushRoutes.Get("/preferences", pushHandler.GetPreferences)
+	pushRoutes.Put("/preferences", pushHandler.UpdatePreferences)
+	
+	// Rute pentru webhook-urile utilizatorului (protejate)
+	webhookRoutes := api.Group("/webhooks", middleware.AuthMiddleware(cfg.JWTSecret))
+	webhookRoutes.Get("/events", webhookHandler.GetWebhookEvents)
+	webhookRoutes.Get("/", webhookHandler.ListWebhooks)
+	webhookRoutes.Post("/", webhookHandler.CreateWebhook)
+	webhookRoutes.Get("/:id", webhookHandler.GetWebhook)
+	webhookRoutes.Put("/:id", webhookHandler.UpdateWebhook)
+	webhookRoutes.Delete("/:id", webhookHandler.DeleteWebhook)
+	webhookRoutes.Get("/:id/deliveries", webhookHandler.GetDeliveries)
+	webhookRoutes.Post("/:id/deliveries/:deliveryId/replay", webhookHandler.ReplayDelivery)
+	
+	// Rute pentru relații (protejate); un utilizator poate avea mai multe relații, adresate prin :id
+	relationships := api.Group("/relationships", middleware.AuthMiddleware(cfg.JWTSecret))
+	relationships.Get("/", relationshipHandler.ListRelationships)
+	relationships.Post("/invite", relationshipHandler.GenerateInviteCode)
+	relationships.Get("/invite/qr", relationshipHandler.GetInviteQR)
+	relationships.Post("/join", relationshipHandler.UseInviteCode)
+	relationships.Post("/group", relationshipHandler.CreateGroup)
+	relationships.Get("/moods", relationshipHandler.GetMoodTags)
+	
+	// Rutele vechi, cu o singură relație, păstrate pentru clienții existenți; folosesc relația implicită a utilizatorului
+	legacy := api.Group("/relationship", middleware.AuthMiddleware(cfg.JWTSecret))
+	legacy.Post("/invite", relationshipHandler.GenerateInviteCode)
+	legacy.Post("/join", relationshipHandler.UseInviteCode)
+	legacy.Get("/invite/qr", relationshipHandler.GetInviteQR)
+	legacy.Post("/group", relationshipHandler.CreateGroup)
+	legacy.Get("/moods", relationshipHandler.GetMoodTags)
+	legacy.Get("/", relationshipHandler.DefaultRelationship, relationshipHandler.GetRelationship)
+	legacy.Post("/position", relationshipHandler.DefaultRelationship, relationshipHandler.UpdatePosition)
+	legacy.Post("/position/undo", relationshipHandler.DefaultRelationship, relationshipHandler.UndoPosition)
+	legacy.Put("/reveal-mode", relationshipHandler.DefaultRelationship, relationshipHandler.SetRevealMode)
+	legacy.Get("/decay", relationshipHandler.DefaultRelationship, relationshipHandler.GetDecayPolicy)
+	legacy.Put("/decay", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateDecayPolicy)
+	legacy.Get("/pause", relationshipHandler.DefaultRelationship, relationshipHandler.GetPause)
+	legacy.Post("/pause", relationshipHandler.DefaultRelationship, relationshipHandler.StartPause)
+	legacy.Delete("/pause", relationshipHandler.DefaultRelationship, relationshipHandler.EndPause)
+	legacy.Get("/axes", relationshipHandler.DefaultRelationship, relationshipHandler.GetAxes)
+	legacy.Put("/axes", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateAxes)
+	legacy.Get("/helix.svg", relationshipHandler.DefaultRelationship, relationshipHandler.GetHelixSVG)
+	legacy.Get("/helix.png", relationshipHandler.DefaultRelationship, relationshipHandler.GetHelixPNG)
+	legacy.Get("/digest/preview", relationshipHandler.DefaultRelationship, relationshipHandler.GetDigestPreview)
+	legacy.Get("/history", relationshipHandler.DefaultRelationship, relationshipHandler.GetHistory)
+	legacy.Get("/history/events", relationshipHandler.DefaultRelationship, relationshipHandler.GetPositionEvents)
+	legacy.Get("/history/export", relationshipHandler.DefaultRelationship, relationshipHandler.ExportHistory)
+	legacy.Post("/history/import", relationshipHandler.DefaultRelationship, relationshipHandler.ImportHistory)
+	legacy.Get("/nudges", relationshipHandler.DefaultRelationship, relationshipHandler.GetNudges)
+	legacy.Post("/nudges", relationshipHandler.DefaultRelationship, relationshipHandler.SendNudge)
+	legacy.Post("/nudges/read", relationshipHandler.DefaultRelationship, relationshipHandler.MarkAllNudgesRead)
+	legacy.Post("/nudges/:nudgeId/read", relationshipHandler.DefaultRelationship, relationshipHandler.MarkNudgeRead)
+	legacy.Get("/journal", relationshipHandler.DefaultRelationship, relationshipHandler.GetJournal)
+	legacy.Post("/journal", relationshipHandler.DefaultRelationship, relationshipHandler.CreateJournalEntry)
+	legacy.Get("/journal/:entryId", relationshipHandler.DefaultRelationship, relationshipHandler.GetJournalEntry)
+	legacy.Put("/journal/:entryId", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateJournalEntry)
+	legacy.Delete("/journal/:entryId", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteJournalEntry)
+	legacy.Get("/journal/:entryId/history", relationshipHandler.DefaultRelationship, relationshipHandler.GetJournalEntryHistory)
+	legacy.Put("/journal/:entryId/reaction", relationshipHandler.DefaultRelationship, relationshipHandler.ReactToJournalEntry)
+	legacy.Delete("/journal/:entryId/reaction", relationshipHandler.DefaultRelationship, relationshipHandler.RemoveJournalReaction)
+	legacy.Get("/questions", relationshipHandler.DefaultRelationship, relationshipHandler.GetQuestionArchive)
+	legacy.Get("/questions/:date", relationshipHandler.DefaultRelationship, relationshipHandler.GetQuestion)
+	legacy.Put("/questions/:date/answer", relationshipHandler.DefaultRelationship, relationshipHandler.AnswerQuestion)
+	legacy.Get("/goals", relationshipHandler.DefaultRelationship, relationshipHandler.GetGoals)
+	legacy.Post("/goals", relationshipHandler.DefaultRelationship, relationshipHandler.CreateGoal)
+	legacy.Get("/goals/:goalId", relationshipHandler.DefaultRelationship, relationshipHandler.GetGoal)
+	legacy.Put("/goals/:goalId", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateGoal)
+	legacy.Delete("/goals/:goalId", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteGoal)
+	legacy.Post("/goals/:goalId/complete", relationshipHandler.DefaultRelationship, relationshipHandler.CompleteGoal)
+	legacy.Delete("/goals/:goalId/complete", relationshipHandler.DefaultRelationship, relationshipHandler.ReopenGoal)
+	legacy.Post("/goals/:goalId/tasks", relationshipHandler.DefaultRelationship, relationshipHandler.CreateGoalTask)
+	legacy.Put("/goals/:goalId/tasks/:taskId", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateGoalTask)
+	legacy.Delete("/goals/:goalId/tasks/:taskId", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteGoalTask)
+	legacy.Post("/goals/:goalId/tasks/:taskId/complete", relationshipHandler.DefaultRelationship, relationshipHandler.CompleteGoalTask)
+	legacy.Delete("/goals/:goalId/tasks/:taskId/complete", relationshipHandler.DefaultRelationship, relationshipHandler.ReopenGoalTask)
+	legacy.Get("/stats", relationshipHandler.DefaultRelationship, relationshipHandler.GetStats)
+	legacy.Get("/milestones", relationshipHandler.DefaultRelationship, relationshipHandler.GetMilestones)
+	legacy.Get("/milestones/dates", relationshipHandler.DefaultRelationship, relationshipHandler.GetRelationshipDates)
+	legacy.Post("/milestones/dates", relationshipHandler.DefaultRelationship, relationshipHandler.CreateRelationshipDate)
+	legacy.Delete("/milestones/dates/:dateId", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteRelationshipDate)
+	legacy.Get("/events", relationshipHandler.DefaultRelationship, relationshipHandler.GetEvents)
+	legacy.Post("/events", relationshipHandler.DefaultRelationship, relationshipHandler.CreateEvent)
+	legacy.Get("/events/:eventId", relationshipHandler.DefaultRelationship, relationshipHandler.GetEvent)
+	legacy.Put("/events/:eventId", relationshipHandler.DefaultRelationship, relationshipHandler.UpdateEvent)
+	legacy.Delete("/events/:eventId", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteEvent)
+	legacy.Get("/calendar/feed", relationshipHandler.DefaultRelationship, relationshipHandler.GetCalendarFeed)
+	legacy.Post("/calendar/feed/rotate", relationshipHandler.DefaultRelationship, relationshipHandler.RotateCalendarFeed)
+	legacy.Delete("/calendar/feed", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteCalendarFeed)
+	legacy.Get("/shares", relationshipHandler.DefaultRelationship, relationshipHandler.GetShareGrants)
+	legacy.Post("/shares", relationshipHandler.DefaultRelationship, relationshipHandler.CreateShareGrant)
+	legacy.Post("/shares/:shareId/approve", relationshipHandler.DefaultRelationship, relationshipHandler.ApproveShareGrant)
+	legacy.Delete("/shares/:shareId", relationshipHandler.DefaultRelationship, relationshipHandler.RevokeShareGrant)
+	legacy.Get("/shares/:shareId/access", relationshipHandler.DefaultRelationship, relationshipHandler.GetShareAccessLog)
+	legacy.Get("/start-date/proposals", relationshipHandler.DefaultRelationship, relationshipHandler.GetStartDateProposals)
+	legacy.Post("/start-date/proposals", relationshipHandler.DefaultRelationship, relationshipHandler.ProposeStartDate)
+	legacy.Post("/start-date/proposals/:proposalId/approve", relationshipHandler.DefaultRelationship, relationshipHandler.ApproveStartDate)
+	legacy.Post("/start-date/proposals/:proposalId/reject", relationshipHandler.DefaultRelationship, relationshipHandler.RejectStartDate)
+	legacy.Delete("/", relationshipHandler.DefaultRelationship, relationshipHandler.DeleteRelationship)
+	
+	relationship := relationships.Group("/:id")
 	relationship.Get("/", relationshipHandler.GetRelationship)
-	relationship.Post("/invite", relationshipHandler.GenerateInviteCode)
-	relationship.Post("/join", relationshipHandler.UseInviteCode)
+	relationship.Post("/invite", relationshipHandler.GenerateGroupInviteCode)
 	relationship.Post("/position", relationshipHandler.UpdatePosition)
+	relationship.Post("/position/undo", relationshipHandler.UndoPosition)
+	relationship.Put("/reveal-mode", relationshipHandler.SetRevealMode)
+	relationship.Get("/decay", relationshipHandler.GetDecayPolicy)
+	relationship.Put("/decay", relationshipHandler.UpdateDecayPolicy)
+	relationship.Get("/pause", relationshipHandler.GetPause)
+	relationship.Post("/pause", relationshipHandler.StartPause)
+	relationship.Delete("/pause", relationshipHandler.EndPause)
+	relationship.Get("/axes", relationshipHandler.GetAxes)
+	relationship.Put("/axes", relationshipHandler.UpdateAxes)
+	relationship.Get("/helix.svg", relationshipHandler.GetHelixSVG)
+	relationship.Get("/helix.png", relationshipHandler.GetHelixPNG)
+	relationship.Get("/digest/preview", relationshipHandler.GetDigestPreview)
+	relationship.Get("/history", relationshipHandler.GetHistory)
+	relationship.Get("/history/events", relationshipHandler.GetPositionEvents)
+	relationship.Get("/history/export", relationshipHandler.ExportHistory)
+	relationship.Post("/history/import", relationshipHandler.ImportHistory)
+	relationship.Get("/nudges", relationshipHandler.GetNudges)
+	relationship.Post("/nudges", relationshipHandler.SendNudge)
+	relationship.Post("/nudges/read", relationshipHandler.MarkAllNudgesRead)
+	relationship.Post("/nudges/:nudgeId/read", relationshipHandler.MarkNudgeRead)
+	relationship.Get("/journal", relationshipHandler.GetJournal)
+	relationship.Post("/journal", relationshipHandler.CreateJournalEntry)
+	relationship.Get("/journal/:entryId", relationshipHandler.GetJournalEntry)
+	relationship.Put("/journal/:entryId", relationshipHandler.UpdateJournalEntry)
+	relationship.Delete("/journal/:entryId", relationshipHandler.DeleteJournalEntry)
+	relationship.Get("/journal/:entryId/history", relationshipHandler.GetJournalEntryHistory)
+	relationship.Put("/journal/:entryId/reaction", relationshipHandler.ReactToJournalEntry)
+	relationship.Delete("/journal/:entryId/reaction", relationshipHandler.RemoveJournalReaction)
+	relationship.Get("/questions", relationshipHandler.GetQuestionArchive)
+	relationship.Get("/questions/:date", relationshipHandler.GetQuestion)
+	relationship.Put("/questions/:date/answer", relationshipHandler.AnswerQuestion)
+	relationship.Get("/goals", relationshipHandler.GetGoals)
+	relationship.Post("/goals", relationshipHandler.CreateGoal)
+	relationship.Get("/goals/:goalId", relationshipHandler.GetGoal)
+	relationship.Put("/goals/:goalId", relationshipHandler.UpdateGoal)
+	relationship.Delete("/goals/:goalId", relationshipHandler.DeleteGoal)
+	relationship.Post("/goals/:goalId/complete", relationshipHandler.CompleteGoal)
+	relationship.Delete("/goals/:goalId/complete", relationshipHandler.ReopenGoal)
+	relationship.Post("/goals/:goalId/tasks", relationshipHandler.CreateGoalTask)
+	relationship.Put("/goals/:goalId/tasks/:taskId", relationshipHandler.UpdateGoalTask)
+	relationship.Delete("/goals/:goalId/tasks/:taskId", relationshipHandler.DeleteGoalTask)
+	relationship.Post("/goals/:goalId/tasks/:taskId/complete", relationshipHandler.CompleteGoalTask)
+	relationship.Delete("/goals/:goalId/tasks/:taskId/complete", relationshipHandler.ReopenGoalTask)
+	relationship.Get("/stats", relationshipHandler.GetStats)
+	relationship.Get("/milestones", relationshipHandler.GetMilestones)
+	relationship.Get("/milestones/dates", relationshipHandler.GetRelationshipDates)
+	relationship.Post("/milestones/dates", relationshipHandler.CreateRelationshipDate)
+	relationship.Delete("/milestones/dates/:dateId", relationshipHandler.DeleteRelationshipDate)
+	relationship.Get("/events", relationshipHandler.GetEvents)
+	relationship.Post("/events", relationshipHandler.CreateEvent)
+	relationship.Get("/events/:eventId", relationshipHandler.GetEvent)
+	relationship.Put("/events/:eventId", relationshipHandler.UpdateEvent)
+	relationship.Delete("/events/:eventId", relationshipHandler.DeleteEvent)
+	relationship.Get("/calendar/feed", relationshipHandler.GetCalendarFeed)
+	relationship.Post("/calendar/feed/rotate", relationshipHandler.RotateCalendarFeed)
+	relationship.Delete("/calendar/feed", relationshipHandler.DeleteCalendarFeed)
+	relationship.Get("/shares", relationshipHandler.GetShareGrants)
+	relationship.Post("/shares", relationshipHandler.CreateShareGrant)
+	relationship.Post("/shares/:shareId/approve", relationshipHandler.ApproveShareGrant)
+	relationship.Delete("/shares/:shareId", relationshipHandler.RevokeShareGrant)
+	relationship.Get("/shares/:shareId/access", relationshipHandler.GetShareAccessLog)
+	relationship.Get("/start-date/proposals", relationshipHandler.GetStartDateProposals)
+	relationship.Post("/start-date/proposals", relationshipHandler.ProposeStartDate)
+	relationship.Post("/start-date/proposals/:proposalId/approve", relationshipHandler.ApproveStartDate)
+	relationship.Post("/start-date/proposals/:proposalId/reject", relationshipHandler.RejectStartDate)
 	relationship.Delete("/", relationshipHandler.DeleteRelationship)
 }
\ No newline at end of file
@@ -0,0 +1,142 @@
+// Package axes descrie dimensiunile apropierii (comunicare, intimitate, încredere etc.)
+// și calculează poziția compusă 0-100 folosită de helix.
+package axes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+)
+
+// MaxAxes este numărul maxim de dimensiuni configurabile pentru o relație
+const MaxAxes = 10
+
+// Axis este o dimensiune a apropierii. Valoarea Min înseamnă „apropiat”, iar Max „distant”,
+// la fel ca poziția compusă.
+type Axis struct {
+	Key    string  `json:"key"`
+	Label  string  `json:"label"`
+	Weight float64 `json:"weight"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+}
+
+// DefaultAxes sunt dimensiunile folosite când relația nu are o configurație proprie
+var DefaultAxes = []Axis{
+	{Key: "communication", Label: "Comunicare", Weight: 1, Min: 0, Max: 100},
+	{Key: "intimacy", Label: "Intimitate", Weight: 1, Min: 0, Max: 100},
+	{Key: "trust", Label: "Încredere", Weight: 1, Min: 0, Max: 100},
+	{Key: "fun", Label: "Distracție", Weight: 1, Min: 0, Max: 100},
+}
+
+var keyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Erori de validare
+var (
+	ErrNoAxes        = errors.New("cel puțin o dimensiune este obligatorie")
+	ErrTooManyAxes   = fmt.Errorf("cel mult %d dimensiuni sunt permise", MaxAxes)
+	ErrNoValues      = errors.New("nicio valoare cunoscută pentru dimensiuni")
+	ErrUnknownAxis   = errors.New("dimensiune necunoscută")
+	ErrValueOutRange = errors.New("valoare în afara intervalului dimensiunii")
+)
+
+// Validate verifică o configurație de dimensiuni
+func Validate(list []Axis) error {
+	if len(list) == 0 {
+		return ErrNoAxes
+	}
+	if len(list) > MaxAxes {
+		return ErrTooManyAxes
+	}
+
+	seen := make(map[string]bool)
+	for _, a := range list {
+		if !keyPattern.MatchString(a.Key) {
+			return fmt.Errorf("cheie invalidă %q: se acceptă litere mici, cifre și _", a.Key)
+		}
+		if seen[a.Key] {
+			return fmt.Errorf("cheia %q este duplicată", a.Key)
+		}
+		seen[a.Key] = true
+
+		if a.Label == "" || len(a.Label) > 64 {
+			return fmt.Errorf("eticheta dimensiunii %q trebuie să aibă între 1 și 64 de caractere", a.Key)
+		}
+		if a.Weight <= 0 || a.Weight > 100 || math.IsNaN(a.Weight) {
+			return fmt.Errorf("ponderea dimensiunii %q trebuie să fie între 0 și 100", a.Key)
+		}
+		if a.Min >= a.Max {
+			return fmt.Errorf("intervalul dimensiunii %q este invalid", a.Key)
+		}
+	}
+	return nil
+}
+
+// Find returnează dimensiunea cu cheia dată
+func Find(list []Axis, key string) (Axis, bool) {
+	for _, a := range list {
+		if a.Key == key {
+			return a, true
+		}
+	}
+	return Axis{}, false
+}
+
+// CheckValues verifică că valorile aparțin unor dimensiuni cunoscute și sunt în intervalele lor
+func CheckValues(list []Axis, values map[string]int) error {
+	for key, v := range values {
+		a, ok := Find(list, key)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownAxis, key)
+		}
+		if v < a.Min || v > a.Max {
+			return fmt.Errorf("%w: %s trebuie să fie între %d și %d", ErrValueOutRange, key, a.Min, a.Max)
+		}
+	}
+	return nil
+}
+
+// Normalize transformă valoarea unei dimensiuni pe scala 0-100
+func Normalize(a Axis, value int) float64 {
+	return float64(value-a.Min) * 100 / float64(a.Max-a.Min)
+}
+
+// Composite calculează poziția compusă 0-100 ca medie ponderată a valorilor normalizate.
+// Dimensiunile fără valoare sunt ignorate, iar ponderile celorlalte se redistribuie.
+func Composite(list []Axis, values map[string]int) (int, error) {
+	var total, weighted float64
+	for _, a := range list {
+		v, ok := values[a.Key]
+		if !ok {
+			continue
+		}
+		total += a.Weight
+		weighted += a.Weight * Normalize(a, v)
+	}
+	if total == 0 {
+		return 0, ErrNoValues
+	}
+
+	position := int(math.Round(weighted / total))
+	if position < 0 {
+		position = 0
+	}
+	if position > 100 {
+		position = 100
+	}
+	return position, nil
+}
+
+// Merge suprascrie valorile curente cu cele noi, păstrând doar dimensiunile configurate
+func Merge(list []Axis, current, updates map[string]int) map[string]int {
+	result := make(map[string]int)
+	for _, a := range list {
+		if v, ok := updates[a.Key]; ok {
+			result[a.Key] = v
+		} else if v, ok := current[a.Key]; ok {
+			result[a.Key] = v
+		}
+	}
+	return result
+}
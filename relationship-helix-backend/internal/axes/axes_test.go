@@ -0,0 +1,78 @@
+package axes
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestComposite(t *testing.T) {
+	list := []Axis{
+		{Key: "communication", Label: "Comunicare", Weight: 1, Min: 0, Max: 100},
+		{Key: "trust", Label: "Încredere", Weight: 3, Min: 1, Max: 5},
+	}
+
+	tests := []struct {
+		name   string
+		values map[string]int
+		want   int
+	}{
+		// (1*20 + 3*50) / 4 = 42.5
+		{"weighted", map[string]int{"communication": 20, "trust": 3}, 43},
+		{"range endpoints", map[string]int{"communication": 100, "trust": 5}, 100},
+		{"missing axis is ignored", map[string]int{"trust": 1}, 0},
+		{"unknown keys are ignored", map[string]int{"communication": 60, "fun": 0}, 60},
+	}
+
+	for _, tt := range tests {
+		got, err := Composite(list, tt.values)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: composite = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := Composite(list, nil); !errors.Is(err, ErrNoValues) {
+		t.Errorf("composite without values: err = %v, want ErrNoValues", err)
+	}
+}
+
+func TestCheckValues(t *testing.T) {
+	if err := CheckValues(DefaultAxes, map[string]int{"trust": 40, "fun": 0}); err != nil {
+		t.Errorf("valid values: unexpected error %v", err)
+	}
+	if err := CheckValues(DefaultAxes, map[string]int{"money": 10}); !errors.Is(err, ErrUnknownAxis) {
+		t.Errorf("unknown axis: err = %v, want ErrUnknownAxis", err)
+	}
+	if err := CheckValues(DefaultAxes, map[string]int{"trust": 101}); !errors.Is(err, ErrValueOutRange) {
+		t.Errorf("out of range: err = %v, want ErrValueOutRange", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate(DefaultAxes); err != nil {
+		t.Errorf("default axes: unexpected error %v", err)
+	}
+
+	invalid := map[string][]Axis{
+		"empty":       nil,
+		"bad key":     {{Key: "Trust!", Label: "x", Weight: 1, Min: 0, Max: 10}},
+		"duplicate":   {{Key: "a", Label: "a", Weight: 1, Max: 1}, {Key: "a", Label: "b", Weight: 1, Max: 1}},
+		"zero weight": {{Key: "a", Label: "a", Weight: 0, Max: 1}},
+		"bad range":   {{Key: "a", Label: "a", Weight: 1, Min: 5, Max: 5}},
+	}
+	for name, list := range invalid {
+		if err := Validate(list); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge(DefaultAxes, map[string]int{"trust": 10, "fun": 20, "removed": 5}, map[string]int{"fun": 70})
+	if len(merged) != 2 || merged["trust"] != 10 || merged["fun"] != 70 {
+		t.Errorf("merged = %v, want trust=10 fun=70", merged)
+	}
+}
@@ -0,0 +1,91 @@
+package axes
+
+import (
+	"database/sql"
+)
+
+// Execer este implementat atât de *sql.DB, cât și de *sql.Tx
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// Load încarcă dimensiunile configurate pentru relație, sau DefaultAxes dacă nu există o configurație proprie
+func Load(db Execer, relationshipID uint) ([]Axis, error) {
+	rows, err := db.Query(
+		`SELECT key, label, weight, min_value, max_value
+         FROM relationship_axes
+         WHERE relationship_id = $1
+         ORDER BY sort_order, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []Axis
+	for rows.Next() {
+		var a Axis
+		if err := rows.Scan(&a.Key, &a.Label, &a.Weight, &a.Min, &a.Max); err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(list) == 0 {
+		return DefaultAxes, nil
+	}
+	return list, nil
+}
+
+// LoadValues încarcă valorile curente ale utilizatorului pe fiecare dimensiune
+func LoadValues(db Execer, relationshipID, userID uint) (map[string]int, error) {
+	rows, err := db.Query(
+		`SELECT axis_key, value FROM axis_positions WHERE relationship_id = $1 AND user_id = $2`,
+		relationshipID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var value int
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
+
+// SaveValues salvează valorile curente ale utilizatorului și le atașează evenimentului din istoric
+func SaveValues(db Execer, relationshipID, userID, eventID uint, values map[string]int) error {
+	for key, value := range values {
+		_, err := db.Exec(
+			`INSERT INTO axis_positions (relationship_id, user_id, axis_key, value, updated_at)
+             VALUES ($1, $2, $3, $4, NOW())
+             ON CONFLICT (relationship_id, user_id, axis_key)
+             DO UPDATE SET value = $4, updated_at = NOW()`,
+			relationshipID, userID, key, value,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = db.Exec(
+			`INSERT INTO position_event_axes (event_id, axis_key, value) VALUES ($1, $2, $3)`,
+			eventID, key, value,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
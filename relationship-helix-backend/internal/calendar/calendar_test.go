@@ -0,0 +1,229 @@
+package calendar
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRuleNormalizes(t *testing.T) {
+	rule, err := ParseRule("RRULE:freq=weekly;byday=FR,MO,MO;interval=2")
+	if err != nil {
+		t.Fatalf("ParseRule: %v", err)
+	}
+	if got, want := rule.String(), "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"; got != want {
+		t.Fatalf("String() = %q, vrem %q", got, want)
+	}
+}
+
+func TestParseRuleRejectsInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20260101",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYMONTH=1",
+	} {
+		if _, err := ParseRule(value); err == nil {
+			t.Errorf("ParseRule(%q) a fost acceptată", value)
+		}
+	}
+}
+
+func TestOccurrencesWeeklyByDay(t *testing.T) {
+	rule, _ := ParseRule("FREQ=WEEKLY;BYDAY=MO,WE;COUNT=5")
+	// Miercuri, 7 ianuarie 2026
+	start := time.Date(2026, 1, 7, 19, 0, 0, 0, time.UTC)
+	got := rule.Occurrences(start, start, start.AddDate(1, 0, 0), 100)
+
+	want := []string{"2026-01-07", "2026-01-12", "2026-01-14", "2026-01-19", "2026-01-21"}
+	if len(got) != len(want) {
+		t.Fatalf("%d apariții, vrem %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if d := got[i].Format("2006-01-02"); d != w {
+			t.Errorf("apariția %d = %s, vrem %s", i, d, w)
+		}
+	}
+}
+
+func TestOccurrencesMonthlySkipsShortMonths(t *testing.T) {
+	rule, _ := ParseRule("FREQ=MONTHLY")
+	start := time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC)
+	got := rule.Occurrences(start, start, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), 100)
+
+	want := []string{"2026-01-31", "2026-03-31", "2026-05-31"}
+	if len(got) != len(want) {
+		t.Fatalf("%d apariții, vrem %d: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if d := got[i].Format("2006-01-02"); d != w {
+			t.Errorf("apariția %d = %s, vrem %s", i, d, w)
+		}
+	}
+}
+
+func TestOccurrencesCountIncludesEarlierOccurrences(t *testing.T) {
+	rule, _ := ParseRule("FREQ=DAILY;COUNT=3")
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	from := start.AddDate(0, 0, 2)
+	got := rule.Occurrences(start, from, start.AddDate(0, 1, 0), 100)
+
+	if len(got) != 1 || !got[0].Equal(from) {
+		t.Fatalf("Occurrences = %v, vrem doar %v", got, from)
+	}
+}
+
+func TestOccurrencesUntilAndLimit(t *testing.T) {
+	rule, _ := ParseRule("FREQ=DAILY;UNTIL=20260305")
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	if got := rule.Occurrences(start, start, start.AddDate(1, 0, 0), 100); len(got) != 5 {
+		t.Fatalf("%d apariții până pe 5 martie, vrem 5", len(got))
+	}
+
+	rule, _ = ParseRule("FREQ=DAILY")
+	if got := rule.Occurrences(start, start, start.AddDate(1, 0, 0), 10); len(got) != 10 {
+		t.Fatalf("%d apariții cu limita 10", len(got))
+	}
+}
+
+func TestOccurrencesKeepLocalTimeAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Bucharest")
+	if err != nil {
+		t.Skip("baza de date a fusurilor orare nu este disponibilă")
+	}
+
+	rule, _ := ParseRule("FREQ=WEEKLY")
+	// Ora de vară începe pe 29 martie 2026
+	start := time.Date(2026, 3, 22, 19, 0, 0, 0, loc)
+	got := rule.Occurrences(start, start, start.AddDate(0, 0, 14), 10)
+	if len(got) != 3 {
+		t.Fatalf("%d apariții, vrem 3", len(got))
+	}
+	for _, o := range got {
+		if o.Hour() != 19 {
+			t.Errorf("apariția %v nu este la ora 19 locală", o)
+		}
+	}
+	if got[0].UTC().Hour() == got[1].UTC().Hour() {
+		t.Errorf("ora UTC ar trebui să se schimbe după trecerea la ora de vară")
+	}
+}
+
+func TestEscapeText(t *testing.T) {
+	got := EscapeText("Cină; la \"Casa\", etaj 2\nRezervare\\nume")
+	want := `Cină\; la "Casa"\, etaj 2\nRezervare\\nume`
+	if got != want {
+		t.Fatalf("EscapeText = %q, vrem %q", got, want)
+	}
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	cal := &Calendar{
+		Name: "Noi doi",
+		Events: []Event{{
+			UID:     "event-1@test",
+			Summary: strings.Repeat("ă", 60),
+			Start:   time.Date(2026, 2, 14, 19, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 2, 14, 21, 0, 0, 0, time.UTC),
+			Alarms:  []int{30},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("linie de %d octeți: %q", len(line), line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	for _, want := range []string{
+		"SUMMARY:" + strings.Repeat("ă", 60) + "\r\n",
+		"DTSTART:20260214T190000Z\r\n",
+		"TRIGGER:-PT30M\r\n",
+		"X-WR-CALNAME:Noi doi\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("lipsește %q din:\n%s", want, unfolded)
+		}
+	}
+}
+
+func TestWriteAllDayAndRecurringTimeZone(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Bucharest")
+	if err != nil {
+		t.Skip("baza de date a fusurilor orare nu este disponibilă")
+	}
+
+	cal := &Calendar{Events: []Event{
+		{
+			UID:     "anniversary@test",
+			Summary: "Aniversare",
+			Start:   time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			End:     time.Date(2026, 5, 2, 0, 0, 0, 0, time.UTC),
+			AllDay:  true,
+			Rule:    "FREQ=YEARLY",
+		},
+		{
+			UID:     "weekly@test",
+			Summary: "Seara de film",
+			Start:   time.Date(2026, 5, 1, 20, 0, 0, 0, loc),
+			End:     time.Date(2026, 5, 1, 22, 0, 0, 0, loc),
+			Rule:    "FREQ=WEEKLY",
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := cal.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"DTSTART;VALUE=DATE:20260501\r\n",
+		"DTEND;VALUE=DATE:20260502\r\n",
+		"DTSTART;TZID=Europe/Bucharest:20260501T200000\r\n",
+		"DTEND;TZID=Europe/Bucharest:20260501T220000\r\n",
+		"RRULE:FREQ=WEEKLY\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("lipsește %q din:\n%s", want, out)
+		}
+	}
+}
+
+func TestExpandOverlap(t *testing.T) {
+	start := time.Date(2026, 4, 10, 22, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 4, 11, 0, 0, 0, 0, time.UTC)
+
+	// Evenimentul început în seara dinainte se suprapune încă cu intervalul
+	got, err := Expand(start, 3*time.Hour, "", from, from.AddDate(0, 0, 1), 10)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Expand = %v, %v; vrem o apariție", got, err)
+	}
+
+	got, err = Expand(start, time.Hour, "", from, from.AddDate(0, 0, 1), 10)
+	if err != nil || len(got) != 0 {
+		t.Fatalf("Expand = %v, %v; vrem nicio apariție", got, err)
+	}
+
+	got, err = Expand(start, 3*time.Hour, "FREQ=DAILY", from, from.AddDate(0, 0, 2), 10)
+	if err != nil || len(got) != 3 || !got[0].Equal(start) {
+		t.Fatalf("Expand recurent = %v, %v", got, err)
+	}
+
+	if _, err := Expand(start, time.Hour, "FREQ=SECONDLY", from, from, 10); err == nil {
+		t.Fatalf("regula invalidă a fost acceptată")
+	}
+}
@@ -0,0 +1,126 @@
+package calendar
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets este lungimea maximă a unei linii iCalendar, fără CRLF
+const maxLineOctets = 75
+
+// Event este un eveniment din fluxul iCalendar
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start       time.Time
+	End         time.Time // Exclusiv; pentru evenimentele de o zi întreagă, ziua de după ultima zi
+	AllDay      bool
+	Rule        string // Regula RRULE normalizată (opțională)
+	Alarms      []int  // Minute înainte de început
+	Created     time.Time
+	Modified    time.Time
+}
+
+// Calendar este un flux iCalendar
+type Calendar struct {
+	Name   string
+	Events []Event
+}
+
+// Write scrie calendarul în formatul iCalendar (RFC 5545). Evenimentele recurente păstrează fusul orar
+// (TZID cu numele IANA), ca ora locală să rămână aceeași peste schimbările de oră; celelalte sunt în UTC.
+func (c *Calendar) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//Relationship Helix//Calendar//RO")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", EscapeText(c.Name))
+	}
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, e := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", EscapeText(e.UID))
+		line("DTSTAMP", stamp)
+		if !e.Created.IsZero() {
+			line("CREATED", e.Created.UTC().Format("20060102T150405Z"))
+		}
+		if !e.Modified.IsZero() {
+			line("LAST-MODIFIED", e.Modified.UTC().Format("20060102T150405Z"))
+		}
+
+		switch {
+		case e.AllDay:
+			line("DTSTART;VALUE=DATE", e.Start.Format("20060102"))
+			line("DTEND;VALUE=DATE", e.End.Format("20060102"))
+		case e.Rule != "" && e.Start.Location() != time.UTC:
+			tzid := ";TZID=" + e.Start.Location().String()
+			line("DTSTART"+tzid, e.Start.Format("20060102T150405"))
+			line("DTEND"+tzid, e.End.In(e.Start.Location()).Format("20060102T150405"))
+		default:
+			line("DTSTART", e.Start.UTC().Format("20060102T150405Z"))
+			line("DTEND", e.End.UTC().Format("20060102T150405Z"))
+		}
+
+		if e.Rule != "" {
+			line("RRULE", e.Rule)
+		}
+		line("SUMMARY", EscapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", EscapeText(e.Description))
+		}
+		if e.Location != "" {
+			line("LOCATION", EscapeText(e.Location))
+		}
+
+		for _, minutes := range e.Alarms {
+			line("BEGIN", "VALARM")
+			line("ACTION", "DISPLAY")
+			line("DESCRIPTION", EscapeText(e.Summary))
+			line("TRIGGER", "-PT"+strconv.Itoa(minutes)+"M")
+			line("END", "VALARM")
+		}
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// EscapeText escapează o valoare de tip TEXT
+func EscapeText(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`, "\r", `\n`)
+	return replacer.Replace(value)
+}
+
+// writeLine scrie o linie de conținut, împărțită la 75 de octeți fără a rupe caracterele UTF-8
+func writeLine(w *bufio.Writer, content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.WriteString(content[:cut])
+		w.WriteString("\r\n ")
+		content = content[cut:]
+		// Liniile de continuare încep cu un spațiu, inclus în limita de 75 de octeți
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(content)
+	w.WriteString("\r\n")
+}
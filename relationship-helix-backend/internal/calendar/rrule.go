@@ -0,0 +1,273 @@
+// Package calendar conține regulile de recurență ale evenimentelor din calendarul relației
+// și generarea fluxului iCalendar (RFC 5545).
+package calendar
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frecvențele de recurență acceptate
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+// maxPeriods limitează numărul de perioade parcurse la expandarea unei reguli
+const maxPeriods = 100000
+
+// ErrInvalidRule este returnată pentru o regulă de recurență invalidă sau neacceptată
+var ErrInvalidRule = errors.New("calendar: regulă de recurență invalidă")
+
+// weekdays asociază codurile RFC 5545 zilelor săptămânii
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// weekdayCodes este inversul lui weekdays
+var weekdayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Rule este o regulă de recurență (subsetul RRULE acceptat: FREQ, INTERVAL, COUNT, UNTIL și BYDAY pentru WEEKLY)
+type Rule struct {
+	Freq     string
+	Interval int
+	Count    int        // 0 = nelimitat
+	Until    *time.Time // Inclusiv; nil = nelimitat
+	ByDay    []time.Weekday
+}
+
+// ParseRule parsează o regulă RRULE, cu sau fără prefixul "RRULE:"
+func ParseRule(value string) (*Rule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, ErrInvalidRule
+	}
+
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || val == "" || seen[name] {
+			return nil, ErrInvalidRule
+		}
+		seen[name] = true
+
+		switch name {
+		case "FREQ":
+			switch val {
+			case FreqDaily, FreqWeekly, FreqMonthly, FreqYearly:
+				rule.Freq = val
+			default:
+				return nil, ErrInvalidRule
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 || n > 1000 {
+				return nil, ErrInvalidRule
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 || n > 10000 {
+				return nil, ErrInvalidRule
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, ErrInvalidRule
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(val, ",") {
+				day, ok := weekdays[code]
+				if !ok {
+					return nil, ErrInvalidRule
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			// Săptămâna începe mereu luni
+			if val != "MO" {
+				return nil, ErrInvalidRule
+			}
+		default:
+			return nil, ErrInvalidRule
+		}
+	}
+
+	if rule.Freq == "" || (rule.Count > 0 && rule.Until != nil) {
+		return nil, ErrInvalidRule
+	}
+	if len(rule.ByDay) > 0 && rule.Freq != FreqWeekly {
+		return nil, ErrInvalidRule
+	}
+	rule.ByDay = sortWeekdays(rule.ByDay)
+
+	return rule, nil
+}
+
+// parseUntil acceptă atât o dată (YYYYMMDD), cât și un moment UTC (YYYYMMDDTHHMMSSZ)
+func parseUntil(value string) (time.Time, error) {
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		// O dată include toată ziua
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Parse("20060102T150405Z", value)
+}
+
+// String returnează regula în forma normalizată, fără prefixul "RRULE:"
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			codes[i] = weekdayCodes[d]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returnează începuturile aparițiilor din intervalul [from, to], pentru un eveniment care
+// începe la start. Ora locală din fusul orar al lui start se păstrează peste schimbările de oră (DST).
+// Rezultatul are cel mult limit elemente.
+func (r *Rule) Occurrences(start, from, to time.Time, limit int) []time.Time {
+	var result []time.Time
+	count := 0
+
+	// emit returnează false când nu mai pot exista apariții
+	emit := func(t time.Time) bool {
+		if t.Before(start) {
+			return true
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+		if t.After(to) {
+			return false
+		}
+		count++
+		if r.Count > 0 && count > r.Count {
+			return false
+		}
+		if !t.Before(from) {
+			result = append(result, t)
+			if len(result) >= limit {
+				return false
+			}
+		}
+		return true
+	}
+
+	y, m, d := start.Date()
+	hh, mm, ss := start.Clock()
+	loc := start.Location()
+
+	for period := 0; period < maxPeriods; period++ {
+		n := period * r.Interval
+		switch r.Freq {
+		case FreqDaily:
+			if !emit(time.Date(y, m, d+n, hh, mm, ss, 0, loc)) {
+				return result
+			}
+		case FreqWeekly:
+			if len(r.ByDay) == 0 {
+				if !emit(time.Date(y, m, d+7*n, hh, mm, ss, 0, loc)) {
+					return result
+				}
+				continue
+			}
+			// Zilele din săptămâna (luni-duminică) care conține start, deplasată cu n săptămâni
+			monday := d - (int(start.Weekday())+6)%7 + 7*n
+			for _, day := range r.ByDay {
+				offset := (int(day) + 6) % 7
+				if !emit(time.Date(y, m, monday+offset, hh, mm, ss, 0, loc)) {
+					return result
+				}
+			}
+		case FreqMonthly:
+			// Lunile fără ziua respectivă (ex. 31) sunt sărite, ca în RFC 5545
+			t := time.Date(y, m+time.Month(n), d, hh, mm, ss, 0, loc)
+			if t.Day() != d {
+				continue
+			}
+			if !emit(t) {
+				return result
+			}
+		case FreqYearly:
+			t := time.Date(y+n, m, d, hh, mm, ss, 0, loc)
+			if t.Day() != d {
+				continue
+			}
+			if !emit(t) {
+				return result
+			}
+		default:
+			return result
+		}
+	}
+
+	return result
+}
+
+// sortWeekdays ordonează zilele de luni până duminică și elimină duplicatele
+func sortWeekdays(days []time.Weekday) []time.Weekday {
+	if len(days) == 0 {
+		return nil
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return (int(days[i])+6)%7 < (int(days[j])+6)%7
+	})
+	result := days[:1]
+	for _, d := range days[1:] {
+		if d != result[len(result)-1] {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// Expand returnează începuturile aparițiilor unui eveniment (cu durata dată) care se suprapun cu
+// intervalul [from, to]. Fără regulă, evenimentul are o singură apariție.
+func Expand(start time.Time, duration time.Duration, rule string, from, to time.Time, limit int) ([]time.Time, error) {
+	if rule == "" {
+		if start.Add(duration).After(from) && !start.After(to) {
+			return []time.Time{start}, nil
+		}
+		return nil, nil
+	}
+
+	r, err := ParseRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	// O apariție care se termină exact la from nu se suprapune cu intervalul
+	return r.Occurrences(start, from.Add(-duration+time.Nanosecond), to, limit), nil
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
 	"strings"
@@ -23,6 +24,69 @@ type Config struct {
 
 	// Invite Code
 	InviteCodeExpiration time.Duration
+	InviteLinkSecret     string
+	InviteLinkExpiration time.Duration
+
+	// Frontend
+	AppURL string
+
+	// URL-ul public al API-ului (folosit în link-urile fluxurilor iCalendar)
+	PublicURL string
+
+	// Fus orar implicit (IANA) pentru calculele calendaristice
+	DefaultTimezone string
+
+	// Notițe atașate pozițiilor
+	NoteMaxLength int
+	MoodTags      []string
+
+	// Fereastra în care o actualizare de poziție poate fi anulată (0 = anularea este dezactivată);
+	// opțional, actualizarea este trimisă celorlalți membri abia după închiderea ferestrei
+	PositionUndoWindow     time.Duration
+	DelayPositionBroadcast bool
+
+	// Limita de gesturi („nudge”) trimise de un membru într-o relație, pe fereastră
+	NudgeLimit  int
+	NudgeWindow time.Duration
+
+	// Lungimea maximă a unei intrări din jurnal
+	JournalMaxLength int
+
+	// Numărul maxim de membri ai unui grup
+	GroupMaxMembers int
+
+	// Numărul maxim de relații simultane ale unui utilizator, pe categorii (0 = nelimitat)
+	RelationshipLimits map[string]int
+
+	// Mementouri zilnice de actualizare a poziției
+	ReminderDefaultTime   string
+	ReminderSnoozeMinutes int
+
+	// Canalele de notificare în afara aplicației (log, email, webhook, push)
+	Notifiers        []string
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUsername     string
+	SMTPPassword     string
+	SMTPFrom         string
+	NotifyWebhookURL string
+
+	// Rezumatul săptămânal prin email (DigestMailer: log sau smtp)
+	DigestMailer   string
+	DigestSendTime string
+
+	// Web Push (VAPID)
+	VAPIDPrivateKey  string
+	VAPIDSubject     string
+	PushTTL          time.Duration
+	PushMaxFailures  int
+	PushAllowPrivate bool
+
+	// Webhook-uri
+	WebhookMaxAttempts  int
+	WebhookDisableAfter int
+	WebhookTimeout      time.Duration
+	WebhookAllowPrivate bool
 }
 
 // LoadConfig încarcă configurația din variabilele de mediu
@@ -56,10 +120,188 @@ func LoadConfig() *Config {
 		inviteExpiration = 24
 	}
 	config.InviteCodeExpiration = time.Duration(inviteExpiration) * time.Hour
+	config.InviteLinkSecret = getEnv("INVITE_LINK_SECRET", "")
+	inviteLinkExpiration, err := strconv.Atoi(getEnv("INVITE_LINK_EXPIRATION_HOURS", strconv.Itoa(inviteExpiration)))
+	if err != nil {
+		inviteLinkExpiration = inviteExpiration
+	}
+	config.InviteLinkExpiration = time.Duration(inviteLinkExpiration) * time.Hour
+
+	// Frontend
+	config.AppURL = getEnv("APP_URL", "http://localhost:3000")
+	config.PublicURL = strings.TrimRight(getEnv("PUBLIC_URL", "http://localhost:"+strconv.Itoa(config.ServerPort)), "/")
+
+	// Fus orar
+	config.DefaultTimezone = getEnv("DEFAULT_TIMEZONE", "Europe/Bucharest")
+
+	// Notițe
+	noteMaxLength, err := strconv.Atoi(getEnv("NOTE_MAX_LENGTH", "280"))
+	if err != nil || noteMaxLength <= 0 {
+		noteMaxLength = 280
+	}
+	config.NoteMaxLength = noteMaxLength
+	config.MoodTags = splitList(getEnv("MOOD_TAGS", "happy,loved,calm,grateful,tired,stressed,anxious,sad,frustrated,lonely"))
+
+	// Anularea actualizărilor de poziție
+	undoSeconds, err := strconv.Atoi(getEnv("POSITION_UNDO_SECONDS", "30"))
+	if err != nil || undoSeconds < 0 {
+		undoSeconds = 30
+	}
+	config.PositionUndoWindow = time.Duration(undoSeconds) * time.Second
+	config.DelayPositionBroadcast = getEnv("POSITION_DELAY_BROADCAST", "false") == "true"
+
+	// Gesturi
+	nudgeLimit, err := strconv.Atoi(getEnv("NUDGE_LIMIT", "20"))
+	if err != nil || nudgeLimit <= 0 {
+		nudgeLimit = 20
+	}
+	config.NudgeLimit = nudgeLimit
+	nudgeWindow, err := strconv.Atoi(getEnv("NUDGE_WINDOW_MINUTES", "60"))
+	if err != nil || nudgeWindow <= 0 {
+		nudgeWindow = 60
+	}
+	config.NudgeWindow = time.Duration(nudgeWindow) * time.Minute
+
+	// Jurnal
+	journalMaxLength, err := strconv.Atoi(getEnv("JOURNAL_MAX_LENGTH", "5000"))
+	if err != nil || journalMaxLength <= 0 {
+		journalMaxLength = 5000
+	}
+	config.JournalMaxLength = journalMaxLength
+
+	// Grupuri
+	groupMaxMembers, err := strconv.Atoi(getEnv("GROUP_MAX_MEMBERS", "12"))
+	if err != nil || groupMaxMembers < 2 {
+		groupMaxMembers = 12
+	}
+	config.GroupMaxMembers = groupMaxMembers
+
+	// Limite pe categorii de relații, de forma categorie:număr
+	config.RelationshipLimits = make(map[string]int)
+	for _, item := range splitList(getEnv("RELATIONSHIP_LIMITS", "romantic:1,friendship:20,family:20,custom:10")) {
+		name, value, found := strings.Cut(item, ":")
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if !found || err != nil || limit < 0 {
+			continue
+		}
+		config.RelationshipLimits[strings.TrimSpace(name)] = limit
+	}
+
+	// Mementouri
+	config.ReminderDefaultTime = getEnv("REMINDER_DEFAULT_TIME", "20:00")
+	snoozeMinutes, err := strconv.Atoi(getEnv("REMINDER_SNOOZE_MINUTES", "60"))
+	if err != nil || snoozeMinutes <= 0 {
+		snoozeMinutes = 60
+	}
+	config.ReminderSnoozeMinutes = snoozeMinutes
+
+	// Notificări
+	config.Notifiers = splitList(getEnv("NOTIFIERS", "log"))
+	config.SMTPHost = getEnv("SMTP_HOST", "")
+	smtpPort, err := strconv.Atoi(getEnv("SMTP_PORT", "587"))
+	if err != nil {
+		smtpPort = 587
+	}
+	config.SMTPPort = smtpPort
+	config.SMTPUsername = getEnv("SMTP_USERNAME", "")
+	config.SMTPPassword = getEnv("SMTP_PASSWORD", "")
+	config.SMTPFrom = getEnv("SMTP_FROM", "")
+	config.NotifyWebhookURL = getEnv("NOTIFY_WEBHOOK_URL", "")
+
+	// Rezumatul săptămânal
+	config.DigestMailer = getEnv("DIGEST_MAILER", "log")
+	config.DigestSendTime = getEnv("DIGEST_SEND_TIME", "09:00")
+
+	// Web Push
+	config.VAPIDPrivateKey = getEnv("VAPID_PRIVATE_KEY", "")
+	config.VAPIDSubject = getEnv("VAPID_SUBJECT", config.AppURL)
+	pushTTL, err := strconv.Atoi(getEnv("PUSH_TTL_HOURS", "24"))
+	if err != nil || pushTTL < 0 {
+		pushTTL = 24
+	}
+	config.PushTTL = time.Duration(pushTTL) * time.Hour
+	pushMaxFailures, err := strconv.Atoi(getEnv("PUSH_MAX_FAILURES", "5"))
+	if err != nil || pushMaxFailures <= 0 {
+		pushMaxFailures = 5
+	}
+	config.PushMaxFailures = pushMaxFailures
+	config.PushAllowPrivate = getEnv("PUSH_ALLOW_PRIVATE", "false") == "true"
+
+	// Webhook-uri
+	webhookMaxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "6"))
+	if err != nil || webhookMaxAttempts <= 0 {
+		webhookMaxAttempts = 6
+	}
+	config.WebhookMaxAttempts = webhookMaxAttempts
+	webhookDisableAfter, err := strconv.Atoi(getEnv("WEBHOOK_DISABLE_AFTER", "5"))
+	if err != nil || webhookDisableAfter <= 0 {
+		webhookDisableAfter = 5
+	}
+	config.WebhookDisableAfter = webhookDisableAfter
+	webhookTimeout, err := strconv.Atoi(getEnv("WEBHOOK_TIMEOUT_SECONDS", "10"))
+	if err != nil || webhookTimeout <= 0 {
+		webhookTimeout = 10
+	}
+	config.WebhookTimeout = time.Duration(webhookTimeout) * time.Second
+	config.WebhookAllowPrivate = getEnv("WEBHOOK_ALLOW_PRIVATE", "false") == "true"
 
 	return config
 }
 
+// Validate verifică setările obligatorii, fără valoare implicită sigură
+func (c *Config) Validate() error {
+	// Secretul link-urilor de invitație nu este derivat din JWT_SECRET: cine îl cunoaște poate semna invitații
+	if c.InviteLinkSecret == "" {
+		return errors.New("INVITE_LINK_SECRET nu este setată")
+	}
+	return nil
+}
+
+// DefaultLocation returnează fusul orar implicit, sau UTC dacă acesta nu este valid
+func (c *Config) DefaultLocation() *time.Location {
+	loc, err := time.LoadLocation(c.DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Location returnează fusul orar cu numele dat, sau fusul orar implicit dacă acesta lipsește sau nu este valid
+func (c *Config) Location(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return c.DefaultLocation()
+}
+
+// IsMoodTag verifică dacă eticheta face parte din vocabularul configurat
+func (c *Config) IsMoodTag(tag string) bool {
+	for _, t := range c.MoodTags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// RelationshipLimit returnează numărul maxim de relații din categoria dată (0 = nelimitat)
+func (c *Config) RelationshipLimit(relationshipType string) int {
+	return c.RelationshipLimits[relationshipType]
+}
+
+// splitList împarte o listă separată prin virgulă, ignorând elementele goale
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // getEnv obține o variabilă de mediu sau utilizează valoarea implicită dacă nu este setată
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -0,0 +1,16 @@
+-- Crearea tabelei pentru propunerile de modificare a datei de început
+-- Tabela păstrează și istoricul modificărilor (audit)
+CREATE TABLE IF NOT EXISTS start_date_proposals (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    proposed_by INTEGER NOT NULL REFERENCES users(id),
+    proposed_date TIMESTAMP NOT NULL,
+    previous_date TIMESTAMP NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    responded_by INTEGER REFERENCES users(id),
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX idx_start_date_proposals_relationship_id ON start_date_proposals(relationship_id);
@@ -0,0 +1,26 @@
+-- Crearea tabelei pentru datele speciale definite de utilizatori
+CREATE TABLE IF NOT EXISTS relationship_dates (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    title VARCHAR(100) NOT NULL,
+    date DATE NOT NULL,
+    recurring BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru aniversările deja notificate
+CREATE TABLE IF NOT EXISTS milestone_notifications (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    milestone_key VARCHAR(100) NOT NULL,
+    milestone_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    
+    -- O aniversare este notificată o singură dată fiecărui partener
+    UNIQUE(relationship_id, user_id, milestone_key, milestone_date)
+);
+
+-- Indecși pentru performanță
+CREATE INDEX idx_relationship_dates_relationship_id ON relationship_dates(relationship_id);
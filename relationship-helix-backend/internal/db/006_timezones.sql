@@ -0,0 +1,46 @@
+-- Fusul orar IANA al fiecărui utilizator (NULL = fusul orar implicit al serverului)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+
+-- Conversia coloanelor de timp la TIMESTAMPTZ. Valorile existente au fost scrise cu NOW() sau cu ora
+-- locală a serverului, deci sunt interpretate în fusul orar al sesiunii (TimeZone din postgresql.conf),
+-- nu în UTC; migrația trebuie rulată cu aceeași setare TimeZone cu care au fost scrise datele.
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE relationships
+    ALTER COLUMN start_date TYPE TIMESTAMPTZ USING start_date AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE invite_codes
+    ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE curve_positions
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN updated_at TYPE TIMESTAMPTZ USING updated_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE start_date_proposals
+    ALTER COLUMN proposed_date TYPE TIMESTAMPTZ USING proposed_date AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN previous_date TYPE TIMESTAMPTZ USING previous_date AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN responded_at TYPE TIMESTAMPTZ USING responded_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE relationship_dates
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE milestone_notifications
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone');
+
+-- Crearea tabelei pentru istoricul pozițiilor (un rând pentru fiecare actualizare)
+CREATE TABLE IF NOT EXISTS position_events (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    position INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX idx_position_events_relationship_id_created_at ON position_events(relationship_id, created_at);
@@ -0,0 +1,6 @@
+-- Notițe, stare și emoji atașate actualizărilor de poziție
+ALTER TABLE position_events
+    ADD COLUMN IF NOT EXISTS note TEXT,
+    ADD COLUMN IF NOT EXISTS mood VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS emoji VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS visibility VARCHAR(10) NOT NULL DEFAULT 'shared';
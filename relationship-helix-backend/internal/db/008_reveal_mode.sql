@@ -0,0 +1,21 @@
+-- Modul „dezvăluire simultană” pentru fiecare relație
+ALTER TABLE relationships
+    ADD COLUMN IF NOT EXISTS reveal_together BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS reveal_window_minutes INTEGER NOT NULL DEFAULT 1440;
+
+-- Crearea tabelei pentru pozițiile sigilate, care așteaptă dezvăluirea
+CREATE TABLE IF NOT EXISTS sealed_positions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    position INTEGER NOT NULL,
+    note TEXT,
+    mood VARCHAR(32),
+    emoji VARCHAR(32),
+    visibility VARCHAR(10) NOT NULL DEFAULT 'shared',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    -- Un utilizator are o singură poziție sigilată pentru o relație
+    UNIQUE(relationship_id, user_id)
+);
@@ -0,0 +1,12 @@
+-- Crearea tabelei pentru pauzele de intimitate
+CREATE TABLE IF NOT EXISTS position_pauses (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ
+);
+
+-- Un utilizator are cel mult o pauză deschisă într-o relație
+CREATE UNIQUE INDEX IF NOT EXISTS idx_position_pauses_open ON position_pauses(relationship_id, user_id) WHERE ended_at IS NULL;
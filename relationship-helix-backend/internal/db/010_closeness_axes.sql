@@ -0,0 +1,38 @@
+-- Crearea tabelei pentru dimensiunile apropierii configurate pe relație
+CREATE TABLE IF NOT EXISTS relationship_axes (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    key VARCHAR(32) NOT NULL,
+    label VARCHAR(64) NOT NULL,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    min_value INTEGER NOT NULL DEFAULT 0,
+    max_value INTEGER NOT NULL DEFAULT 100,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    UNIQUE(relationship_id, key)
+);
+
+-- Crearea tabelei pentru valorile curente ale fiecărui partener pe dimensiuni
+CREATE TABLE IF NOT EXISTS axis_positions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    axis_key VARCHAR(32) NOT NULL,
+    value INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    UNIQUE(relationship_id, user_id, axis_key)
+);
+
+-- Crearea tabelei pentru valorile pe dimensiuni din istoricul pozițiilor
+CREATE TABLE IF NOT EXISTS position_event_axes (
+    event_id INTEGER NOT NULL REFERENCES position_events(id) ON DELETE CASCADE,
+    axis_key VARCHAR(32) NOT NULL,
+    value INTEGER NOT NULL,
+    
+    PRIMARY KEY(event_id, axis_key)
+);
+
+-- Pozițiile sigilate păstrează și valorile pe dimensiuni
+ALTER TABLE sealed_positions ADD COLUMN IF NOT EXISTS axes JSONB;
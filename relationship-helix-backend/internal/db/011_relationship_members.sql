@@ -0,0 +1,37 @@
+-- Tipul relației (cuplu sau grup) și numele opțional al grupului
+ALTER TABLE relationships
+    ADD COLUMN IF NOT EXISTS kind VARCHAR(16) NOT NULL DEFAULT 'couple',
+    ADD COLUMN IF NOT EXISTS name VARCHAR(100);
+
+-- Crearea tabelei pentru membrii relațiilor
+CREATE TABLE IF NOT EXISTS relationship_members (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    display_name VARCHAR(100) NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    -- Un utilizator apare o singură dată într-o relație
+    UNIQUE(relationship_id, user_id)
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_members_user_id ON relationship_members(user_id);
+
+-- Mută perechile existente în tabela de membri (cel care a invitat devine proprietar)
+INSERT INTO relationship_members (relationship_id, user_id, display_name, role, joined_at)
+SELECT id, user1_id, user1_name, 'owner', created_at FROM relationships
+UNION ALL
+SELECT id, user2_id, user2_name, 'member', created_at FROM relationships
+ON CONFLICT (relationship_id, user_id) DO NOTHING;
+
+-- Perechile nu mai sunt codificate în tabela de relații
+ALTER TABLE relationships
+    DROP COLUMN IF EXISTS user1_id,
+    DROP COLUMN IF EXISTS user2_id,
+    DROP COLUMN IF EXISTS user1_name,
+    DROP COLUMN IF EXISTS user2_name;
+
+-- Codurile de invitație pot adăuga membri într-un grup existent
+ALTER TABLE invite_codes ADD COLUMN IF NOT EXISTS relationship_id INTEGER REFERENCES relationships(id) ON DELETE CASCADE;
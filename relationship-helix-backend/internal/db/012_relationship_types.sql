@@ -0,0 +1,12 @@
+-- Categoria relației (romantic, friendship, family sau custom, cu o etichetă proprie)
+ALTER TABLE relationships
+    ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'romantic',
+    ADD COLUMN IF NOT EXISTS type_label VARCHAR(50);
+
+-- Codurile de invitație rețin categoria relației pe care o vor crea
+ALTER TABLE invite_codes
+    ADD COLUMN IF NOT EXISTS type VARCHAR(16) NOT NULL DEFAULT 'romantic',
+    ADD COLUMN IF NOT EXISTS type_label VARCHAR(50);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_members_relationship_id ON relationship_members(relationship_id);
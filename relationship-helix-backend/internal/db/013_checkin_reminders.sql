@@ -0,0 +1,11 @@
+-- Crearea tabelei pentru preferințele de memento zilnic (lipsa rândului = preferințele implicite)
+CREATE TABLE IF NOT EXISTS checkin_reminders (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    remind_at INTEGER,   -- minute de la miezul nopții, în fusul orar al utilizatorului (NULL = ora implicită)
+    quiet_start INTEGER,
+    quiet_end INTEGER,
+    snoozed_until TIMESTAMPTZ,
+    last_sent_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
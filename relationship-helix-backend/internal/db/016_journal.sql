@@ -0,0 +1,37 @@
+-- Crearea tabelei pentru jurnalul comun al relației
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT,   -- NULL după ștergere
+    position_event_id INTEGER REFERENCES position_events(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_journal_entries_relationship_id ON journal_entries(relationship_id, id);
+
+-- Crearea tabelei pentru versiunile anterioare ale intrărilor (la editare și ștergere)
+CREATE TABLE IF NOT EXISTS journal_entry_revisions (
+    id SERIAL PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL,   -- edited sau deleted
+    body TEXT NOT NULL,
+    position_event_id INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_journal_entry_revisions_entry_id ON journal_entry_revisions(entry_id);
+
+-- Crearea tabelei pentru reacțiile la intrări (o reacție per membru)
+CREATE TABLE IF NOT EXISTS journal_reactions (
+    entry_id INTEGER NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (entry_id, user_id)
+);
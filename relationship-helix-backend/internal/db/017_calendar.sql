@@ -0,0 +1,39 @@
+-- Crearea tabelei pentru evenimentele din calendarul relației
+CREATE TABLE IF NOT EXISTS relationship_events (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    location VARCHAR(255),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,   -- exclusiv; pentru o zi întreagă, miezul nopții de după ultima zi
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL,   -- fusul orar în care se repetă evenimentul
+    recurrence TEXT,   -- regula RRULE normalizată (NULL = o singură dată)
+    reminders INTEGER[] NOT NULL DEFAULT '{}',   -- minute înainte de început
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_events_relationship_id ON relationship_events(relationship_id, starts_at);
+
+-- Crearea tabelei pentru mementourile de eveniment deja trimise
+CREATE TABLE IF NOT EXISTS relationship_event_reminders (
+    event_id INTEGER NOT NULL REFERENCES relationship_events(id) ON DELETE CASCADE,
+    occurrence_at TIMESTAMPTZ NOT NULL,
+    minutes_before INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, occurrence_at, minutes_before)
+);
+
+-- Crearea tabelei pentru link-urile private ale fluxului iCalendar (unul per membru și relație)
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, relationship_id)
+);
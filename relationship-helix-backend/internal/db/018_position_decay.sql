@@ -0,0 +1,10 @@
+-- Politica de estompare a pozițiilor neactualizate, pentru fiecare relație
+ALTER TABLE relationships
+    ADD COLUMN IF NOT EXISTS decay_mode VARCHAR(10) NOT NULL DEFAULT 'off', -- off, stale sau drift
+    ADD COLUMN IF NOT EXISTS decay_after_days INTEGER NOT NULL DEFAULT 14,
+    ADD COLUMN IF NOT EXISTS decay_rate INTEGER NOT NULL DEFAULT 5;       -- puncte pe zi spre mijlocul scalei
+
+-- Starea calculată de job-ul de estompare (NULL = poziția este actuală)
+ALTER TABLE curve_positions
+    ADD COLUMN IF NOT EXISTS decayed_position INTEGER,
+    ADD COLUMN IF NOT EXISTS stale_since TIMESTAMPTZ;
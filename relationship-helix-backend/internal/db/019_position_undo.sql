@@ -0,0 +1,6 @@
+-- Anularea unei actualizări de poziție în fereastra de anulare
+ALTER TABLE position_events
+    ADD COLUMN IF NOT EXISTS previous_position INTEGER,          -- NULL = actualizarea nu poate fi anulată
+    ADD COLUMN IF NOT EXISTS previous_updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reverted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reverts_event_id INTEGER REFERENCES position_events(id) ON DELETE SET NULL;
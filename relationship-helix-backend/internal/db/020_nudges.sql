@@ -0,0 +1,15 @@
+-- Crearea tabelei pentru gesturile trimise între membri („nudge”), cu confirmare de citire
+CREATE TABLE IF NOT EXISTS nudges (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru lista gesturilor și pentru limita de trimitere
+CREATE INDEX IF NOT EXISTS idx_nudges_relationship_id_id ON nudges(relationship_id, id);
+CREATE INDEX IF NOT EXISTS idx_nudges_recipient_unread ON nudges(relationship_id, recipient_id) WHERE read_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_nudges_sender_id_created_at ON nudges(sender_id, created_at);
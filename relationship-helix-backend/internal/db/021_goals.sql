@@ -0,0 +1,35 @@
+-- Crearea tabelei pentru obiectivele comune ale relației
+CREATE TABLE IF NOT EXISTS relationship_goals (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    due_date DATE,
+    assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open', -- open, in_progress sau done
+    completed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru sarcinile (lista de verificare) fiecărui obiectiv
+CREATE TABLE IF NOT EXISTS goal_tasks (
+    id SERIAL PRIMARY KEY,
+    goal_id INTEGER NOT NULL REFERENCES relationship_goals(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    due_date DATE,
+    assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    completed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_goals_relationship_id ON relationship_goals(relationship_id);
+CREATE INDEX IF NOT EXISTS idx_goal_tasks_goal_id ON goal_tasks(goal_id, sort_order);
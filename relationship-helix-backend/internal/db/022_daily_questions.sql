@@ -0,0 +1,24 @@
+-- Crearea tabelei pentru întrebarea zilei atribuită fiecărei relații
+CREATE TABLE IF NOT EXISTS daily_questions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    question_date DATE NOT NULL,
+    question_id VARCHAR(32) NOT NULL, -- ID-ul întrebării din biblioteca inclusă în aplicație
+    revealed_at TIMESTAMPTZ, -- Momentul în care toți membrii au răspuns
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(relationship_id, question_date)
+);
+
+-- Crearea tabelei pentru răspunsurile membrilor la întrebarea zilei
+CREATE TABLE IF NOT EXISTS daily_answers (
+    id SERIAL PRIMARY KEY,
+    daily_question_id INTEGER NOT NULL REFERENCES daily_questions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    answer TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(daily_question_id, user_id)
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_daily_questions_relationship_date ON daily_questions(relationship_id, question_date DESC);
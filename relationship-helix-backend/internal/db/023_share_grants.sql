@@ -0,0 +1,34 @@
+-- Crearea tabelei pentru accesul partajat, doar pentru citire, al unei terțe persoane (ex. un terapeut)
+CREATE TABLE IF NOT EXISTS share_grants (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    label VARCHAR(100) NOT NULL, -- Cui îi este destinat accesul (ex. numele terapeutului)
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 al token-ului; token-ul este afișat o singură dată
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    revoked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru aprobările membrilor; accesul este activ doar după aprobarea tuturor membrilor
+CREATE TABLE IF NOT EXISTS share_grant_approvals (
+    grant_id INTEGER NOT NULL REFERENCES share_grants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approved_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (grant_id, user_id)
+);
+
+-- Crearea tabelei pentru jurnalul accesărilor, vizibil tuturor membrilor
+CREATE TABLE IF NOT EXISTS share_access_log (
+    id SERIAL PRIMARY KEY,
+    grant_id INTEGER NOT NULL REFERENCES share_grants(id) ON DELETE CASCADE,
+    resource VARCHAR(32) NOT NULL, -- overview, history, stats sau notes
+    ip_address VARCHAR(64),
+    user_agent VARCHAR(255),
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_share_grants_relationship_id ON share_grants(relationship_id);
+CREATE INDEX IF NOT EXISTS idx_share_access_log_grant_id ON share_access_log(grant_id, id DESC);
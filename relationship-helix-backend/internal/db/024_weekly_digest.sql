@@ -0,0 +1,20 @@
+-- Crearea tabelei pentru abonarea la rezumatul săptămânal (lipsa rândului = neabonat)
+CREATE TABLE IF NOT EXISTS digest_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru rezumatele trimise; cheia primară garantează cel mult o trimitere pe săptămână
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    status VARCHAR(16) NOT NULL, -- sending, sent, skipped (nimic de raportat) sau failed
+    attempts INTEGER NOT NULL DEFAULT 1,
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (relationship_id, user_id, week_start)
+);
@@ -0,0 +1,3 @@
+-- Pozițiile importate din fișiere (istoric de dinaintea aplicației); NULL = actualizare făcută în aplicație
+ALTER TABLE position_events
+    ADD COLUMN IF NOT EXISTS imported_at TIMESTAMPTZ;
@@ -0,0 +1,12 @@
+-- Token-ul fluxului iCalendar se salvează doar ca amprentă SHA-256; link-ul este afișat o singură dată
+ALTER TABLE calendar_feeds ADD COLUMN IF NOT EXISTS token_hash VARCHAR(64);
+
+UPDATE calendar_feeds
+SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex')
+WHERE token_hash IS NULL;
+
+ALTER TABLE calendar_feeds
+    ALTER COLUMN token_hash SET NOT NULL,
+    DROP COLUMN IF EXISTS token;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token_hash ON calendar_feeds(token_hash);
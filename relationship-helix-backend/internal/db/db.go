@@ -13,6 +13,13 @@ import (
 	_ "github.com/lib/pq"
 )
 
+// Queryer este implementat atât de *sql.DB, cât și de *sql.Tx; funcțiile care doar citesc îl acceptă
+// ca să poată rula și în interiorul unei tranzacții
+type Queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
 // InitDB inițializează conexiunea la baza de date
 func InitDB(databaseURL string) (*sql.DB, error) {
 	// Conectare la baza de date
@@ -4,8 +4,9 @@ CREATE TABLE IF NOT EXISTS users (
     username VARCHAR(100) NOT NULL,
     email VARCHAR(255) NOT NULL UNIQUE,
     password VARCHAR(255) NOT NULL,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+    timezone VARCHAR(64),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 -- Indecși pentru performanță
@@ -14,29 +15,44 @@ CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 -- Crearea tabelei pentru relații
 CREATE TABLE IF NOT EXISTS relationships (
     id SERIAL PRIMARY KEY,
-    user1_id INTEGER NOT NULL REFERENCES users(id),
-    user2_id INTEGER NOT NULL REFERENCES users(id),
-    user1_name VARCHAR(100) NOT NULL,
-    user2_name VARCHAR(100) NOT NULL,
-    start_date TIMESTAMP NOT NULL DEFAULT NOW(),
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    kind VARCHAR(16) NOT NULL DEFAULT 'couple',
+    name VARCHAR(100),
+    type VARCHAR(16) NOT NULL DEFAULT 'romantic',
+    type_label VARCHAR(50),
+    start_date TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reveal_together BOOLEAN NOT NULL DEFAULT FALSE,
+    reveal_window_minutes INTEGER NOT NULL DEFAULT 1440
+);
+
+-- Crearea tabelei pentru membrii relațiilor
+CREATE TABLE IF NOT EXISTS relationship_members (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    display_name VARCHAR(100) NOT NULL,
+    role VARCHAR(16) NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     
-    -- O relație unică între doi utilizatori
-    UNIQUE(user1_id, user2_id)
+    -- Un utilizator apare o singură dată într-o relație
+    UNIQUE(relationship_id, user_id)
 );
 
 -- Indecși pentru performanță
-CREATE INDEX IF NOT EXISTS idx_relationships_user1_id ON relationships(user1_id);
-CREATE INDEX IF NOT EXISTS idx_relationships_user2_id ON relationships(user2_id);
+CREATE INDEX IF NOT EXISTS idx_relationship_members_user_id ON relationship_members(user_id);
+CREATE INDEX IF NOT EXISTS idx_relationship_members_relationship_id ON relationship_members(relationship_id);
 
 -- Crearea tabelei pentru coduri de invitație
 CREATE TABLE IF NOT EXISTS invite_codes (
     id SERIAL PRIMARY KEY,
     user_id INTEGER NOT NULL REFERENCES users(id),
+    relationship_id INTEGER REFERENCES relationships(id) ON DELETE CASCADE,
     code VARCHAR(20) NOT NULL UNIQUE,
-    expires_at TIMESTAMP NOT NULL,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+    type VARCHAR(16) NOT NULL DEFAULT 'romantic',
+    type_label VARCHAR(50),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 -- Indecși pentru performanță
@@ -49,8 +65,8 @@ CREATE TABLE IF NOT EXISTS curve_positions (
     relationship_id INTEGER NOT NULL REFERENCES relationships(id),
     user_id INTEGER NOT NULL REFERENCES users(id),
     position INTEGER NOT NULL DEFAULT 0,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     
     -- Un utilizator are o singură poziție pentru o relație
     UNIQUE(relationship_id, user_id)
@@ -58,4 +74,456 @@ CREATE TABLE IF NOT EXISTS curve_positions (
 
 -- Indecși pentru performanță
 CREATE INDEX IF NOT EXISTS idx_curve_positions_relationship_id ON curve_positions(relationship_id);
-CREATE INDEX IF NOT EXISTS idx_curve_positions_user_id ON curve_positions(user_id);
\ No newline at end of file
+CREATE INDEX IF NOT EXISTS idx_curve_positions_user_id ON curve_positions(user_id);
+
+-- Crearea tabelei pentru propunerile de modificare a datei de început
+-- Tabela păstrează și istoricul modificărilor (audit)
+CREATE TABLE IF NOT EXISTS start_date_proposals (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    proposed_by INTEGER NOT NULL REFERENCES users(id),
+    proposed_date TIMESTAMPTZ NOT NULL,
+    previous_date TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    responded_by INTEGER REFERENCES users(id),
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_start_date_proposals_relationship_id ON start_date_proposals(relationship_id);
+
+
+-- Crearea tabelei pentru datele speciale definite de utilizatori
+CREATE TABLE IF NOT EXISTS relationship_dates (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER NOT NULL REFERENCES users(id),
+    title VARCHAR(100) NOT NULL,
+    date DATE NOT NULL,
+    recurring BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru aniversările deja notificate
+CREATE TABLE IF NOT EXISTS milestone_notifications (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    milestone_key VARCHAR(100) NOT NULL,
+    milestone_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    -- O aniversare este notificată o singură dată fiecărui partener
+    UNIQUE(relationship_id, user_id, milestone_key, milestone_date)
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_dates_relationship_id ON relationship_dates(relationship_id);
+
+-- Crearea tabelei pentru istoricul pozițiilor (un rând pentru fiecare actualizare)
+CREATE TABLE IF NOT EXISTS position_events (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    position INTEGER NOT NULL,
+    note TEXT,
+    mood VARCHAR(32),
+    emoji VARCHAR(32),
+    visibility VARCHAR(10) NOT NULL DEFAULT 'shared',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_position_events_relationship_id_created_at ON position_events(relationship_id, created_at);
+
+-- Crearea tabelei pentru pozițiile sigilate, care așteaptă dezvăluirea
+CREATE TABLE IF NOT EXISTS sealed_positions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    position INTEGER NOT NULL,
+    note TEXT,
+    mood VARCHAR(32),
+    emoji VARCHAR(32),
+    visibility VARCHAR(10) NOT NULL DEFAULT 'shared',
+    axes JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    -- Un utilizator are o singură poziție sigilată pentru o relație
+    UNIQUE(relationship_id, user_id)
+);
+
+-- Crearea tabelei pentru pauzele de intimitate
+CREATE TABLE IF NOT EXISTS position_pauses (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ends_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ
+);
+
+-- Un utilizator are cel mult o pauză deschisă într-o relație
+CREATE UNIQUE INDEX IF NOT EXISTS idx_position_pauses_open ON position_pauses(relationship_id, user_id) WHERE ended_at IS NULL;
+
+-- Crearea tabelei pentru dimensiunile apropierii configurate pe relație
+CREATE TABLE IF NOT EXISTS relationship_axes (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    key VARCHAR(32) NOT NULL,
+    label VARCHAR(64) NOT NULL,
+    weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    min_value INTEGER NOT NULL DEFAULT 0,
+    max_value INTEGER NOT NULL DEFAULT 100,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    UNIQUE(relationship_id, key)
+);
+
+-- Crearea tabelei pentru valorile curente ale fiecărui partener pe dimensiuni
+CREATE TABLE IF NOT EXISTS axis_positions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    axis_key VARCHAR(32) NOT NULL,
+    value INTEGER NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    
+    UNIQUE(relationship_id, user_id, axis_key)
+);
+
+-- Crearea tabelei pentru valorile pe dimensiuni din istoricul pozițiilor
+CREATE TABLE IF NOT EXISTS position_event_axes (
+    event_id INTEGER NOT NULL REFERENCES position_events(id) ON DELETE CASCADE,
+    axis_key VARCHAR(32) NOT NULL,
+    value INTEGER NOT NULL,
+    
+    PRIMARY KEY(event_id, axis_key)
+);
+
+-- Crearea tabelei pentru preferințele de memento zilnic (lipsa rândului = preferințele implicite)
+CREATE TABLE IF NOT EXISTS checkin_reminders (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    remind_at INTEGER,   -- minute de la miezul nopții, în fusul orar al utilizatorului (NULL = ora implicită)
+    quiet_start INTEGER,
+    quiet_end INTEGER,
+    snoozed_until TIMESTAMPTZ,
+    last_sent_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru abonamentele Web Push ale browserelor
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL UNIQUE,
+    p256dh VARCHAR(128) NOT NULL,
+    auth VARCHAR(64) NOT NULL,
+    user_agent VARCHAR(255),
+    expires_at TIMESTAMPTZ,
+    failure_count INTEGER NOT NULL DEFAULT 0,
+    last_success_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_push_subscriptions_user_id ON push_subscriptions(user_id);
+
+-- Crearea tabelei pentru preferințele de notificare pe tipuri de evenimente (lipsa rândului = activat)
+CREATE TABLE IF NOT EXISTS push_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    
+    PRIMARY KEY(user_id, event_type)
+);
+
+-- Crearea tabelei pentru webhook-urile înregistrate de utilizatori
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    relationship_id INTEGER,   -- NULL = toate relațiile utilizatorului
+    url TEXT NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    events TEXT[] NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    failure_count INTEGER NOT NULL DEFAULT 0,   -- livrări eșuate consecutive
+    disabled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks(user_id);
+
+-- Crearea tabelei pentru jurnalul livrărilor
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id SERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL,
+    relationship_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ,
+    last_status_code INTEGER,
+    last_error TEXT,
+    replay_of INTEGER REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries(webhook_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- Crearea tabelei pentru jurnalul comun al relației
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT,   -- NULL după ștergere
+    position_event_id INTEGER REFERENCES position_events(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    edited_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_journal_entries_relationship_id ON journal_entries(relationship_id, id);
+
+-- Crearea tabelei pentru versiunile anterioare ale intrărilor (la editare și ștergere)
+CREATE TABLE IF NOT EXISTS journal_entry_revisions (
+    id SERIAL PRIMARY KEY,
+    entry_id INTEGER NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    action VARCHAR(10) NOT NULL,   -- edited sau deleted
+    body TEXT NOT NULL,
+    position_event_id INTEGER,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_journal_entry_revisions_entry_id ON journal_entry_revisions(entry_id);
+
+-- Crearea tabelei pentru reacțiile la intrări (o reacție per membru)
+CREATE TABLE IF NOT EXISTS journal_reactions (
+    entry_id INTEGER NOT NULL REFERENCES journal_entries(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (entry_id, user_id)
+);
+
+-- Crearea tabelei pentru evenimentele din calendarul relației
+CREATE TABLE IF NOT EXISTS relationship_events (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(100) NOT NULL,
+    description TEXT,
+    location VARCHAR(255),
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,   -- exclusiv; pentru o zi întreagă, miezul nopții de după ultima zi
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    timezone VARCHAR(64) NOT NULL,   -- fusul orar în care se repetă evenimentul
+    recurrence TEXT,   -- regula RRULE normalizată (NULL = o singură dată)
+    reminders INTEGER[] NOT NULL DEFAULT '{}',   -- minute înainte de început
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_events_relationship_id ON relationship_events(relationship_id, starts_at);
+
+-- Crearea tabelei pentru mementourile de eveniment deja trimise
+CREATE TABLE IF NOT EXISTS relationship_event_reminders (
+    event_id INTEGER NOT NULL REFERENCES relationship_events(id) ON DELETE CASCADE,
+    occurrence_at TIMESTAMPTZ NOT NULL,
+    minutes_before INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, occurrence_at, minutes_before)
+);
+
+-- Crearea tabelei pentru link-urile private ale fluxului iCalendar (unul per membru și relație)
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, relationship_id)
+);
+
+-- Politica de estompare a pozițiilor neactualizate, pentru fiecare relație
+ALTER TABLE relationships
+    ADD COLUMN IF NOT EXISTS decay_mode VARCHAR(10) NOT NULL DEFAULT 'off', -- off, stale sau drift
+    ADD COLUMN IF NOT EXISTS decay_after_days INTEGER NOT NULL DEFAULT 14,
+    ADD COLUMN IF NOT EXISTS decay_rate INTEGER NOT NULL DEFAULT 5;       -- puncte pe zi spre mijlocul scalei
+
+-- Starea calculată de job-ul de estompare (NULL = poziția este actuală)
+ALTER TABLE curve_positions
+    ADD COLUMN IF NOT EXISTS decayed_position INTEGER,
+    ADD COLUMN IF NOT EXISTS stale_since TIMESTAMPTZ;
+
+-- Anularea unei actualizări de poziție în fereastra de anulare
+ALTER TABLE position_events
+    ADD COLUMN IF NOT EXISTS previous_position INTEGER,          -- NULL = actualizarea nu poate fi anulată
+    ADD COLUMN IF NOT EXISTS previous_updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reverted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS reverts_event_id INTEGER REFERENCES position_events(id) ON DELETE SET NULL;
+
+-- Crearea tabelei pentru gesturile trimise între membri („nudge”), cu confirmare de citire
+CREATE TABLE IF NOT EXISTS nudges (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    recipient_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(32) NOT NULL,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru lista gesturilor și pentru limita de trimitere
+CREATE INDEX IF NOT EXISTS idx_nudges_relationship_id_id ON nudges(relationship_id, id);
+CREATE INDEX IF NOT EXISTS idx_nudges_recipient_unread ON nudges(relationship_id, recipient_id) WHERE read_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_nudges_sender_id_created_at ON nudges(sender_id, created_at);
+
+-- Crearea tabelei pentru obiectivele comune ale relației
+CREATE TABLE IF NOT EXISTS relationship_goals (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT,
+    due_date DATE,
+    assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open', -- open, in_progress sau done
+    completed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru sarcinile (lista de verificare) fiecărui obiectiv
+CREATE TABLE IF NOT EXISTS goal_tasks (
+    id SERIAL PRIMARY KEY,
+    goal_id INTEGER NOT NULL REFERENCES relationship_goals(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    title VARCHAR(200) NOT NULL,
+    due_date DATE,
+    assignee_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'open',
+    completed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    completed_at TIMESTAMPTZ,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_relationship_goals_relationship_id ON relationship_goals(relationship_id);
+CREATE INDEX IF NOT EXISTS idx_goal_tasks_goal_id ON goal_tasks(goal_id, sort_order);
+
+-- Crearea tabelei pentru întrebarea zilei atribuită fiecărei relații
+CREATE TABLE IF NOT EXISTS daily_questions (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    question_date DATE NOT NULL,
+    question_id VARCHAR(32) NOT NULL, -- ID-ul întrebării din biblioteca inclusă în aplicație
+    revealed_at TIMESTAMPTZ, -- Momentul în care toți membrii au răspuns
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(relationship_id, question_date)
+);
+
+-- Crearea tabelei pentru răspunsurile membrilor la întrebarea zilei
+CREATE TABLE IF NOT EXISTS daily_answers (
+    id SERIAL PRIMARY KEY,
+    daily_question_id INTEGER NOT NULL REFERENCES daily_questions(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    answer TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(daily_question_id, user_id)
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_daily_questions_relationship_date ON daily_questions(relationship_id, question_date DESC);
+
+-- Crearea tabelei pentru accesul partajat, doar pentru citire, al unei terțe persoane (ex. un terapeut)
+CREATE TABLE IF NOT EXISTS share_grants (
+    id SERIAL PRIMARY KEY,
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    label VARCHAR(100) NOT NULL, -- Cui îi este destinat accesul (ex. numele terapeutului)
+    token_hash VARCHAR(64) NOT NULL UNIQUE, -- SHA-256 al token-ului; token-ul este afișat o singură dată
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    revoked_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru aprobările membrilor; accesul este activ doar după aprobarea tuturor membrilor
+CREATE TABLE IF NOT EXISTS share_grant_approvals (
+    grant_id INTEGER NOT NULL REFERENCES share_grants(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    approved_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (grant_id, user_id)
+);
+
+-- Crearea tabelei pentru jurnalul accesărilor, vizibil tuturor membrilor
+CREATE TABLE IF NOT EXISTS share_access_log (
+    id SERIAL PRIMARY KEY,
+    grant_id INTEGER NOT NULL REFERENCES share_grants(id) ON DELETE CASCADE,
+    resource VARCHAR(32) NOT NULL, -- overview, history, stats sau notes
+    ip_address VARCHAR(64),
+    user_agent VARCHAR(255),
+    accessed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indecși pentru performanță
+CREATE INDEX IF NOT EXISTS idx_share_grants_relationship_id ON share_grants(relationship_id);
+CREATE INDEX IF NOT EXISTS idx_share_access_log_grant_id ON share_access_log(grant_id, id DESC);
+
+-- Crearea tabelei pentru abonarea la rezumatul săptămânal (lipsa rândului = neabonat)
+CREATE TABLE IF NOT EXISTS digest_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Crearea tabelei pentru rezumatele trimise; cheia primară garantează cel mult o trimitere pe săptămână
+CREATE TABLE IF NOT EXISTS digest_deliveries (
+    relationship_id INTEGER NOT NULL REFERENCES relationships(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    week_start DATE NOT NULL,
+    status VARCHAR(16) NOT NULL, -- sending, sent, skipped (nimic de raportat) sau failed
+    attempts INTEGER NOT NULL DEFAULT 1,
+    last_error TEXT,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (relationship_id, user_id, week_start)
+);
+
+-- Pozițiile importate din fișiere (istoric de dinaintea aplicației); NULL = actualizare făcută în aplicație
+ALTER TABLE position_events
+    ADD COLUMN IF NOT EXISTS imported_at TIMESTAMPTZ;
+
+-- Token-ul fluxului iCalendar se salvează doar ca amprentă SHA-256; link-ul este afișat o singură dată
+ALTER TABLE calendar_feeds ADD COLUMN IF NOT EXISTS token_hash VARCHAR(64);
+
+UPDATE calendar_feeds
+SET token_hash = encode(sha256(convert_to(token, 'UTF8')), 'hex')
+WHERE token_hash IS NULL;
+
+ALTER TABLE calendar_feeds
+    ALTER COLUMN token_hash SET NOT NULL,
+    DROP COLUMN IF EXISTS token;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_calendar_feeds_token_hash ON calendar_feeds(token_hash);
@@ -0,0 +1,100 @@
+// Package decay calculează cum se estompează o poziție care nu a mai fost actualizată de mult timp.
+package decay
+
+import (
+	"errors"
+	"time"
+)
+
+// Modurile de estompare ale unei relații
+const (
+	ModeOff   = "off"   // Pozițiile rămân neschimbate
+	ModeStale = "stale" // Pozițiile vechi sunt doar marcate ca neactualizate
+	ModeDrift = "drift" // Pozițiile vechi se apropie zilnic de valoarea neutră
+)
+
+// Neutral este valoarea spre care se apropie pozițiile în modul drift (mijlocul scalei 0-100)
+const Neutral = 50
+
+// Limitele acceptate pentru politica de estompare
+const (
+	MinAfterDays = 1
+	MaxAfterDays = 365
+	MinRate      = 1
+	MaxRate      = 50
+)
+
+// ErrInvalidPolicy este returnată pentru o politică de estompare invalidă
+var ErrInvalidPolicy = errors.New("politica de estompare este invalidă")
+
+// Policy este politica de estompare a pozițiilor dintr-o relație
+type Policy struct {
+	Mode      string
+	AfterDays int // Zilele fără actualizare după care poziția devine veche
+	Rate      int // Puncte pe zi cu care poziția se apropie de Neutral (doar pentru ModeDrift)
+}
+
+// Validate verifică modul și limitele politicii
+func (p Policy) Validate() error {
+	switch p.Mode {
+	case ModeOff, ModeStale, ModeDrift:
+	default:
+		return ErrInvalidPolicy
+	}
+	if p.AfterDays < MinAfterDays || p.AfterDays > MaxAfterDays {
+		return ErrInvalidPolicy
+	}
+	if p.Rate < MinRate || p.Rate > MaxRate {
+		return ErrInvalidPolicy
+	}
+	return nil
+}
+
+// State este starea unei poziții după aplicarea politicii
+type State struct {
+	Position   int        // Poziția efectivă, eventual estompată
+	Stale      bool       // Poziția nu a mai fost actualizată de cel puțin AfterDays zile
+	Decayed    bool       // Poziția efectivă diferă de cea setată de membru
+	StaleSince *time.Time // Momentul în care poziția a devenit veche
+}
+
+// Apply calculează starea poziției setate la updatedAt, la momentul now. În modul drift poziția se
+// apropie de Neutral cu Rate puncte pentru fiecare zi întreagă trecută de când a devenit veche,
+// începând chiar din momentul respectiv, fără a trece de Neutral.
+func (p Policy) Apply(position int, updatedAt, now time.Time) State {
+	state := State{Position: position}
+	if p.Mode != ModeStale && p.Mode != ModeDrift {
+		return state
+	}
+
+	staleSince := updatedAt.Add(time.Duration(p.AfterDays) * 24 * time.Hour)
+	if now.Before(staleSince) {
+		return state
+	}
+	state.Stale = true
+	state.StaleSince = &staleSince
+
+	if p.Mode == ModeDrift {
+		days := int(now.Sub(staleSince)/(24*time.Hour)) + 1
+		state.Position = drift(position, days*p.Rate)
+		state.Decayed = state.Position != position
+	}
+	return state
+}
+
+// drift apropie poziția de Neutral cu cel mult amount puncte
+func drift(position, amount int) int {
+	switch {
+	case position > Neutral:
+		if position-amount < Neutral {
+			return Neutral
+		}
+		return position - amount
+	case position < Neutral:
+		if position+amount > Neutral {
+			return Neutral
+		}
+		return position + amount
+	}
+	return position
+}
@@ -0,0 +1,73 @@
+package decay
+
+import (
+	"testing"
+	"time"
+)
+
+var updated = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestValidate(t *testing.T) {
+	if err := (Policy{Mode: ModeDrift, AfterDays: 14, Rate: 5}).Validate(); err != nil {
+		t.Fatalf("politica validă a fost respinsă: %v", err)
+	}
+	for _, p := range []Policy{
+		{Mode: "fade", AfterDays: 14, Rate: 5},
+		{Mode: ModeStale, AfterDays: 0, Rate: 5},
+		{Mode: ModeStale, AfterDays: 366, Rate: 5},
+		{Mode: ModeDrift, AfterDays: 14, Rate: 0},
+	} {
+		if err := p.Validate(); err != ErrInvalidPolicy {
+			t.Errorf("Validate(%+v) = %v, vrem ErrInvalidPolicy", p, err)
+		}
+	}
+}
+
+func TestApplyOff(t *testing.T) {
+	p := Policy{Mode: ModeOff, AfterDays: 1, Rate: 10}
+	state := p.Apply(90, updated, updated.AddDate(1, 0, 0))
+	if state.Stale || state.Decayed || state.Position != 90 || state.StaleSince != nil {
+		t.Fatalf("Apply cu modul off = %+v", state)
+	}
+}
+
+func TestApplyStale(t *testing.T) {
+	p := Policy{Mode: ModeStale, AfterDays: 7, Rate: 10}
+
+	if state := p.Apply(90, updated, updated.AddDate(0, 0, 7).Add(-time.Second)); state.Stale {
+		t.Fatalf("poziția a devenit veche prea devreme: %+v", state)
+	}
+
+	state := p.Apply(90, updated, updated.AddDate(0, 0, 30))
+	if !state.Stale || state.Decayed || state.Position != 90 {
+		t.Fatalf("Apply cu modul stale = %+v", state)
+	}
+	if want := updated.AddDate(0, 0, 7); !state.StaleSince.Equal(want) {
+		t.Errorf("StaleSince = %v, vrem %v", state.StaleSince, want)
+	}
+}
+
+func TestApplyDrift(t *testing.T) {
+	p := Policy{Mode: ModeDrift, AfterDays: 7, Rate: 10}
+	tests := []struct {
+		position int
+		days     int
+		want     int
+	}{
+		{90, 7, 80},  // Prima zi de estompare
+		{90, 8, 70},  // A doua zi
+		{10, 9, 40},  // Sub mijloc, poziția crește
+		{90, 60, 50}, // Nu trece de valoarea neutră
+		{45, 7, 50},
+		{50, 20, 50},
+	}
+	for _, tt := range tests {
+		state := p.Apply(tt.position, updated, updated.AddDate(0, 0, tt.days))
+		if state.Position != tt.want || !state.Stale {
+			t.Errorf("Apply(%d, %d zile) = %+v, vrem poziția %d", tt.position, tt.days, state, tt.want)
+		}
+		if state.Decayed != (tt.want != tt.position) {
+			t.Errorf("Apply(%d, %d zile).Decayed = %v", tt.position, tt.days, state.Decayed)
+		}
+	}
+}
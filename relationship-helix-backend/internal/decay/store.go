@@ -0,0 +1,153 @@
+package decay
+
+import (
+	"database/sql"
+	"time"
+
+	"relationship-helix/internal/pause"
+)
+
+// NotifyFunc trimite un eveniment în timp real unui utilizator dintr-o relație
+type NotifyFunc func(relationshipID, userID uint, eventType string, payload interface{})
+
+// stored este o poziție curentă, împreună cu starea de estompare salvată
+type stored struct {
+	RelationshipID uint
+	UserID         uint
+	Position       int
+	UpdatedAt      time.Time
+	Decayed        sql.NullInt64
+	StaleSince     sql.NullTime
+	Policy         Policy
+}
+
+// Refresh recalculează starea de estompare a pozițiilor și anunță toți membrii relației prin evenimentul
+// position_decayed pentru fiecare poziție a cărei stare s-a schimbat. Cu relationshipID 0 sunt verificate
+// toate relațiile cu estompare activă sau cu poziții încă marcate. Pozițiile membrilor aflați în pauză
+// rămân neschimbate până la încheierea pauzei.
+func Refresh(db *sql.DB, relationshipID uint, now time.Time, notify NotifyFunc) error {
+	positions, err := load(db, relationshipID)
+	if err != nil {
+		return err
+	}
+
+	members := make(map[uint][]uint)
+	for _, p := range positions {
+		memberPause, err := pause.Active(db, p.RelationshipID, p.UserID)
+		if err != nil {
+			return err
+		}
+		if memberPause != nil {
+			continue
+		}
+
+		state := p.Policy.Apply(p.Position, p.UpdatedAt, now)
+		var decayed, staleSince interface{}
+		if state.Decayed {
+			decayed = state.Position
+		}
+		if state.StaleSince != nil {
+			staleSince = *state.StaleSince
+		}
+
+		unchanged := state.Stale == p.StaleSince.Valid &&
+			state.Decayed == p.Decayed.Valid &&
+			(!state.Decayed || int(p.Decayed.Int64) == state.Position)
+		if unchanged {
+			continue
+		}
+
+		// Condiția pe updated_at evită suprascrierea unei poziții actualizate între timp
+		result, err := db.Exec(
+			`UPDATE curve_positions
+             SET decayed_position = $3, stale_since = $4
+             WHERE relationship_id = $1 AND user_id = $2 AND updated_at = $5`,
+			p.RelationshipID, p.UserID, decayed, staleSince, p.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			continue
+		}
+
+		recipients, ok := members[p.RelationshipID]
+		if !ok {
+			recipients, err = memberIDs(db, p.RelationshipID)
+			if err != nil {
+				return err
+			}
+			members[p.RelationshipID] = recipients
+		}
+
+		payload := map[string]interface{}{
+			"relationshipId":   p.RelationshipID,
+			"userId":           p.UserID,
+			"position":         state.Position,
+			"originalPosition": p.Position,
+			"stale":            state.Stale,
+			"decayed":          state.Decayed,
+			"staleSince":       state.StaleSince,
+		}
+		for _, userID := range recipients {
+			notify(p.RelationshipID, userID, "position_decayed", payload)
+		}
+	}
+
+	return nil
+}
+
+// load încarcă pozițiile care trebuie verificate
+func load(db *sql.DB, relationshipID uint) ([]stored, error) {
+	rows, err := db.Query(
+		`SELECT cp.relationship_id, cp.user_id, cp.position, cp.updated_at, cp.decayed_position, cp.stale_since,
+                r.decay_mode, r.decay_after_days, r.decay_rate
+         FROM curve_positions cp
+         JOIN relationships r ON r.id = cp.relationship_id
+         WHERE (r.decay_mode <> $1 OR cp.stale_since IS NOT NULL)
+           AND ($2 = 0 OR cp.relationship_id = $2)
+         ORDER BY cp.relationship_id, cp.user_id`,
+		ModeOff, relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var positions []stored
+	for rows.Next() {
+		var p stored
+		err := rows.Scan(
+			&p.RelationshipID, &p.UserID, &p.Position, &p.UpdatedAt, &p.Decayed, &p.StaleSince,
+			&p.Policy.Mode, &p.Policy.AfterDays, &p.Policy.Rate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, p)
+	}
+
+	return positions, rows.Err()
+}
+
+// memberIDs încarcă ID-urile membrilor relației
+func memberIDs(db *sql.DB, relationshipID uint) ([]uint, error) {
+	rows, err := db.Query(
+		`SELECT user_id FROM relationship_members WHERE relationship_id = $1 ORDER BY joined_at, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
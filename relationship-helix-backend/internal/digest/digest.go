@@ -0,0 +1,204 @@
+// Package digest construiește rezumatul săptămânal al unei relații: apropierea medie a fiecărui membru,
+// cele mai mari schimbări de poziție, aniversările atinse și numărul de intrări în jurnal.
+package digest
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"relationship-helix/internal/milestones"
+	"relationship-helix/internal/reminders"
+	"relationship-helix/internal/stats"
+)
+
+// DefaultSendTime este ora implicită la care se trimite rezumatul, lunea (09:00), în minute de la miezul nopții
+const DefaultSendTime = 9 * 60
+
+// ParseSendTime transformă ora de trimitere din configurație (HH:MM) în minute, revenind la DefaultSendTime
+func ParseSendTime(value string) int {
+	minutes, err := reminders.ParseClock(value)
+	if err != nil {
+		return DefaultSendTime
+	}
+	return minutes
+}
+
+// Week este o săptămână calendaristică [Start, End), de luni până duminică, într-un fus orar
+type Week struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// WeekOf returnează săptămâna care conține momentul t, în fusul orar loc
+func WeekOf(t time.Time, loc *time.Location) Week {
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	offset := (int(t.Weekday()) + 6) % 7 // Zile de la luni
+	start := time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+	return Week{Start: start, End: time.Date(start.Year(), start.Month(), start.Day()+7, 0, 0, 0, 0, loc)}
+}
+
+// LastCompleted returnează ultima săptămână încheiată înainte de momentul now
+func LastCompleted(now time.Time, loc *time.Location) Week {
+	current := WeekOf(now, loc)
+	return WeekOf(current.Start.Add(-time.Nanosecond), loc)
+}
+
+// Member este un membru al relației inclus în rezumat
+type Member struct {
+	UserID   uint
+	Username string
+	Paused   bool // Membrul are o pauză activă; pozițiile lui nu sunt incluse
+}
+
+// Input conține datele din care se construiește rezumatul
+type Input struct {
+	Week             Week
+	RecipientID      uint
+	RelationshipName string
+	Members          []Member
+	// Evenimentele din săptămână, plus ultima poziție a fiecărui membru dinaintea ei
+	Events    []stats.Event
+	StartDate time.Time
+	Custom    []milestones.CustomDate
+	Journal   map[uint]int // Numărul de intrări în jurnal ale fiecărui membru
+	AppURL    string
+}
+
+// Swing este cea mai mare schimbare de poziție a unui membru într-o singură actualizare
+type Swing struct {
+	From   int       `json:"from"`
+	To     int       `json:"to"`
+	Change int       `json:"change"`
+	At     time.Time `json:"at"`
+}
+
+// MemberSummary conține activitatea unui membru în săptămâna rezumată
+type MemberSummary struct {
+	UserID         uint     `json:"userId"`
+	Username       string   `json:"username"`
+	IsRecipient    bool     `json:"isRecipient"`
+	Paused         bool     `json:"paused"`
+	Average        *float64 `json:"average"`
+	Updates        int      `json:"updates"`
+	BiggestSwing   *Swing   `json:"biggestSwing"`
+	JournalEntries int      `json:"journalEntries"`
+}
+
+// Report este rezumatul săptămânal, așa cum îl vede destinatarul
+type Report struct {
+	Week             Week                   `json:"week"`
+	RelationshipName string                 `json:"relationshipName"`
+	Recipient        string                 `json:"recipient"`
+	Members          []MemberSummary        `json:"members"`
+	Milestones       []milestones.Milestone `json:"milestones"`
+	JournalEntries   int                    `json:"journalEntries"`
+	URL              string                 `json:"url"`
+}
+
+// Empty verifică dacă în săptămâna rezumată nu s-a întâmplat nimic (nicio actualizare, aniversare sau intrare în jurnal)
+func (r Report) Empty() bool {
+	if len(r.Milestones) > 0 || r.JournalEntries > 0 {
+		return false
+	}
+	for _, m := range r.Members {
+		if m.Updates > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Build construiește rezumatul; destinatarul apare primul, ceilalți membri în ordinea dată
+func Build(in Input) Report {
+	report := Report{
+		Week:             in.Week,
+		RelationshipName: in.RelationshipName,
+		Milestones:       []milestones.Milestone{},
+		URL:              in.AppURL,
+	}
+
+	events := append([]stats.Event(nil), in.Events...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+
+	for _, m := range in.Members {
+		summary := MemberSummary{
+			UserID:         m.UserID,
+			Username:       m.Username,
+			IsRecipient:    m.UserID == in.RecipientID,
+			Paused:         m.Paused && m.UserID != in.RecipientID,
+			JournalEntries: in.Journal[m.UserID],
+		}
+		if !summary.Paused {
+			memberEvents := filterUser(events, m.UserID)
+			summary.Average = round(stats.TimeWeightedAverage(stats.Segments(memberEvents, in.Week.Start, in.Week.End)))
+			summary.Updates, summary.BiggestSwing = swings(memberEvents, in.Week)
+		}
+
+		report.JournalEntries += summary.JournalEntries
+		if summary.IsRecipient {
+			report.Recipient = m.Username
+			report.Members = append([]MemberSummary{summary}, report.Members...)
+			continue
+		}
+		report.Members = append(report.Members, summary)
+	}
+
+	// Aniversările se calculează în zile calendaristice, ca în restul aplicației
+	loc := in.Week.Start.Location()
+	from := milestones.Date(in.Week.Start, loc)
+	to := milestones.Date(in.Week.End.Add(-time.Nanosecond), loc)
+	report.Milestones = milestones.Between(milestones.Date(in.StartDate, loc), in.Custom, from, to, to)
+
+	return report
+}
+
+// swings numără actualizările din săptămână și găsește cea mai mare schimbare față de poziția anterioară
+func swings(events []stats.Event, week Week) (int, *Swing) {
+	var updates int
+	var biggest *Swing
+	for i, e := range events {
+		if e.At.Before(week.Start) || !e.At.Before(week.End) {
+			continue
+		}
+		updates++
+		if i == 0 {
+			continue
+		}
+		change := e.Position - events[i-1].Position
+		if change != 0 && (biggest == nil || abs(change) > abs(biggest.Change)) {
+			biggest = &Swing{From: events[i-1].Position, To: e.Position, Change: change, At: e.At.In(week.Start.Location())}
+		}
+	}
+	return updates, biggest
+}
+
+func filterUser(events []stats.Event, userID uint) []stats.Event {
+	var result []stats.Event
+	for _, e := range events {
+		if e.UserID == userID {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func round(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := math.Round(*v*10) / 10
+	return &r
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,136 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"relationship-helix/internal/stats"
+)
+
+func TestWeekOf(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Bucharest")
+	if err != nil {
+		t.Skip("fusul orar nu este disponibil")
+	}
+
+	// Duminică seara în București este deja luni în UTC
+	w := WeekOf(time.Date(2026, 10, 18, 22, 30, 0, 0, time.UTC), loc)
+	if !w.Start.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, loc)) || !w.End.Equal(time.Date(2026, 10, 26, 0, 0, 0, 0, loc)) {
+		t.Errorf("WeekOf = %v – %v", w.Start, w.End)
+	}
+
+	// Săptămâna schimbării orei are 7 zile calendaristice, dar 169 de ore
+	last := LastCompleted(time.Date(2026, 10, 27, 10, 0, 0, 0, loc), loc)
+	if !last.Start.Equal(time.Date(2026, 10, 19, 0, 0, 0, 0, loc)) || last.End.Sub(last.Start) != 169*time.Hour {
+		t.Errorf("LastCompleted = %v – %v", last.Start, last.End)
+	}
+}
+
+func testInput() Input {
+	week := WeekOf(time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), time.UTC)
+	at := func(day, hour int) time.Time { return week.Start.Add(time.Duration(day*24+hour) * time.Hour) }
+
+	return Input{
+		Week:        week,
+		RecipientID: 2,
+		Members: []Member{
+			{UserID: 1, Username: "ana"},
+			{UserID: 2, Username: "mihai"},
+			{UserID: 3, Username: "ioana", Paused: true},
+		},
+		Events: []stats.Event{
+			{UserID: 1, Position: 20, At: at(-3, 0)}, // Înainte de săptămână
+			{UserID: 1, Position: 60, At: at(2, 0)},
+			{UserID: 1, Position: 50, At: at(4, 0)},
+			{UserID: 2, Position: 30, At: at(1, 0)},
+			{UserID: 3, Position: 90, At: at(1, 0)},
+		},
+		StartDate: time.Date(2026, 7, 12, 0, 0, 0, 0, time.UTC), // 3 luni pe 12 octombrie
+		Journal:   map[uint]int{1: 2, 2: 1},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(testInput())
+
+	if r.Recipient != "mihai" || len(r.Members) != 3 || r.Members[0].UserID != 2 || !r.Members[0].IsRecipient {
+		t.Fatalf("destinatarul trebuie să apară primul: %+v", r.Members)
+	}
+
+	ana := r.Members[1]
+	// 2 zile la 20, 2 zile la 60, 3 zile la 50 => (40 + 120 + 150) / 7
+	if ana.Average == nil || *ana.Average != 44.3 {
+		t.Errorf("media Anei = %v, vrem 44.3", ana.Average)
+	}
+	if ana.Updates != 2 || ana.BiggestSwing == nil || ana.BiggestSwing.From != 20 || ana.BiggestSwing.Change != 40 {
+		t.Errorf("actualizările Anei: %d, %+v", ana.Updates, ana.BiggestSwing)
+	}
+
+	mihai := r.Members[0]
+	if mihai.Updates != 1 || mihai.BiggestSwing != nil || mihai.Average == nil || *mihai.Average != 30 {
+		t.Errorf("Mihai: %+v", mihai)
+	}
+
+	ioana := r.Members[2]
+	if !ioana.Paused || ioana.Average != nil || ioana.Updates != 0 {
+		t.Errorf("pozițiile membrului în pauză nu trebuie incluse: %+v", ioana)
+	}
+
+	if r.JournalEntries != 3 {
+		t.Errorf("intrări în jurnal = %d, vrem 3", r.JournalEntries)
+	}
+	if len(r.Milestones) != 1 || r.Milestones[0].Key != "monthly:3" {
+		t.Errorf("aniversări = %+v", r.Milestones)
+	}
+	if r.Empty() {
+		t.Errorf("rezumatul nu este gol")
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	in := testInput()
+	in.Events = in.Events[:1]
+	in.Journal = nil
+	in.StartDate = in.Week.Start.AddDate(0, 0, -10)
+	if r := Build(in); !r.Empty() {
+		t.Errorf("o săptămână fără activitate trebuie să fie goală: %+v", r)
+	}
+}
+
+func TestRender(t *testing.T) {
+	in := testInput()
+	in.Members[0].Username = "<ana>"
+	in.AppURL = "https://helix.example.com"
+
+	out, err := Render(Build(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if out.Subject != "Rezumatul săptămânii 12 – 18 octombrie 2026" {
+		t.Errorf("subiect = %q", out.Subject)
+	}
+	for _, want := range []string{"Bună, mihai!", "medie 44,3", "20 → 60 (+40)", "3 luni împreună (12 octombrie)", "Jurnal: 3 intrări", "ioana: în pauză"} {
+		if !strings.Contains(out.Text, want) {
+			t.Errorf("textul nu conține %q:\n%s", want, out.Text)
+		}
+	}
+	if !strings.Contains(out.HTML, "&lt;ana&gt;") || strings.Contains(out.HTML, "<ana>") {
+		t.Errorf("numele nu este escapat în HTML")
+	}
+	if !strings.Contains(out.HTML, `href="https://helix.example.com"`) {
+		t.Errorf("HTML-ul nu conține legătura către aplicație")
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	w := WeekOf(time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC), time.UTC)
+	if got := period(w); got != "28 decembrie 2026 – 3 ianuarie 2027" {
+		t.Errorf("period = %q", got)
+	}
+	w = WeekOf(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+	if got := period(w); got != "28 septembrie – 4 octombrie 2026" {
+		t.Errorf("period = %q", got)
+	}
+}
@@ -0,0 +1,156 @@
+package digest
+
+import (
+	"database/sql"
+	"time"
+
+	"relationship-helix/internal/db"
+	"relationship-helix/internal/milestones"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/stats"
+)
+
+// Load încarcă datele rezumatului pentru săptămâna dată, așa cum le vede destinatarul: actualizările făcute
+// de ceilalți membri în timpul unei pauze active și actualizările anulate nu sunt incluse.
+func Load(q db.Queryer, relationshipID, recipientID uint, week Week) (Input, error) {
+	in := Input{Week: week, RecipientID: recipientID, Journal: make(map[uint]int)}
+
+	var name sql.NullString
+	err := q.QueryRow(
+		`SELECT name, start_date FROM relationships WHERE id = $1`,
+		relationshipID,
+	).Scan(&name, &in.StartDate)
+	if err != nil {
+		return Input{}, err
+	}
+	in.RelationshipName = name.String
+
+	members, err := loadMembers(q, relationshipID)
+	if err != nil {
+		return Input{}, err
+	}
+	in.Members = members
+
+	if in.Events, err = loadEvents(q, relationshipID, recipientID, week); err != nil {
+		return Input{}, err
+	}
+	if in.Custom, err = loadCustomDates(q, relationshipID); err != nil {
+		return Input{}, err
+	}
+
+	rows, err := q.Query(
+		`SELECT user_id, COUNT(*)
+         FROM journal_entries
+         WHERE relationship_id = $1 AND deleted_at IS NULL
+           AND created_at >= $2 AND created_at < $3
+         GROUP BY user_id`,
+		relationshipID, week.Start, week.End,
+	)
+	if err != nil {
+		return Input{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var userID uint
+		var count int
+		if err := rows.Scan(&userID, &count); err != nil {
+			return Input{}, err
+		}
+		in.Journal[userID] = count
+	}
+
+	return in, rows.Err()
+}
+
+// loadMembers încarcă membrii relației, în ordinea intrării, împreună cu starea pauzei
+func loadMembers(q db.Queryer, relationshipID uint) ([]Member, error) {
+	rows, err := q.Query(
+		`SELECT m.user_id, u.username, EXISTS (
+             SELECT 1 FROM position_pauses pp
+             WHERE pp.relationship_id = m.relationship_id AND pp.user_id = m.user_id AND pp.ended_at IS NULL
+               AND (pp.ends_at IS NULL OR pp.ends_at > NOW())
+         )
+         FROM relationship_members m
+         JOIN users u ON u.id = m.user_id
+         WHERE m.relationship_id = $1
+         ORDER BY m.joined_at, m.id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []Member
+	for rows.Next() {
+		var m Member
+		if err := rows.Scan(&m.UserID, &m.Username, &m.Paused); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+// loadEvents încarcă actualizările din săptămână, plus ultima poziție vizibilă a fiecărui membru dinaintea ei
+func loadEvents(q db.Queryer, relationshipID, viewerID uint, week Week) ([]stats.Event, error) {
+	rows, err := q.Query(
+		`WITH visible AS (
+             SELECT e.id, e.user_id, e.position, e.created_at
+             FROM position_events e
+             WHERE e.relationship_id = $1
+               AND e.reverted_at IS NULL AND e.reverts_event_id IS NULL
+               AND `+pause.VisibleCondition("e", "$4")+`
+         )
+         SELECT user_id, position, created_at
+         FROM visible
+         WHERE created_at >= $2 AND created_at < $3
+         UNION ALL
+         (SELECT DISTINCT ON (user_id) user_id, position, created_at
+          FROM visible
+          WHERE created_at < $2
+          ORDER BY user_id, created_at DESC, id DESC)`,
+		relationshipID, week.Start, week.End, viewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []stats.Event
+	for rows.Next() {
+		var e stats.Event
+		if err := rows.Scan(&e.UserID, &e.Position, &e.At); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+
+	return events, rows.Err()
+}
+
+// loadCustomDates încarcă datele speciale definite de membrii relației
+func loadCustomDates(q db.Queryer, relationshipID uint) ([]milestones.CustomDate, error) {
+	rows, err := q.Query(
+		`SELECT id, title, date, recurring FROM relationship_dates WHERE relationship_id = $1 ORDER BY date`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dates []milestones.CustomDate
+	for rows.Next() {
+		var d milestones.CustomDate
+		if err := rows.Scan(&d.ID, &d.Title, &d.Date, &d.Recurring); err != nil {
+			return nil, err
+		}
+		d.Date = milestones.Date(d.Date, time.UTC)
+		dates = append(dates, d)
+	}
+
+	return dates, rows.Err()
+}
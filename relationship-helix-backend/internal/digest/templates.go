@@ -0,0 +1,107 @@
+package digest
+
+import (
+	"bytes"
+	"embed"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Numele lunilor în limba română
+var months = [...]string{
+	"ianuarie", "februarie", "martie", "aprilie", "mai", "iunie",
+	"iulie", "august", "septembrie", "octombrie", "noiembrie", "decembrie",
+}
+
+// funcs sunt funcțiile de formatare disponibile în ambele șabloane
+var funcs = map[string]interface{}{
+	"day":     day,
+	"period":  period,
+	"number":  number,
+	"signed":  signed,
+	"updates": func(n int) string { return countLabel(n, "actualizare", "actualizări") },
+	"entries": func(n int) string { return countLabel(n, "intrare", "intrări") },
+}
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("digest.txt.tmpl").Funcs(funcs).ParseFS(templateFiles, "templates/digest.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("digest.html.tmpl").Funcs(funcs).ParseFS(templateFiles, "templates/digest.html.tmpl"))
+)
+
+// Rendered este rezumatul gata de trimis prin email
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Subject returnează subiectul email-ului pentru rezumat
+func (r Report) Subject() string {
+	return "Rezumatul săptămânii " + period(r.Week)
+}
+
+// Render generează variantele text și HTML ale rezumatului
+func Render(r Report) (Rendered, error) {
+	data := struct {
+		Report
+		Subject string
+	}{r, r.Subject()}
+
+	var text, html bytes.Buffer
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return Rendered{}, err
+	}
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return Rendered{}, err
+	}
+
+	return Rendered{Subject: data.Subject, Text: text.String(), HTML: html.String()}, nil
+}
+
+// day formatează ziua calendaristică, ex. „12 octombrie”
+func day(t time.Time) string {
+	return strconv.Itoa(t.Day()) + " " + months[t.Month()-1]
+}
+
+// period formatează intervalul săptămânii, ex. „6 – 12 octombrie 2026”
+func period(w Week) string {
+	start, end := w.Start, w.End.Add(-time.Nanosecond).In(w.Start.Location())
+	switch {
+	case start.Year() != end.Year():
+		return day(start) + " " + strconv.Itoa(start.Year()) + " – " + day(end) + " " + strconv.Itoa(end.Year())
+	case start.Month() != end.Month():
+		return day(start) + " – " + day(end) + " " + strconv.Itoa(end.Year())
+	default:
+		return strconv.Itoa(start.Day()) + " – " + day(end) + " " + strconv.Itoa(end.Year())
+	}
+}
+
+// number formatează o medie cu o zecimală, cu virgulă ca separator
+func number(v *float64) string {
+	return strings.Replace(strconv.FormatFloat(*v, 'f', 1, 64), ".", ",", 1)
+}
+
+// signed formatează o schimbare de poziție cu semn
+func signed(v int) string {
+	if v > 0 {
+		return "+" + strconv.Itoa(v)
+	}
+	return strconv.Itoa(v)
+}
+
+// countLabel formatează un număr împreună cu substantivul la singular sau plural
+func countLabel(n int, singular, plural string) string {
+	if n == 1 {
+		return "o " + singular
+	}
+	if n%100 >= 20 || (n >= 20 && n%100 == 0) {
+		return strconv.Itoa(n) + " de " + plural
+	}
+	return strconv.Itoa(n) + " " + plural
+}
@@ -0,0 +1,205 @@
+// Package helix desenează dubla elice a relației pe server, ca SVG sau PNG, cu aceeași geometrie ca animația
+// din aplicația web (HelixAnimation.jsx), pentru email-uri, carduri de partajare și webhook-uri.
+package helix
+
+import (
+	"errors"
+	"image/color"
+	"math"
+	"time"
+)
+
+// Limitele dimensiunii imaginii, în pixeli
+const (
+	MinSize = 64
+	MaxSize = 2000
+)
+
+// Geometria animației din aplicația web, în unități de 1/200 din lățimea imaginii
+const (
+	radius      = 30.0 // Raza elicei
+	maxDistance = 40.0 // Îndepărtarea maximă, la poziția 100
+	lineWidth   = 2.0  // Grosimea curbei
+	glowWidth   = 5.0  // Raza strălucirii neon
+	cycles      = 6    // Numărul de cicluri (curba parcurge cycles*10 radiani)
+	steps       = cycles * 100
+)
+
+// ErrInvalidSize indică o dimensiune în afara intervalului [MinSize, MaxSize]
+var ErrInvalidSize = errors.New("dimensiunea imaginii este în afara intervalului permis")
+
+// Point este poziția unui membru începând cu momentul At
+type Point struct {
+	At       time.Time
+	Position int
+}
+
+// Input conține pozițiile desenate. Fără fereastră de istoric, fiecare curbă are poziția curentă pe toată
+// înălțimea; cu fereastră, înălțimea corespunde intervalului From-To (sus cel mai vechi moment, jos cel mai recent).
+type Input struct {
+	UserPosition    int
+	PartnerPosition int
+
+	// PartnerHidden estompează curba partenerului (ex. în timpul unei pauze), desenată la poziția neutră
+	PartnerHidden bool
+
+	From, To       time.Time
+	UserHistory    []Point // Sortate cronologic
+	PartnerHistory []Point
+}
+
+// Options descrie imaginea generată
+type Options struct {
+	Width, Height int
+	Theme         Theme
+
+	// Phase rotește elicea (în radiani), ca un cadru anume din animație
+	Phase float64
+}
+
+// Theme conține culorile și efectele imaginii
+type Theme struct {
+	Name       string
+	Background color.NRGBA // Transparentă dacă A este 0
+	User       color.NRGBA
+	Partner    color.NRGBA
+	Glow       bool // Strălucire neon în jurul curbelor
+	Scanlines  bool // Linii orizontale ca pe un ecran CRT
+}
+
+// DefaultTheme este tema folosită dacă nu se cere alta
+const DefaultTheme = "neon"
+
+// themes conține temele disponibile, după nume
+var themes = map[string]Theme{
+	"neon": {
+		Name:       "neon",
+		Background: color.NRGBA{0x00, 0x00, 0x33, 0xff},
+		User:       color.NRGBA{0xff, 0x00, 0xff, 0xff},
+		Partner:    color.NRGBA{0x00, 0xff, 0xff, 0xff},
+		Glow:       true,
+		Scanlines:  true,
+	},
+	"transparent": {
+		Name:    "transparent",
+		User:    color.NRGBA{0xff, 0x00, 0xff, 0xff},
+		Partner: color.NRGBA{0x00, 0xff, 0xff, 0xff},
+		Glow:    true,
+	},
+	"light": {
+		Name:       "light",
+		Background: color.NRGBA{0xff, 0xff, 0xff, 0xff},
+		User:       color.NRGBA{0xb0, 0x00, 0xb0, 0xff},
+		Partner:    color.NRGBA{0x00, 0x80, 0x8b, 0xff},
+	},
+}
+
+// ThemeNames conține numele temelor disponibile, în ordinea afișării
+var ThemeNames = []string{"neon", "transparent", "light"}
+
+// LookupTheme returnează tema cu numele dat
+func LookupTheme(name string) (Theme, bool) {
+	theme, ok := themes[name]
+	return theme, ok
+}
+
+// strand este o curbă a elicei, ca linie poligonală în pixeli
+type strand struct {
+	points []point
+	color  color.NRGBA
+	alpha  float64
+}
+
+type point struct {
+	x, y float64
+}
+
+// neutralPosition este poziția la care se desenează curba unui partener ascuns
+const neutralPosition = 50
+
+// hiddenAlpha este opacitatea curbei unui partener ascuns
+const hiddenAlpha = 0.3
+
+// validate verifică opțiunile și completează tema implicită
+func (o *Options) validate() error {
+	if o.Width < MinSize || o.Width > MaxSize || o.Height < MinSize || o.Height > MaxSize {
+		return ErrInvalidSize
+	}
+	if o.Theme.Name == "" {
+		o.Theme = themes[DefaultTheme]
+	}
+	return nil
+}
+
+// unit este unitatea geometriei, astfel încât elicea ocupă aceeași fracțiune din lățime la orice dimensiune
+func (o *Options) unit() float64 {
+	return float64(o.Width) / 200
+}
+
+// strands calculează cele două curbe. Ca în aplicația web, amplitudinea fiecărei curbe este
+// radius - maxDistance*poziție/100, iar curba partenerului este defazată cu π.
+func strands(in Input, o Options) [2]strand {
+	unit := o.unit()
+	margin := glowWidth * unit * 2
+	top, height := margin, float64(o.Height)-2*margin
+	centerX := float64(o.Width) / 2
+
+	partnerPosition := in.PartnerPosition
+	partnerAlpha := 1.0
+	partnerHistory := in.PartnerHistory
+	if in.PartnerHidden {
+		partnerPosition, partnerAlpha, partnerHistory = neutralPosition, hiddenAlpha, nil
+	}
+
+	window := !in.From.IsZero() && in.To.After(in.From)
+	curve := func(current int, history []Point, offset float64) []point {
+		points := make([]point, 0, steps+1)
+		for s := 0; s <= steps; s++ {
+			progress := float64(s) / steps
+			position := current
+			if window {
+				at := in.From.Add(time.Duration(progress * float64(in.To.Sub(in.From))))
+				position = positionAt(history, current, at)
+			}
+
+			i := progress * cycles * 10
+			amplitude := (radius - maxDistance*float64(clamp(position, 0, 100))/100) * unit
+			points = append(points, point{
+				x: centerX + math.Sin(i+o.Phase+offset)*amplitude,
+				y: top + progress*height,
+			})
+		}
+		return points
+	}
+
+	return [2]strand{
+		{points: curve(in.UserPosition, in.UserHistory, 0), color: o.Theme.User, alpha: 1},
+		{points: curve(partnerPosition, partnerHistory, math.Pi), color: o.Theme.Partner, alpha: partnerAlpha},
+	}
+}
+
+// positionAt returnează poziția valabilă la momentul at: ultima actualizare de până atunci, prima actualizare
+// pentru momentele de dinaintea ei, sau poziția curentă dacă nu există istoric
+func positionAt(history []Point, current int, at time.Time) int {
+	if len(history) == 0 {
+		return current
+	}
+	position := history[0].Position
+	for _, p := range history {
+		if p.At.After(at) {
+			break
+		}
+		position = p.Position
+	}
+	return position
+}
+
+func clamp(value, min, max int) int {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package helix
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInvalidSize(t *testing.T) {
+	for _, o := range []Options{{Width: 10, Height: 400}, {Width: 400, Height: MaxSize + 1}} {
+		if _, err := SVG(Input{}, o); err != ErrInvalidSize {
+			t.Errorf("SVG(%dx%d) err = %v", o.Width, o.Height, err)
+		}
+		if _, err := PNG(Input{}, o); err != ErrInvalidSize {
+			t.Errorf("PNG(%dx%d) err = %v", o.Width, o.Height, err)
+		}
+	}
+}
+
+func TestAmplitudeFollowsPosition(t *testing.T) {
+	o := Options{Width: 200, Height: 400}
+	_ = o.validate()
+
+	// unit = 1: poziția 0 dă raza completă (30), poziția 75 o anulează, poziția 100 o inversează (-10)
+	for position, want := range map[int]float64{0: 30, 75: 0, 100: 10} {
+		s := strands(Input{UserPosition: position}, o)[0]
+		var max float64
+		for _, p := range s.points {
+			if d := p.x - 100; d > max {
+				max = d
+			} else if -d > max {
+				max = -d
+			}
+		}
+		if max < want-0.5 || max > want+0.01 {
+			t.Errorf("poziția %d: amplitudine %.2f, vrem %.2f", position, max, want)
+		}
+	}
+}
+
+func TestHistoryWindow(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	history := []Point{
+		{At: from.Add(24 * time.Hour), Position: 0},
+		{At: from.Add(5 * 24 * time.Hour), Position: 100},
+	}
+
+	if got := positionAt(history, 40, from); got != 0 {
+		t.Errorf("înainte de prima actualizare: %d, vrem 0", got)
+	}
+	if got := positionAt(history, 40, from.Add(3*24*time.Hour)); got != 0 {
+		t.Errorf("între actualizări: %d, vrem 0", got)
+	}
+	if got := positionAt(history, 40, to); got != 100 {
+		t.Errorf("după ultima actualizare: %d, vrem 100", got)
+	}
+	if got := positionAt(nil, 40, to); got != 40 {
+		t.Errorf("fără istoric: %d, vrem poziția curentă", got)
+	}
+}
+
+func TestSVG(t *testing.T) {
+	theme, _ := LookupTheme("neon")
+	svg, err := SVG(Input{UserPosition: 20, PartnerPosition: 60, PartnerHidden: true}, Options{Width: 300, Height: 500, Theme: theme})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`width="300"`, `height="500"`, `#ff00ff`, `#00ffff`, `url(#glow)`, `url(#scanlines)`, `stroke-opacity="0.3"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG-ul nu conține %s", want)
+		}
+	}
+	if strings.Count(svg, "<polyline") != 2 {
+		t.Errorf("SVG-ul trebuie să aibă două curbe")
+	}
+}
+
+func TestPNG(t *testing.T) {
+	data, err := PNG(Input{UserPosition: 10, PartnerPosition: 10}, Options{Width: 400, Height: 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 400 || b.Dy() != 300 {
+		t.Fatalf("dimensiune %v", b)
+	}
+
+	// Colțul are culoarea fundalului temei implicite (cu linia de scanare peste primele două rânduri)
+	if _, _, b, a := img.At(399, 3).RGBA(); b>>8 != 0x33 || a>>8 != 0xff {
+		t.Errorf("fundal neașteptat: b=%x a=%x", b>>8, a>>8)
+	}
+
+	// Centrul liniei utilizatorului, la jumătatea înălțimii, este roz
+	s := strands(Input{UserPosition: 10, PartnerPosition: 10}, Options{Width: 400, Height: 300, Theme: themes[DefaultTheme]})
+	p := s[0].points[steps/2]
+	r, g, _, _ := img.At(int(p.x), int(p.y)).RGBA()
+	if r>>8 < 0xc0 || g>>8 > 0x60 {
+		t.Errorf("pixelul curbei nu este roz: r=%x g=%x", r>>8, g>>8)
+	}
+}
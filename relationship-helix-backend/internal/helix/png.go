@@ -0,0 +1,138 @@
+package helix
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+)
+
+// glowAlpha este opacitatea maximă a strălucirii, lângă curbă
+const glowAlpha = 0.6
+
+// PNG desenează elicea ca imagine PNG, cu margini netezite
+func PNG(in Input, o Options) ([]byte, error) {
+	img, err := Render(in, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render desenează elicea într-o imagine
+func Render(in Input, o Options) (*image.NRGBA, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+	unit := o.unit()
+
+	img := image.NewNRGBA(image.Rect(0, 0, o.Width, o.Height))
+	if o.Theme.Background.A > 0 {
+		for i := 0; i < len(img.Pix); i += 4 {
+			bg := o.Theme.Background
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = bg.R, bg.G, bg.B, bg.A
+		}
+	}
+
+	for _, s := range strands(in, o) {
+		if o.Theme.Glow {
+			glow := coverage(s.points, o.Width, o.Height, glowWidth*unit, func(d, r float64) float64 {
+				f := 1 - d/r
+				return glowAlpha * f * f
+			})
+			paint(img, glow, s.color, s.alpha)
+		}
+
+		core := coverage(s.points, o.Width, o.Height, lineWidth*unit/2+0.5, func(d, r float64) float64 {
+			// Pixelul este acoperit complet în interiorul liniei și parțial pe ultimul pixel al marginii
+			return math.Min(1, r-d)
+		})
+		paint(img, core, s.color, s.alpha)
+	}
+
+	if o.Theme.Scanlines {
+		black := color.NRGBA{A: 0xff}
+		for y := 0; y < o.Height; y += 4 {
+			for dy := 0; dy < 2 && y+dy < o.Height; dy++ {
+				for x := 0; x < o.Width; x++ {
+					blend(img, x, y+dy, black, 0.1)
+				}
+			}
+		}
+	}
+
+	return img, nil
+}
+
+// coverage calculează, pentru fiecare pixel, acoperirea (0-1) de către linia poligonală: falloff primește distanța
+// de la centrul pixelului la linie și raza r, și este aplicată pixelilor aflați la distanță mai mică decât r.
+// Pentru fiecare pixel se păstrează acoperirea maximă, astfel încât segmentele alăturate nu se suprapun vizibil.
+func coverage(points []point, width, height int, r float64, falloff func(d, r float64) float64) []float64 {
+	cov := make([]float64, width*height)
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		x0 := int(math.Max(0, math.Floor(math.Min(a.x, b.x)-r)))
+		x1 := int(math.Min(float64(width-1), math.Ceil(math.Max(a.x, b.x)+r)))
+		y0 := int(math.Max(0, math.Floor(math.Min(a.y, b.y)-r)))
+		y1 := int(math.Min(float64(height-1), math.Ceil(math.Max(a.y, b.y)+r)))
+
+		for y := y0; y <= y1; y++ {
+			for x := x0; x <= x1; x++ {
+				d := segmentDistance(point{float64(x) + 0.5, float64(y) + 0.5}, a, b)
+				if d >= r {
+					continue
+				}
+				if v := falloff(d, r); v > cov[y*width+x] {
+					cov[y*width+x] = v
+				}
+			}
+		}
+	}
+	return cov
+}
+
+// paint aplică culoarea peste imagine, cu opacitatea dată de acoperire
+func paint(img *image.NRGBA, cov []float64, c color.NRGBA, alpha float64) {
+	width := img.Rect.Dx()
+	for i, v := range cov {
+		if v > 0 {
+			blend(img, i%width, i/width, c, v*alpha)
+		}
+	}
+}
+
+// blend compune culoarea c, cu opacitatea alpha, peste pixelul (x, y) (operatorul „source over”)
+func blend(img *image.NRGBA, x, y int, c color.NRGBA, alpha float64) {
+	i := img.PixOffset(x, y)
+	p := img.Pix[i : i+4 : i+4]
+
+	srcA := alpha * float64(c.A) / 255
+	dstA := float64(p[3]) / 255
+	outA := srcA + dstA*(1-srcA)
+	if outA <= 0 {
+		return
+	}
+
+	mix := func(src, dst uint8) uint8 {
+		v := (float64(src)*srcA + float64(dst)*dstA*(1-srcA)) / outA
+		return uint8(math.Round(math.Min(255, v)))
+	}
+	p[0], p[1], p[2] = mix(c.R, p[0]), mix(c.G, p[1]), mix(c.B, p[2])
+	p[3] = uint8(math.Round(outA * 255))
+}
+
+// segmentDistance returnează distanța de la punctul p la segmentul ab
+func segmentDistance(p, a, b point) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	t := 0.0
+	if length := dx*dx + dy*dy; length > 0 {
+		t = math.Max(0, math.Min(1, ((p.x-a.x)*dx+(p.y-a.y)*dy)/length))
+	}
+	return math.Hypot(p.x-(a.x+t*dx), p.y-(a.y+t*dy))
+}
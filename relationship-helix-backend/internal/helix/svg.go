@@ -0,0 +1,83 @@
+package helix
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SVG desenează elicea ca document SVG
+func SVG(in Input, o Options) (string, error) {
+	if err := o.validate(); err != nil {
+		return "", err
+	}
+	unit := o.unit()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`,
+		o.Width, o.Height, o.Width, o.Height)
+
+	b.WriteString(`<defs>`)
+	if o.Theme.Glow {
+		fmt.Fprintf(&b, `<filter id="glow" x="-50%%" y="-50%%" width="200%%" height="200%%">`+
+			`<feGaussianBlur stdDeviation="%s" result="blur"/>`+
+			`<feMerge><feMergeNode in="blur"/><feMergeNode in="SourceGraphic"/></feMerge></filter>`,
+			formatFloat(glowWidth*unit/2))
+	}
+	if o.Theme.Scanlines {
+		b.WriteString(`<pattern id="scanlines" width="4" height="4" patternUnits="userSpaceOnUse">` +
+			`<rect width="4" height="2" fill="#000000" fill-opacity="0.1"/></pattern>`)
+	}
+	b.WriteString(`</defs>`)
+
+	if o.Theme.Background.A > 0 {
+		fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"%s/>`,
+			hexColor(o.Theme.Background), opacityAttr("fill-opacity", float64(o.Theme.Background.A)/255))
+	}
+
+	for _, s := range strands(in, o) {
+		b.WriteString(`<polyline fill="none" stroke-linecap="round" stroke-linejoin="round"`)
+		fmt.Fprintf(&b, ` stroke="%s" stroke-width="%s"%s`,
+			hexColor(s.color), formatFloat(lineWidth*unit), opacityAttr("stroke-opacity", s.alpha))
+		if o.Theme.Glow {
+			b.WriteString(` filter="url(#glow)"`)
+		}
+		b.WriteString(` points="`)
+		for i, p := range s.points {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(formatFloat(p.x))
+			b.WriteByte(',')
+			b.WriteString(formatFloat(p.y))
+		}
+		b.WriteString(`"/>`)
+	}
+
+	if o.Theme.Scanlines {
+		b.WriteString(`<rect width="100%" height="100%" fill="url(#scanlines)"/>`)
+	}
+
+	b.WriteString(`</svg>`)
+	return b.String(), nil
+}
+
+// hexColor formatează culoarea ca #rrggbb (opacitatea este separată în SVG)
+func hexColor(c color.NRGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// opacityAttr returnează atributul de opacitate, sau nimic pentru opacitate completă
+func opacityAttr(name string, alpha float64) string {
+	if alpha >= 1 {
+		return ""
+	}
+	return ` ` + name + `="` + formatFloat(alpha) + `"`
+}
+
+// formatFloat formatează coordonatele cu cel mult două zecimale, pentru un document compact
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,66 @@
+package history
+
+import (
+	"time"
+)
+
+// Event reprezintă o actualizare de poziție a unui partener
+type Event struct {
+	UserID   uint
+	Position int
+	At       time.Time
+}
+
+// Summary rezumă pozițiile unui partener într-o zi
+type Summary struct {
+	Count   int     `json:"count"`
+	Average float64 `json:"average"`
+	Last    int     `json:"last"`
+}
+
+// DayBucket grupează actualizările dintr-o zi calendaristică, din perspectiva utilizatorului
+type DayBucket struct {
+	Date    string   `json:"date"`
+	User    *Summary `json:"user"`
+	Partner *Summary `json:"partner"`
+}
+
+// BucketByDay grupează evenimentele (sortate cronologic) pe zile calendaristice în fusul orar loc.
+// Zilele fără actualizări nu apar în rezultat.
+func BucketByDay(events []Event, userID uint, loc *time.Location) []DayBucket {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	buckets := []DayBucket{}
+	sums := map[bool]int{}
+	for _, e := range events {
+		date := e.At.In(loc).Format("2006-01-02")
+		if len(buckets) == 0 || buckets[len(buckets)-1].Date != date {
+			buckets = append(buckets, DayBucket{Date: date})
+			sums = map[bool]int{}
+		}
+		bucket := &buckets[len(buckets)-1]
+
+		isUser := e.UserID == userID
+		summary := bucket.Partner
+		if isUser {
+			summary = bucket.User
+		}
+		if summary == nil {
+			summary = &Summary{}
+			if isUser {
+				bucket.User = summary
+			} else {
+				bucket.Partner = summary
+			}
+		}
+
+		summary.Count++
+		summary.Last = e.Position
+		sums[isUser] += e.Position
+		summary.Average = float64(sums[isUser]) / float64(summary.Count)
+	}
+
+	return buckets
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(month time.Month, day, hour, min int) time.Time {
+	return time.Date(2024, month, day, hour, min, 0, 0, time.UTC)
+}
+
+func TestBucketByDay(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Bucharest")
+	if err != nil {
+		t.Skip("fusul orar Europe/Bucharest nu este disponibil")
+	}
+
+	tests := []struct {
+		name   string
+		events []Event
+		loc    *time.Location
+		want   []string
+	}{
+		{
+			// 21:59 și 22:01 UTC sunt 23:59 și 00:01 la București
+			name:   "aproape de miezul nopții",
+			events: []Event{{1, 40, utc(3, 15, 21, 59)}, {2, 60, utc(3, 15, 22, 1)}},
+			loc:    loc,
+			want:   []string{"2024-03-15", "2024-03-16"},
+		},
+		{
+			name:   "aproape de miezul nopții, fără fus orar",
+			events: []Event{{1, 40, utc(3, 15, 21, 59)}, {2, 60, utc(3, 15, 22, 1)}},
+			loc:    nil,
+			want:   []string{"2024-03-15"},
+		},
+		{
+			// 31 martie are 23 de ore: începe la 22:00 UTC (EET) și se termină la 21:00 UTC (EEST)
+			name:   "trecerea la ora de vară",
+			events: []Event{{1, 10, utc(3, 30, 21, 59)}, {1, 20, utc(3, 30, 22, 0)}, {2, 30, utc(3, 31, 20, 59)}, {2, 40, utc(3, 31, 21, 0)}},
+			loc:    loc,
+			want:   []string{"2024-03-30", "2024-03-31", "2024-04-01"},
+		},
+		{
+			// 27 octombrie are 25 de ore: începe la 21:00 UTC (EEST) și se termină la 22:00 UTC (EET)
+			name:   "trecerea la ora de iarnă",
+			events: []Event{{1, 10, utc(10, 26, 20, 59)}, {1, 20, utc(10, 26, 21, 0)}, {2, 30, utc(10, 27, 21, 59)}, {2, 40, utc(10, 27, 22, 0)}},
+			loc:    loc,
+			want:   []string{"2024-10-26", "2024-10-27", "2024-10-28"},
+		},
+		{
+			name:   "fără evenimente",
+			events: nil,
+			loc:    loc,
+			want:   []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		got := BucketByDay(tt.events, 1, tt.loc)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: zile = %+v, vrem %v", tt.name, got, tt.want)
+			continue
+		}
+		for i, date := range tt.want {
+			if got[i].Date != date {
+				t.Errorf("%s: ziua %d = %s, vrem %s", tt.name, i, got[i].Date, date)
+			}
+		}
+	}
+}
+
+func TestBucketByDaySummaries(t *testing.T) {
+	events := []Event{
+		{1, 40, utc(3, 15, 8, 0)},
+		{2, 70, utc(3, 15, 9, 0)},
+		{1, 60, utc(3, 15, 20, 0)},
+		{2, 50, utc(3, 16, 9, 0)},
+	}
+
+	got := BucketByDay(events, 1, time.UTC)
+	if len(got) != 2 {
+		t.Fatalf("zile = %+v", got)
+	}
+
+	user, partner := got[0].User, got[0].Partner
+	if user == nil || user.Count != 2 || user.Average != 50 || user.Last != 60 {
+		t.Errorf("utilizator 15 martie = %+v", user)
+	}
+	if partner == nil || partner.Count != 1 || partner.Average != 70 || partner.Last != 70 {
+		t.Errorf("partener 15 martie = %+v", partner)
+	}
+
+	// O zi în care a răspuns doar partenerul nu are rezumat pentru utilizator
+	if got[1].User != nil || got[1].Partner == nil || got[1].Partner.Last != 50 {
+		t.Errorf("16 martie = %+v", got[1])
+	}
+}
@@ -0,0 +1,327 @@
+package history
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"relationship-helix/internal/stats"
+)
+
+// ErrTooManyRows este returnată când fișierul importat depășește numărul maxim de rânduri
+var ErrTooManyRows = errors.New("prea multe rânduri")
+
+// Formatele de dată acceptate la import, pe lângă RFC 3339; sunt interpretate în fusul orar al utilizatorului
+var importLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+}
+
+// ImportOptions configurează citirea unui fișier importat
+type ImportOptions struct {
+	UserID   uint           // Utilizatorul pentru care se importă; rândurile altor membri sunt omise
+	Location *time.Location // Fusul orar al datelor fără fus orar explicit
+	Before   time.Time      // Momentele trebuie să fie anterioare acestei limite
+	MaxRows  int
+}
+
+// ImportRow este un rând valid din fișierul importat
+type ImportRow struct {
+	Row        int
+	At         time.Time
+	Position   int
+	Note       *string
+	Mood       *string
+	Emoji      *string
+	Visibility string
+}
+
+// RowError descrie un rând invalid
+type RowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// Motivele pentru care un rând valid nu este importat
+const (
+	SkipDuplicate   = "duplicate"    // Același moment apare mai devreme în fișier
+	SkipExisting    = "exists"       // Istoricul conține deja o actualizare în acel moment
+	SkipOtherMember = "other_member" // Rândul aparține altui membru (ex. un export al ambilor parteneri)
+)
+
+// Skipped este un rând care nu va fi importat
+type Skipped struct {
+	Row    int        `json:"row"`
+	At     *time.Time `json:"at,omitempty"`
+	Reason string     `json:"reason"`
+}
+
+// ParsedImport este conținutul citit dintr-un fișier importat
+type ParsedImport struct {
+	Rows    []ImportRow // Rândurile valide ale utilizatorului
+	Skipped []Skipped   // Rândurile altor membri
+	Errors  []RowError  // Rândurile invalide
+}
+
+// importRecord este forma unui rând JSON sau NDJSON; câmpurile exportate în plus sunt ignorate
+type importRecord struct {
+	At         string      `json:"at"`
+	Position   json.Number `json:"position"`
+	Note       *string     `json:"note"`
+	Mood       *string     `json:"mood"`
+	Emoji      *string     `json:"emoji"`
+	Visibility string      `json:"visibility"`
+	UserID     *uint       `json:"userId"`
+}
+
+// ParseImport citește fișierul importat. Rândurile invalide sunt raportate în lista de erori; eroarea
+// returnată indică un fișier care nu poate fi citit deloc (format necunoscut, sintaxă, prea multe rânduri).
+// Rândurile sunt numerotate de la 1, fără antetul CSV.
+func ParseImport(r io.Reader, format string, opts ImportOptions) (ParsedImport, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	var records []importRecord
+	var err error
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r, opts.MaxRows)
+	case FormatJSON:
+		records, err = readJSON(r, opts.MaxRows)
+	case FormatNDJSON:
+		records, err = readNDJSON(r, opts.MaxRows)
+	default:
+		return ParsedImport{}, ErrUnknownFormat
+	}
+	if err != nil {
+		return ParsedImport{}, err
+	}
+
+	parsed := ParsedImport{Rows: []ImportRow{}, Skipped: []Skipped{}, Errors: []RowError{}}
+	for i, rec := range records {
+		// Un export al aplicației conține și pozițiile celorlalți membri, care nu pot fi importate
+		if rec.UserID != nil && *rec.UserID != opts.UserID {
+			skipped := Skipped{Row: i + 1, Reason: SkipOtherMember}
+			if at, ok := parseImportTime(strings.TrimSpace(rec.At), opts.Location); ok {
+				skipped.At = &at
+			}
+			parsed.Skipped = append(parsed.Skipped, skipped)
+			continue
+		}
+
+		row, message := rec.parse(opts)
+		if message != "" {
+			parsed.Errors = append(parsed.Errors, RowError{Row: i + 1, Message: message})
+			continue
+		}
+		row.Row = i + 1
+		parsed.Rows = append(parsed.Rows, row)
+	}
+
+	return parsed, nil
+}
+
+// parse validează un rând; returnează mesajul de eroare sau un șir gol
+func (rec importRecord) parse(opts ImportOptions) (ImportRow, string) {
+	at, ok := parseImportTime(strings.TrimSpace(rec.At), opts.Location)
+	if !ok {
+		return ImportRow{}, "Momentul lipsește sau nu are un format recunoscut (ex. 2024-03-15 20:30)"
+	}
+	if !opts.Before.IsZero() && !at.Before(opts.Before) {
+		return ImportRow{}, "Momentul trebuie să fie anterior ultimei actualizări a poziției din aplicație"
+	}
+
+	position, err := strconv.Atoi(strings.TrimSpace(rec.Position.String()))
+	if err != nil || position < 0 || position > stats.MaxPosition {
+		return ImportRow{}, "Poziția trebuie să fie un număr întreg între 0 și " + strconv.Itoa(stats.MaxPosition)
+	}
+
+	return ImportRow{
+		At:         at,
+		Position:   position,
+		Note:       rec.Note,
+		Mood:       rec.Mood,
+		Emoji:      rec.Emoji,
+		Visibility: strings.TrimSpace(rec.Visibility),
+	}, ""
+}
+
+// parseImportTime citește un moment în unul dintre formatele acceptate
+func parseImportTime(value string, loc *time.Location) (time.Time, bool) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, true
+	}
+	for _, layout := range importLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readCSV citește un fișier CSV cu antet; coloanele obligatorii sunt at și position
+func readCSV(r io.Reader, maxRows int) ([]importRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("CSV invalid: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		// Antetul poate începe cu BOM-ul adăugat de unele tabelare
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		columns[name] = i
+	}
+	if _, ok := columns["at"]; !ok {
+		return nil, errors.New("CSV invalid: lipsește coloana at")
+	}
+	if _, ok := columns["position"]; !ok {
+		return nil, errors.New("CSV invalid: lipsește coloana position")
+	}
+
+	cell := func(record []string, name string) string {
+		if i, ok := columns[strings.ToLower(name)]; ok && i < len(record) {
+			return record[i]
+		}
+		return ""
+	}
+	optionalCell := func(record []string, name string) *string {
+		if value := unescapeFormula(cell(record, name)); value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	var records []importRecord
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV invalid: %w", err)
+		}
+		if maxRows > 0 && len(records) == maxRows {
+			return nil, ErrTooManyRows
+		}
+
+		rec := importRecord{
+			At:         cell(record, "at"),
+			Position:   json.Number(cell(record, "position")),
+			Note:       optionalCell(record, "note"),
+			Mood:       optionalCell(record, "mood"),
+			Emoji:      optionalCell(record, "emoji"),
+			Visibility: cell(record, "visibility"),
+		}
+		if value := strings.TrimSpace(cell(record, "userId")); value != "" {
+			if id, err := strconv.ParseUint(value, 10, 32); err == nil {
+				userID := uint(id)
+				rec.UserID = &userID
+			}
+		}
+		records = append(records, rec)
+	}
+}
+
+// readJSON citește o listă JSON de obiecte
+func readJSON(r io.Reader, maxRows int) ([]importRecord, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return nil, errors.New("JSON invalid: se așteaptă o listă de obiecte")
+	}
+
+	var records []importRecord
+	for decoder.More() {
+		if maxRows > 0 && len(records) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		var rec importRecord
+		if err := decoder.Decode(&rec); err != nil {
+			return nil, fmt.Errorf("JSON invalid la rândul %d: %w", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, fmt.Errorf("JSON invalid: %w", err)
+	}
+	return records, nil
+}
+
+// readNDJSON citește câte un obiect JSON pe linie; liniile goale sunt ignorate
+func readNDJSON(r io.Reader, maxRows int) ([]importRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var records []importRecord
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if maxRows > 0 && len(records) == maxRows {
+			return nil, ErrTooManyRows
+		}
+		var rec importRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			return nil, fmt.Errorf("NDJSON invalid la rândul %d: %w", len(records)+1, err)
+		}
+		records = append(records, rec)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("NDJSON invalid: %w", err)
+	}
+	return records, nil
+}
+
+// Deduplicate omite momentele repetate în fișier și pe cele deja prezente în istoric (existing).
+// Momentele se compară la secundă.
+func Deduplicate(rows []ImportRow, existing []time.Time) ([]ImportRow, []Skipped) {
+	seen := make(map[int64]bool, len(existing))
+	for _, t := range existing {
+		seen[t.Unix()] = true
+	}
+
+	fresh := []ImportRow{}
+	skipped := []Skipped{}
+	inFile := make(map[int64]bool, len(rows))
+	for _, row := range rows {
+		key := row.At.Unix()
+		reason := ""
+		switch {
+		case inFile[key]:
+			reason = SkipDuplicate
+		case seen[key]:
+			reason = SkipExisting
+		}
+		if reason != "" {
+			at := row.At
+			skipped = append(skipped, Skipped{Row: row.Row, At: &at, Reason: reason})
+			continue
+		}
+		inFile[key] = true
+		fresh = append(fresh, row)
+	}
+
+	return fresh, skipped
+}
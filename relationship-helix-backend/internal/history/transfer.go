@@ -0,0 +1,144 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formatele acceptate la export și import
+const (
+	FormatCSV    = "csv"
+	FormatJSON   = "json"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats conține formatele acceptate, în ordinea din mesajele de eroare
+var Formats = []string{FormatCSV, FormatJSON, FormatNDJSON}
+
+// ContentTypes asociază fiecărui format tipul MIME al fișierului exportat
+var ContentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatJSON:   "application/json; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson; charset=utf-8",
+}
+
+// ErrUnknownFormat este returnată pentru un format care nu face parte din Formats
+var ErrUnknownFormat = errors.New("format necunoscut")
+
+// csvColumns este antetul fișierelor CSV exportate
+var csvColumns = []string{"at", "userId", "username", "position", "note", "mood", "emoji", "visibility", "imported"}
+
+// Record este o actualizare de poziție exportată
+type Record struct {
+	At         time.Time `json:"at"`
+	UserID     uint      `json:"userId"`
+	Username   string    `json:"username"`
+	Position   int       `json:"position"`
+	Note       *string   `json:"note"`
+	Mood       *string   `json:"mood"`
+	Emoji      *string   `json:"emoji"`
+	Visibility string    `json:"visibility"`
+	Imported   bool      `json:"imported"`
+}
+
+// Encoder scrie înregistrările exportate pe măsură ce sunt citite, fără să le păstreze în memorie
+type Encoder struct {
+	format string
+	w      io.Writer
+	csv    *csv.Writer
+	json   *json.Encoder
+	count  int
+}
+
+// NewEncoder creează un encoder pentru formatul dat
+func NewEncoder(w io.Writer, format string) (*Encoder, error) {
+	e := &Encoder{format: format, w: w}
+	switch format {
+	case FormatCSV:
+		e.csv = csv.NewWriter(w)
+		if err := e.csv.Write(csvColumns); err != nil {
+			return nil, err
+		}
+	case FormatJSON, FormatNDJSON:
+		e.json = json.NewEncoder(w)
+		e.json.SetEscapeHTML(false)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	return e, nil
+}
+
+// Encode scrie o înregistrare
+func (e *Encoder) Encode(r Record) error {
+	e.count++
+	switch e.format {
+	case FormatCSV:
+		return e.csv.Write([]string{
+			r.At.UTC().Format(time.RFC3339),
+			strconv.FormatUint(uint64(r.UserID), 10),
+			escapeFormula(r.Username),
+			strconv.Itoa(r.Position),
+			escapeFormula(optional(r.Note)),
+			optional(r.Mood),
+			optional(r.Emoji),
+			r.Visibility,
+			strconv.FormatBool(r.Imported),
+		})
+	case FormatJSON:
+		separator := ",\n"
+		if e.count == 1 {
+			separator = "[\n"
+		}
+		if _, err := io.WriteString(e.w, separator); err != nil {
+			return err
+		}
+	}
+	r.At = r.At.UTC()
+	return e.json.Encode(r)
+}
+
+// Close încheie fișierul (închide lista JSON, golește bufferul CSV)
+func (e *Encoder) Close() error {
+	switch e.format {
+	case FormatCSV:
+		e.csv.Flush()
+		return e.csv.Error()
+	case FormatJSON:
+		closing := "]\n"
+		if e.count == 0 {
+			closing = "[]\n"
+		}
+		_, err := io.WriteString(e.w, closing)
+		return err
+	}
+	return nil
+}
+
+// escapeFormula împiedică interpretarea textului ca formulă la deschiderea fișierului CSV într-un tabelar.
+// Apostroful adăugat este eliminat la import.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeFormula elimină apostroful adăugat de escapeFormula
+func unescapeFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@\t\r", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func optional(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
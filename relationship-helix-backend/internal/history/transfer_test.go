@@ -0,0 +1,146 @@
+package history
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testRecords() []Record {
+	note := "=SUM(A1) ne-am certat"
+	mood := "tense"
+	return []Record{
+		{At: time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC), UserID: 1, Username: "ana", Position: 40, Visibility: "shared"},
+		{At: time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC), UserID: 2, Username: "mihai", Position: 70, Note: &note, Mood: &mood, Visibility: "shared", Imported: true},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range Formats {
+		var buf bytes.Buffer
+		enc, err := NewEncoder(&buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range testRecords() {
+			if err := enc.Encode(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, err := ParseImport(&buf, format, ImportOptions{UserID: 2})
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", format, err, buf.String())
+		}
+		if len(parsed.Errors) != 0 || len(parsed.Rows) != 1 || len(parsed.Skipped) != 1 {
+			t.Fatalf("%s: %+v", format, parsed)
+		}
+		if s := parsed.Skipped[0]; s.Row != 1 || s.Reason != SkipOtherMember {
+			t.Errorf("%s: rândul Anei trebuie omis: %+v", format, s)
+		}
+
+		row := parsed.Rows[0]
+		if row.Row != 2 || row.Position != 70 || !row.At.Equal(time.Date(2024, 3, 16, 9, 0, 0, 0, time.UTC)) {
+			t.Errorf("%s: rând citit greșit: %+v", format, row)
+		}
+		if row.Note == nil || *row.Note != "=SUM(A1) ne-am certat" || row.Mood == nil || *row.Mood != "tense" {
+			t.Errorf("%s: notița nu a fost păstrată: %v %v", format, row.Note, row.Mood)
+		}
+	}
+}
+
+func TestCSVEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(&buf, FormatCSV)
+	_ = enc.Encode(testRecords()[1])
+	_ = enc.Close()
+	if !strings.Contains(buf.String(), "'=SUM(A1)") {
+		t.Errorf("formula nu a fost neutralizată:\n%s", buf.String())
+	}
+}
+
+func TestEmptyJSONExport(t *testing.T) {
+	var buf bytes.Buffer
+	enc, _ := NewEncoder(&buf, FormatJSON)
+	_ = enc.Close()
+	if buf.String() != "[]\n" {
+		t.Errorf("export gol = %q", buf.String())
+	}
+}
+
+func TestParseSpreadsheet(t *testing.T) {
+	loc := time.FixedZone("EET", 2*60*60)
+	input := "\ufeffDate,Position,Note\n" +
+		"2023-05-01,30,\n" +
+		"01.05.2023 21:15,35,seară liniștită\n" +
+		"2023-05-02,abc,\n" +
+		"mâine,20,\n" +
+		"2030-01-01,10,\n"
+
+	_, err := ParseImport(strings.NewReader(input), FormatCSV, ImportOptions{Location: loc})
+	if err == nil || !strings.Contains(err.Error(), "coloana at") {
+		t.Fatalf("lipsa coloanei at trebuie raportată: %v", err)
+	}
+
+	input = strings.Replace(input, "Date", "At", 1)
+	parsed, err := ParseImport(strings.NewReader(input), FormatCSV, ImportOptions{
+		Location: loc,
+		Before:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(parsed.Rows) != 2 {
+		t.Fatalf("rânduri valide = %+v", parsed.Rows)
+	}
+	if want := time.Date(2023, 5, 1, 21, 15, 0, 0, loc); !parsed.Rows[1].At.Equal(want) {
+		t.Errorf("data locală = %v, vrem %v", parsed.Rows[1].At, want)
+	}
+	if parsed.Rows[0].Note != nil {
+		t.Errorf("o celulă goală nu este o notiță")
+	}
+
+	var rows []int
+	for _, e := range parsed.Errors {
+		rows = append(rows, e.Row)
+	}
+	if len(rows) != 3 || rows[0] != 3 || rows[1] != 4 || rows[2] != 5 {
+		t.Errorf("erori = %+v", parsed.Errors)
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	if _, err := ParseImport(strings.NewReader(""), "xml", ImportOptions{}); err != ErrUnknownFormat {
+		t.Errorf("format necunoscut: %v", err)
+	}
+	input := "{\"at\":\"2024-01-01\",\"position\":1}\n{\"at\":\"2024-01-02\",\"position\":2}\n"
+	if _, err := ParseImport(strings.NewReader(input), FormatNDJSON, ImportOptions{MaxRows: 1}); err != ErrTooManyRows {
+		t.Errorf("limita de rânduri: %v", err)
+	}
+	if _, err := ParseImport(strings.NewReader(`{"at":"2024-01-01"}`), FormatJSON, ImportOptions{}); err == nil {
+		t.Errorf("un obiect JSON în locul listei trebuie respins")
+	}
+}
+
+func TestDeduplicate(t *testing.T) {
+	at := time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC)
+	rows := []ImportRow{
+		{Row: 1, At: at, Position: 10},
+		{Row: 2, At: at.Add(500 * time.Millisecond), Position: 20}, // Aceeași secundă
+		{Row: 3, At: at.Add(time.Hour), Position: 30},
+		{Row: 4, At: at.Add(2 * time.Hour), Position: 40},
+	}
+
+	fresh, skipped := Deduplicate(rows, []time.Time{at.Add(time.Hour)})
+	if len(fresh) != 2 || fresh[0].Row != 1 || fresh[1].Row != 4 {
+		t.Errorf("rânduri noi = %+v", fresh)
+	}
+	if len(skipped) != 2 || skipped[0].Reason != SkipDuplicate || skipped[1].Reason != SkipExisting {
+		t.Errorf("rânduri omise = %+v", skipped)
+	}
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"relationship-helix/internal/decay"
+)
+
+// DecayJob marchează și estompează pozițiile care nu au mai fost actualizate, conform politicii fiecărei relații
+type DecayJob struct {
+	DB       *sql.DB
+	Notify   NotifyFunc
+	Interval time.Duration
+}
+
+// NewDecayJob creează un nou job de estompare
+func NewDecayJob(db *sql.DB, notify NotifyFunc) *DecayJob {
+	return &DecayJob{
+		DB:       db,
+		Notify:   notify,
+		Interval: 15 * time.Minute,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *DecayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := decay.Refresh(j.DB, 0, time.Now(), decay.NotifyFunc(j.Notify)); err != nil {
+			log.Printf("Estompare: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
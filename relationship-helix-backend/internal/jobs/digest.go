@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/digest"
+	"relationship-helix/internal/mailer"
+)
+
+const (
+	// Numărul maxim de încercări pentru un rezumat a cărui trimitere a eșuat
+	maxDigestAttempts = 3
+
+	// Pauza dintre două încercări de trimitere
+	digestRetryDelay = 30 * time.Minute
+)
+
+// DigestJob trimite rezumatul săptămânal utilizatorilor abonați, lunea, în fusul orar al fiecăruia.
+// Fiecare rezumat este revendicat în digest_deliveries înainte de trimitere, astfel încât nu este
+// trimis de două ori, nici după o repornire a serverului și nici când rulează mai multe instanțe.
+type DigestJob struct {
+	DB       *sql.DB
+	Config   *config.Config
+	Mailer   mailer.Mailer
+	Interval time.Duration
+}
+
+// NewDigestJob creează un nou job pentru rezumatul săptămânal
+func NewDigestJob(db *sql.DB, cfg *config.Config, m mailer.Mailer) *DigestJob {
+	return &DigestJob{
+		DB:       db,
+		Config:   cfg,
+		Mailer:   m,
+		Interval: 5 * time.Minute,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *DigestJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(ctx, time.Now()); err != nil {
+			log.Printf("Rezumat săptămânal: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// digestRecipient este un utilizator abonat, împreună cu una dintre relațiile lui
+type digestRecipient struct {
+	UserID         uint
+	Email          string
+	Timezone       string
+	RelationshipID uint
+	JoinedAt       time.Time
+}
+
+// check trimite rezumatele săptămânii încheiate pentru care a sosit ora de trimitere
+func (j *DigestJob) check(ctx context.Context, now time.Time) error {
+	recipients, err := j.loadRecipients()
+	if err != nil {
+		return err
+	}
+
+	sendTime := digest.ParseSendTime(j.Config.DigestSendTime)
+
+	for _, r := range recipients {
+		loc := j.Config.Location(r.Timezone)
+		week := digest.LastCompleted(now, loc)
+
+		// Relațiile în care utilizatorul a intrat după săptămâna rezumată nu au încă un rezumat
+		if !r.JoinedAt.Before(week.End) {
+			continue
+		}
+
+		sendAt := week.End.Add(time.Duration(sendTime) * time.Minute)
+		if now.Before(sendAt) {
+			continue
+		}
+
+		if err := j.deliver(ctx, r, week); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver revendică rezumatul, îl construiește și îl trimite, apoi înregistrează rezultatul
+func (j *DigestJob) deliver(ctx context.Context, r *digestRecipient, week digest.Week) error {
+	weekStart := week.Start.Format("2006-01-02")
+
+	// Revendicarea reușește doar pentru un rezumat nou sau pentru unul eșuat, care mai are încercări.
+	// Un rezumat rămas în starea sending (ex. serverul s-a oprit în timpul trimiterii) nu mai este retrimis.
+	err := j.DB.QueryRow(
+		`INSERT INTO digest_deliveries (relationship_id, user_id, week_start, status, attempts, created_at, updated_at)
+         VALUES ($1, $2, $3, 'sending', 1, NOW(), NOW())
+         ON CONFLICT (relationship_id, user_id, week_start) DO UPDATE
+         SET status = 'sending', attempts = digest_deliveries.attempts + 1, updated_at = NOW()
+         WHERE digest_deliveries.status = 'failed' AND digest_deliveries.attempts < $4
+           AND digest_deliveries.updated_at < NOW() - $5 * INTERVAL '1 second'
+         RETURNING 1`,
+		r.RelationshipID, r.UserID, weekStart, maxDigestAttempts, int(digestRetryDelay.Seconds()),
+	).Scan(new(int))
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	in, err := digest.Load(j.DB, r.RelationshipID, r.UserID, week)
+	if err != nil {
+		return j.finish(r, weekStart, "failed", err)
+	}
+	in.AppURL = j.Config.AppURL
+
+	report := digest.Build(in)
+	if report.Empty() {
+		return j.finish(r, weekStart, "skipped", nil)
+	}
+
+	rendered, err := digest.Render(report)
+	if err != nil {
+		return j.finish(r, weekStart, "failed", err)
+	}
+
+	err = j.Mailer.Send(ctx, mailer.Message{
+		To:      r.Email,
+		Subject: rendered.Subject,
+		Text:    rendered.Text,
+		HTML:    rendered.HTML,
+	})
+	if err != nil {
+		return j.finish(r, weekStart, "failed", err)
+	}
+
+	return j.finish(r, weekStart, "sent", nil)
+}
+
+// finish înregistrează rezultatul trimiterii; un rezumat eșuat va fi reîncercat la o verificare ulterioară
+func (j *DigestJob) finish(r *digestRecipient, weekStart, status string, sendErr error) error {
+	var lastError *string
+	if sendErr != nil {
+		log.Printf("Rezumat săptămânal: Eroare la trimiterea către utilizatorul %d: %v\n", r.UserID, sendErr)
+		message := sendErr.Error()
+		lastError = &message
+	}
+
+	_, err := j.DB.Exec(
+		`UPDATE digest_deliveries
+         SET status = $4, last_error = $5, sent_at = CASE WHEN $4 = 'sent' THEN NOW() END, updated_at = NOW()
+         WHERE relationship_id = $1 AND user_id = $2 AND week_start = $3`,
+		r.RelationshipID, r.UserID, weekStart, status, lastError,
+	)
+	return err
+}
+
+// loadRecipients încarcă utilizatorii abonați la rezumat, câte un rând pentru fiecare relație
+func (j *DigestJob) loadRecipients() ([]*digestRecipient, error) {
+	rows, err := j.DB.Query(
+		`SELECT u.id, u.email, COALESCE(u.timezone, ''), m.relationship_id, m.joined_at
+         FROM digest_preferences p
+         JOIN users u ON u.id = p.user_id
+         JOIN relationship_members m ON m.user_id = u.id
+         WHERE p.enabled AND u.email <> ''
+         ORDER BY u.id, m.joined_at, m.id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*digestRecipient
+	for rows.Next() {
+		r := &digestRecipient{}
+		if err := rows.Scan(&r.UserID, &r.Email, &r.Timezone, &r.RelationshipID, &r.JoinedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,189 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/calendar"
+	"relationship-helix/internal/config"
+)
+
+// eventReminderGrace este întârzierea maximă cu care un memento mai este trimis (ex. după o repornire)
+const eventReminderGrace = 10 * time.Minute
+
+// EventReminderJob trimite membrilor mementourile evenimentelor din calendarul relației
+type EventReminderJob struct {
+	DB       *sql.DB
+	Config   *config.Config
+	Notify   NotifyFunc
+	Interval time.Duration
+}
+
+// NewEventReminderJob creează un nou job de mementouri pentru evenimente
+func NewEventReminderJob(db *sql.DB, cfg *config.Config, notify NotifyFunc) *EventReminderJob {
+	return &EventReminderJob{
+		DB:       db,
+		Config:   cfg,
+		Notify:   notify,
+		Interval: time.Minute,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *EventReminderJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(time.Now()); err != nil {
+			log.Printf("Mementouri evenimente: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reminderEvent conține datele unui eveniment cu mementouri
+type reminderEvent struct {
+	ID             uint
+	RelationshipID uint
+	Title          string
+	StartsAt       time.Time
+	EndsAt         time.Time
+	AllDay         bool
+	Timezone       string
+	Recurrence     string
+	Reminders      []int64
+}
+
+// check trimite mementourile al căror moment a sosit în ultimele minute
+func (j *EventReminderJob) check(now time.Time) error {
+	events, err := j.loadEvents(now)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		var longest time.Duration
+		for _, m := range e.Reminders {
+			if d := time.Duration(m) * time.Minute; d > longest {
+				longest = d
+			}
+		}
+
+		// Aparițiile care încep până la cel mai lung memento de acum încolo
+		duration := e.EndsAt.Sub(e.StartsAt)
+		start := e.StartsAt.In(j.Config.Location(e.Timezone))
+		occurrences, err := calendar.Expand(start, duration, e.Recurrence, now.Add(-eventReminderGrace), now.Add(longest), 100)
+		if err != nil {
+			log.Printf("Mementouri evenimente: Regulă invalidă la evenimentul %d: %v\n", e.ID, err)
+			continue
+		}
+
+		for _, o := range occurrences {
+			for _, m := range e.Reminders {
+				at := o.Add(-time.Duration(m) * time.Minute)
+				if at.After(now) || now.Sub(at) > eventReminderGrace {
+					continue
+				}
+				if err := j.notifyOnce(e, o, int(m)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// notifyOnce trimite mementoul tuturor membrilor, doar dacă nu a mai fost trimis pentru această apariție
+func (j *EventReminderJob) notifyOnce(e *reminderEvent, occurrence time.Time, minutes int) error {
+	result, err := j.DB.Exec(
+		`INSERT INTO relationship_event_reminders (event_id, occurrence_at, minutes_before, created_at)
+         VALUES ($1, $2, $3, NOW())
+         ON CONFLICT DO NOTHING`,
+		e.ID, occurrence, minutes,
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil
+	}
+
+	memberIDs, err := j.members(e.RelationshipID)
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"eventId":       e.ID,
+		"title":         e.Title,
+		"startsAt":      occurrence,
+		"endsAt":        occurrence.Add(e.EndsAt.Sub(e.StartsAt)),
+		"allDay":        e.AllDay,
+		"minutesBefore": minutes,
+	}
+	for _, userID := range memberIDs {
+		j.Notify(e.RelationshipID, userID, "event_reminder", payload)
+	}
+	return nil
+}
+
+// loadEvents încarcă evenimentele cu mementouri care pot avea apariții viitoare
+func (j *EventReminderJob) loadEvents(now time.Time) ([]*reminderEvent, error) {
+	rows, err := j.DB.Query(
+		`SELECT id, relationship_id, title, starts_at, ends_at, all_day, timezone, COALESCE(recurrence, ''), reminders
+         FROM relationship_events
+         WHERE cardinality(reminders) > 0 AND (recurrence IS NOT NULL OR starts_at > $1)`,
+		now.Add(-eventReminderGrace),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*reminderEvent
+	for rows.Next() {
+		var e reminderEvent
+		var reminders pq.Int64Array
+		if err := rows.Scan(&e.ID, &e.RelationshipID, &e.Title, &e.StartsAt, &e.EndsAt, &e.AllDay, &e.Timezone, &e.Recurrence, &reminders); err != nil {
+			return nil, err
+		}
+		e.Reminders = reminders
+		events = append(events, &e)
+	}
+
+	return events, rows.Err()
+}
+
+// members încarcă ID-urile membrilor relației
+func (j *EventReminderJob) members(relationshipID uint) ([]uint, error) {
+	rows, err := j.DB.Query(
+		`SELECT user_id FROM relationship_members WHERE relationship_id = $1 ORDER BY joined_at, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
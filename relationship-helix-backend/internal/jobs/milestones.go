@@ -0,0 +1,254 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/milestones"
+	"relationship-helix/internal/webhooks"
+)
+
+// NotifyFunc trimite un eveniment în timp real unui utilizator dintr-o relație
+type NotifyFunc func(relationshipID, userID uint, eventType string, payload interface{})
+
+// MilestoneJob verifică periodic aniversările și le anunță la miezul nopții, în fusul orar al fiecărui partener
+type MilestoneJob struct {
+	DB       *sql.DB
+	Config   *config.Config
+	Notify   NotifyFunc
+	Interval time.Duration
+
+	// Ultima zi verificată pentru fiecare fus orar al membrilor ("" = fusul orar implicit)
+	lastDays map[string]time.Time
+	// Momentul ultimei verificări reușite; membrii intrați după el sunt verificați imediat
+	lastRun time.Time
+}
+
+// NewMilestoneJob creează un nou job de aniversări
+func NewMilestoneJob(db *sql.DB, cfg *config.Config, notify NotifyFunc) *MilestoneJob {
+	return &MilestoneJob{
+		DB:       db,
+		Config:   cfg,
+		Notify:   notify,
+		Interval: time.Minute,
+		lastDays: make(map[string]time.Time),
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *MilestoneJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(time.Now()); err != nil {
+			log.Printf("Aniversări: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relationshipRow conține datele necesare calculului aniversărilor pentru o relație
+type relationshipRow struct {
+	ID        uint
+	UserIDs   []uint
+	Timezones []string
+	StartDate time.Time
+	Custom    []milestones.CustomDate
+}
+
+// check trimite evenimentele milestone_reached pentru membrii la care a început o zi nouă. Sunt încărcați doar
+// membrii din fusurile orare în care ziua s-a schimbat de la ultima verificare și cei intrați între timp în relații.
+func (j *MilestoneJob) check(now time.Time) error {
+	days, err := j.changedDays(now)
+	if err != nil {
+		return err
+	}
+
+	since := j.lastRun
+	if since.IsZero() {
+		since = now
+	}
+	if len(days) == 0 && !j.hasNewMembers(since) {
+		j.lastRun = now
+		return nil
+	}
+
+	timezones := make([]string, 0, len(days))
+	for timezone := range days {
+		timezones = append(timezones, timezone)
+	}
+
+	relationships, err := j.loadRelationships(timezones, since)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range relationships {
+		for i, userID := range r.UserIDs {
+			loc := j.Config.Location(r.Timezones[i])
+			today := milestones.Date(now, loc)
+
+			for _, m := range milestones.On(milestones.Date(r.StartDate, loc), r.Custom, today) {
+				if err := j.notifyOnce(r.ID, userID, m); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Zilele sunt marcate ca verificate doar după o verificare reușită; altfel se reîncearcă la următorul tick
+	for timezone, today := range days {
+		j.lastDays[timezone] = today
+	}
+	j.lastRun = now
+
+	return nil
+}
+
+// changedDays returnează fusurile orare ale membrilor în care ziua curentă nu a fost încă verificată
+func (j *MilestoneJob) changedDays(now time.Time) (map[string]time.Time, error) {
+	rows, err := j.DB.Query(
+		`SELECT DISTINCT COALESCE(u.timezone, '')
+         FROM relationship_members m
+         JOIN users u ON u.id = m.user_id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changed := make(map[string]time.Time)
+	for rows.Next() {
+		var timezone string
+		if err := rows.Scan(&timezone); err != nil {
+			return nil, err
+		}
+		today := milestones.Date(now, j.Config.Location(timezone))
+		if last, ok := j.lastDays[timezone]; !ok || !last.Equal(today) {
+			changed[timezone] = today
+		}
+	}
+
+	return changed, rows.Err()
+}
+
+// hasNewMembers verifică dacă au intrat membri noi în relații după momentul dat
+func (j *MilestoneJob) hasNewMembers(since time.Time) bool {
+	var exists bool
+	err := j.DB.QueryRow(
+		`SELECT EXISTS (SELECT 1 FROM relationship_members WHERE joined_at > $1)`,
+		since,
+	).Scan(&exists)
+	// La eroare, membrii sunt verificați oricum; notificările duplicate sunt oprite de milestone_notifications
+	return err != nil || exists
+}
+
+// notifyOnce trimite evenimentul doar dacă aniversarea nu a mai fost notificată
+func (j *MilestoneJob) notifyOnce(relationshipID, userID uint, m milestones.Milestone) error {
+	result, err := j.DB.Exec(
+		`INSERT INTO milestone_notifications (relationship_id, user_id, milestone_key, milestone_date, created_at)
+         VALUES ($1, $2, $3, $4, NOW())
+         ON CONFLICT DO NOTHING`,
+		relationshipID, userID, m.Key, m.Date,
+	)
+	if err != nil {
+		return err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return nil
+	}
+
+	j.Notify(relationshipID, userID, "milestone_reached", m)
+
+	return webhooks.Enqueue(j.DB, webhooks.Event{
+		Type:           webhooks.EventMilestoneReached,
+		RelationshipID: relationshipID,
+		RecipientIDs:   []uint{userID},
+		Data:           m,
+	})
+}
+
+// loadRelationships încarcă membrii din fusurile orare date și pe cei intrați după since,
+// grupați pe relații, împreună cu datele speciale ale relațiilor
+func (j *MilestoneJob) loadRelationships(timezones []string, since time.Time) ([]*relationshipRow, error) {
+	rows, err := j.DB.Query(
+		`SELECT r.id, r.start_date, m.user_id, COALESCE(u.timezone, '')
+         FROM relationships r
+         JOIN relationship_members m ON m.relationship_id = r.id
+         JOIN users u ON u.id = m.user_id
+         WHERE COALESCE(u.timezone, '') = ANY($1) OR m.joined_at > $2
+         ORDER BY r.id, m.joined_at, m.id`,
+		pq.Array(timezones), since,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*relationshipRow
+	byID := make(map[uint]*relationshipRow)
+	for rows.Next() {
+		var id, userID uint
+		var startDate time.Time
+		var timezone string
+		if err := rows.Scan(&id, &startDate, &userID, &timezone); err != nil {
+			return nil, err
+		}
+
+		// Fiecare membru apare pe un rând separat
+		r, ok := byID[id]
+		if !ok {
+			r = &relationshipRow{ID: id, StartDate: startDate}
+			result = append(result, r)
+			byID[id] = r
+		}
+		r.UserIDs = append(r.UserIDs, userID)
+		r.Timezones = append(r.Timezones, timezone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]int64, 0, len(result))
+	for _, r := range result {
+		ids = append(ids, int64(r.ID))
+	}
+
+	dateRows, err := j.DB.Query(
+		`SELECT id, relationship_id, title, date, recurring FROM relationship_dates WHERE relationship_id = ANY($1)`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer dateRows.Close()
+
+	for dateRows.Next() {
+		var d milestones.CustomDate
+		var relationshipID uint
+		if err := dateRows.Scan(&d.ID, &relationshipID, &d.Title, &d.Date, &d.Recurring); err != nil {
+			return nil, err
+		}
+		if r, ok := byID[relationshipID]; ok {
+			d.Date = milestones.Date(d.Date, time.UTC)
+			r.Custom = append(r.Custom, d)
+		}
+	}
+
+	return result, dateRows.Err()
+}
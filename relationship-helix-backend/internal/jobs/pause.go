@@ -0,0 +1,95 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"relationship-helix/internal/pause"
+)
+
+// PauseJob închide pauzele de intimitate expirate și anunță ceilalți membri
+type PauseJob struct {
+	DB       *sql.DB
+	Notify   NotifyFunc
+	Interval time.Duration
+}
+
+// NewPauseJob creează un nou job de pauze
+func NewPauseJob(db *sql.DB, notify NotifyFunc) *PauseJob {
+	return &PauseJob{
+		DB:       db,
+		Notify:   notify,
+		Interval: 30 * time.Second,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *PauseJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(); err != nil {
+			log.Printf("Pauze: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check trimite pause_ended celorlalți membri ai relațiilor utilizatorilor a căror pauză a expirat
+func (j *PauseJob) check() error {
+	ended, err := pause.EndDue(j.DB)
+	if err != nil {
+		return err
+	}
+
+	for i := range ended {
+		p := &ended[i]
+
+		memberIDs, err := j.otherMembers(p.RelationshipID, p.UserID)
+		if err != nil {
+			log.Printf("Pauze: Eroare la relația %d: %v\n", p.RelationshipID, err)
+			continue
+		}
+
+		payload, err := pause.EndedPayload(j.DB, p)
+		if err != nil {
+			log.Printf("Pauze: Eroare la relația %d: %v\n", p.RelationshipID, err)
+			continue
+		}
+		for _, memberID := range memberIDs {
+			j.Notify(p.RelationshipID, memberID, "pause_ended", payload)
+		}
+	}
+
+	return nil
+}
+
+// otherMembers returnează membrii relației, cu excepția utilizatorului dat
+func (j *PauseJob) otherMembers(relationshipID, userID uint) ([]uint, error) {
+	rows, err := j.DB.Query(
+		`SELECT user_id FROM relationship_members WHERE relationship_id = $1 AND user_id <> $2`,
+		relationshipID, userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
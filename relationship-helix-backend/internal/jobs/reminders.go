@@ -0,0 +1,208 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+	"time"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/notify"
+	"relationship-helix/internal/reminders"
+)
+
+// ReminderJob trimite mementoul zilnic utilizatorilor care nu și-au actualizat poziția în ziua respectivă
+type ReminderJob struct {
+	DB       *sql.DB
+	Config   *config.Config
+	Notifier notify.Notifier
+	Notify   NotifyFunc
+	Interval time.Duration
+}
+
+// NewReminderJob creează un nou job de mementouri
+func NewReminderJob(db *sql.DB, cfg *config.Config, notifier notify.Notifier, notify NotifyFunc) *ReminderJob {
+	return &ReminderJob{
+		DB:       db,
+		Config:   cfg,
+		Notifier: notifier,
+		Notify:   notify,
+		Interval: time.Minute,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *ReminderJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(ctx, time.Now()); err != nil {
+			log.Printf("Mementouri: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// reminderUser conține datele necesare trimiterii mementoului către un utilizator
+type reminderUser struct {
+	ID       uint
+	Username string
+	Email    string
+	Timezone string
+	Settings reminders.Settings
+}
+
+// staleRelationship este o relație în care utilizatorul nu și-a actualizat poziția astăzi
+type staleRelationship struct {
+	ID            uint
+	LastUpdatedAt *time.Time
+}
+
+// check trimite mementourile scadente
+func (j *ReminderJob) check(ctx context.Context, now time.Time) error {
+	users, err := j.loadUsers()
+	if err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		loc := j.Config.Location(u.Timezone)
+		if !u.Settings.Due(now, loc) {
+			continue
+		}
+
+		stale, err := j.staleRelationships(u.ID, now, loc)
+		if err != nil {
+			return err
+		}
+		if len(stale) == 0 {
+			continue
+		}
+
+		if err := j.send(ctx, u, stale, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// send marchează mementoul ca trimis și îl livrează prin WebSocket și prin canalele de notificare
+func (j *ReminderJob) send(ctx context.Context, u *reminderUser, stale []staleRelationship, now time.Time) error {
+	_, err := j.DB.Exec(
+		`INSERT INTO checkin_reminders (user_id, last_sent_at, updated_at)
+         VALUES ($1, $2, NOW())
+         ON CONFLICT (user_id) DO UPDATE SET last_sent_at = $2`,
+		u.ID, now,
+	)
+	if err != nil {
+		return err
+	}
+
+	relationshipIDs := make([]uint, len(stale))
+	for i, r := range stale {
+		relationshipIDs[i] = r.ID
+		j.Notify(r.ID, u.ID, "checkin_reminder", map[string]interface{}{
+			"relationshipId": r.ID,
+			"lastUpdatedAt":  r.LastUpdatedAt,
+		})
+	}
+
+	body := "Nu ți-ai actualizat astăzi poziția."
+	if len(stale) > 1 {
+		body = "Nu ți-ai actualizat astăzi poziția în " + strconv.Itoa(len(stale)) + " relații."
+	}
+
+	err = j.Notifier.Send(ctx, notify.Message{
+		UserID:   u.ID,
+		Email:    u.Email,
+		Username: u.Username,
+		Kind:     "checkin_reminder",
+		Title:    "Cum vă simțiți azi?",
+		Body:     body,
+		URL:      j.Config.AppURL,
+		Data: map[string]interface{}{
+			"relationshipIds": relationshipIDs,
+		},
+	})
+	if err != nil {
+		log.Printf("Mementouri: Eroare la notificarea utilizatorului %d: %v\n", u.ID, err)
+	}
+
+	return nil
+}
+
+// loadUsers încarcă utilizatorii care au cel puțin o relație și nu au renunțat la mementouri
+func (j *ReminderJob) loadUsers() ([]*reminderUser, error) {
+	rows, err := j.DB.Query(
+		`SELECT u.id, u.username, u.email, COALESCE(u.timezone, ''),
+                r.remind_at, r.quiet_start, r.quiet_end, r.snoozed_until, r.last_sent_at
+         FROM users u
+         LEFT JOIN checkin_reminders r ON r.user_id = u.id
+         WHERE COALESCE(r.enabled, TRUE)
+           AND EXISTS(SELECT 1 FROM relationship_members m WHERE m.user_id = u.id)`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	defaultRemindAt := reminders.ParseDefault(j.Config.ReminderDefaultTime)
+
+	var result []*reminderUser
+	for rows.Next() {
+		u := &reminderUser{Settings: reminders.Settings{Enabled: true, RemindAt: defaultRemindAt}}
+		var remindAt, quietStart, quietEnd sql.NullInt64
+		var snoozedUntil, lastSentAt sql.NullTime
+		err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.Timezone, &remindAt, &quietStart, &quietEnd, &snoozedUntil, &lastSentAt)
+		if err != nil {
+			return nil, err
+		}
+		reminders.Apply(&u.Settings, remindAt, quietStart, quietEnd, snoozedUntil, lastSentAt)
+		result = append(result, u)
+	}
+
+	return result, rows.Err()
+}
+
+// staleRelationships returnează relațiile în care utilizatorul nu și-a actualizat poziția (inclusiv una sigilată) astăzi
+func (j *ReminderJob) staleRelationships(userID uint, now time.Time, loc *time.Location) ([]staleRelationship, error) {
+	rows, err := j.DB.Query(
+		`SELECT m.relationship_id, GREATEST(cp.updated_at, sp.updated_at)
+         FROM relationship_members m
+         LEFT JOIN curve_positions cp ON cp.relationship_id = m.relationship_id AND cp.user_id = m.user_id
+         LEFT JOIN sealed_positions sp ON sp.relationship_id = m.relationship_id AND sp.user_id = m.user_id
+         WHERE m.user_id = $1
+         ORDER BY m.joined_at, m.id`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []staleRelationship
+	for rows.Next() {
+		var r staleRelationship
+		var lastUpdatedAt sql.NullTime
+		if err := rows.Scan(&r.ID, &lastUpdatedAt); err != nil {
+			return nil, err
+		}
+		if lastUpdatedAt.Valid {
+			if reminders.SameDay(lastUpdatedAt.Time, now, loc) {
+				continue
+			}
+			r.LastUpdatedAt = &lastUpdatedAt.Time
+		}
+		result = append(result, r)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"relationship-helix/internal/reveal"
+)
+
+// RevealJob dezvăluie pozițiile sigilate al căror termen a expirat
+type RevealJob struct {
+	DB       *sql.DB
+	Notify   NotifyFunc
+	Interval time.Duration
+}
+
+// NewRevealJob creează un nou job de dezvăluire
+func NewRevealJob(db *sql.DB, notify NotifyFunc) *RevealJob {
+	return &RevealJob{
+		DB:       db,
+		Notify:   notify,
+		Interval: 30 * time.Second,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *RevealJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(); err != nil {
+			log.Printf("Dezvăluire: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check dezvăluie relațiile la care a trecut fereastra de la prima poziție sigilată
+func (j *RevealJob) check() error {
+	rows, err := j.DB.Query(
+		`SELECT s.relationship_id
+         FROM sealed_positions s
+         JOIN relationships r ON r.id = s.relationship_id
+         GROUP BY s.relationship_id, r.reveal_window_minutes
+         HAVING MIN(s.created_at) + r.reveal_window_minutes * INTERVAL '1 minute' <= NOW()`,
+	)
+	if err != nil {
+		return err
+	}
+
+	var due []uint
+	for rows.Next() {
+		var relationshipID uint
+		if err := rows.Scan(&relationshipID); err != nil {
+			rows.Close()
+			return err
+		}
+		due = append(due, relationshipID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, relationshipID := range due {
+		if _, err := reveal.Reveal(j.DB, relationshipID, reveal.NotifyFunc(j.Notify)); err != nil {
+			log.Printf("Dezvăluire: Eroare la relația %d: %v\n", relationshipID, err)
+		}
+	}
+
+	return nil
+}
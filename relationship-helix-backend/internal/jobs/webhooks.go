@@ -0,0 +1,239 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/webhooks"
+)
+
+// webhookBatchSize limitează numărul de livrări preluate la o verificare
+const webhookBatchSize = 50
+
+// webhookWorkers limitează numărul de livrări trimise în paralel
+const webhookWorkers = 8
+
+// WebhookJob trimite livrările de webhook în așteptare și programează reîncercările
+type WebhookJob struct {
+	DB       *sql.DB
+	Config   *config.Config
+	Client   *webhooks.Client
+	Interval time.Duration
+}
+
+// NewWebhookJob creează un nou job de webhook-uri
+func NewWebhookJob(db *sql.DB, cfg *config.Config) *WebhookJob {
+	return &WebhookJob{
+		DB:       db,
+		Config:   cfg,
+		Client:   webhooks.NewClient(cfg.WebhookTimeout, cfg.WebhookAllowPrivate),
+		Interval: 10 * time.Second,
+	}
+}
+
+// Run rulează job-ul până la anularea contextului
+func (j *WebhookJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.check(ctx); err != nil {
+			log.Printf("Webhook-uri: Eroare la verificare: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// webhookDelivery conține datele necesare trimiterii unei livrări
+type webhookDelivery struct {
+	ID             uint
+	WebhookID      uint
+	EventType      string
+	RelationshipID uint
+	Payload        []byte
+	Attempts       int
+	CreatedAt      time.Time
+	URL            string
+	Secret         string
+}
+
+// check trimite livrările scadente ale webhook-urilor active
+func (j *WebhookJob) check(ctx context.Context) error {
+	deliveries, err := j.claim()
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, webhookWorkers)
+	for i := range deliveries {
+		d := &deliveries[i]
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := j.deliver(ctx, d); err != nil {
+				log.Printf("Webhook-uri: Eroare la livrarea %d: %v\n", d.ID, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// claim preia livrările scadente; next_attempt_at este mutat după timeout-ul de trimitere,
+// astfel încât o livrare întreruptă să fie reluată, dar nu trimisă de două ori în paralel
+func (j *WebhookJob) claim() ([]webhookDelivery, error) {
+	lease := 2*j.Config.WebhookTimeout + time.Minute
+
+	rows, err := j.DB.Query(
+		`UPDATE webhook_deliveries d
+         SET next_attempt_at = NOW() + $1 * INTERVAL '1 second'
+         FROM (
+             SELECT d2.id, w.url, w.secret
+             FROM webhook_deliveries d2
+             JOIN webhooks w ON w.id = d2.webhook_id
+             WHERE d2.status = $2 AND d2.next_attempt_at <= NOW() AND w.active
+             ORDER BY d2.next_attempt_at
+             LIMIT $3
+             FOR UPDATE OF d2 SKIP LOCKED
+         ) due
+         WHERE d.id = due.id
+         RETURNING d.id, d.webhook_id, d.event_type, d.relationship_id, d.payload, d.attempts, d.created_at, due.url, due.secret`,
+		int(lease.Seconds()), webhooks.StatusPending, webhookBatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []webhookDelivery
+	for rows.Next() {
+		var d webhookDelivery
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventType, &d.RelationshipID, &d.Payload, &d.Attempts, &d.CreatedAt, &d.URL, &d.Secret); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// deliver trimite o livrare și înregistrează rezultatul
+func (j *WebhookJob) deliver(ctx context.Context, d *webhookDelivery) error {
+	body, err := json.Marshal(webhooks.Envelope{
+		ID:             d.ID,
+		Type:           d.EventType,
+		RelationshipID: d.RelationshipID,
+		CreatedAt:      d.CreatedAt,
+		Data:           json.RawMessage(d.Payload),
+	})
+	if err != nil {
+		return err
+	}
+
+	result := j.Client.Send(ctx, d.URL, d.Secret, d.ID, d.EventType, body)
+	if ctx.Err() != nil {
+		// Serverul se oprește; livrarea este reluată după expirarea preluării
+		return nil
+	}
+
+	return j.record(d, result)
+}
+
+// record actualizează livrarea și contorul de eșecuri al webhook-ului; după WebhookMaxAttempts
+// încercări livrarea eșuează, iar după WebhookDisableAfter livrări eșuate la rând webhook-ul este dezactivat
+func (j *WebhookJob) record(d *webhookDelivery, result webhooks.Result) error {
+	attempts := d.Attempts + 1
+
+	var statusCode *int
+	if result.StatusCode != 0 {
+		statusCode = &result.StatusCode
+	}
+
+	if result.OK() {
+		_, err := j.DB.Exec(
+			`UPDATE webhook_deliveries
+             SET status = $2, attempts = $3, last_status_code = $4, last_error = NULL, next_attempt_at = NULL, delivered_at = NOW()
+             WHERE id = $1`,
+			d.ID, webhooks.StatusSucceeded, attempts, statusCode,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = j.DB.Exec(`UPDATE webhooks SET failure_count = 0 WHERE id = $1 AND failure_count > 0`, d.WebhookID)
+		return err
+	}
+
+	message := result.ErrorMessage()
+
+	if attempts < j.Config.WebhookMaxAttempts {
+		_, err := j.DB.Exec(
+			`UPDATE webhook_deliveries
+             SET attempts = $2, last_status_code = $3, last_error = $4, next_attempt_at = NOW() + $5 * INTERVAL '1 second'
+             WHERE id = $1`,
+			d.ID, attempts, statusCode, message, int(webhooks.Backoff(attempts).Seconds()),
+		)
+		return err
+	}
+
+	// Începe o tranzacție
+	tx, err := j.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(
+		`UPDATE webhook_deliveries
+         SET status = $2, attempts = $3, last_status_code = $4, last_error = $5, next_attempt_at = NULL
+         WHERE id = $1`,
+		d.ID, webhooks.StatusFailed, attempts, statusCode, message,
+	)
+	if err != nil {
+		return err
+	}
+
+	var failures int
+	var active bool
+	err = tx.QueryRow(
+		`UPDATE webhooks SET failure_count = failure_count + 1 WHERE id = $1 RETURNING failure_count, active`,
+		d.WebhookID,
+	).Scan(&failures, &active)
+	if err == sql.ErrNoRows {
+		// Webhook-ul a fost șters între timp
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if active && failures >= j.Config.WebhookDisableAfter {
+		_, err := tx.Exec(
+			`UPDATE webhooks SET active = FALSE, disabled_at = NOW(), updated_at = NOW() WHERE id = $1`,
+			d.WebhookID,
+		)
+		if err != nil {
+			return err
+		}
+		if err := webhooks.CancelPending(tx, d.WebhookID, "webhook dezactivat după livrări eșuate repetat"); err != nil {
+			return err
+		}
+		log.Printf("Webhook-uri: Webhook-ul %d a fost dezactivat după %d livrări eșuate\n", d.WebhookID, failures)
+	}
+
+	return tx.Commit()
+}
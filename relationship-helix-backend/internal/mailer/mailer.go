@@ -0,0 +1,161 @@
+// Package mailer trimite email-uri cu variantă text și HTML (ex. rezumatul săptămânal), prin implementări
+// interschimbabile alese din configurație.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+
+	"relationship-helix/internal/config"
+)
+
+// Message este un email adresat unui singur destinatar
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string // Opțional; fără HTML se trimite doar varianta text
+}
+
+// Mailer trimite email-uri
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// FromConfig alege implementarea din DIGEST_MAILER: "smtp" (folosește setările SMTP_*) sau "log" (implicit).
+// Dacă SMTP nu este configurat complet, email-urile sunt doar scrise în jurnal.
+func FromConfig(cfg *config.Config) Mailer {
+	switch cfg.DigestMailer {
+	case "smtp":
+		if cfg.SMTPHost == "" || cfg.SMTPFrom == "" {
+			log.Println("Email: SMTP_HOST și SMTP_FROM sunt necesare; email-urile vor fi doar scrise în jurnal")
+			return Log{}
+		}
+		return &SMTP{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}
+	case "log", "":
+		return Log{}
+	default:
+		log.Printf("Email: implementare necunoscută %q; email-urile vor fi doar scrise în jurnal\n", cfg.DigestMailer)
+		return Log{}
+	}
+}
+
+// Log scrie email-ul în jurnalul serverului, fără să-l trimită (util în dezvoltare)
+type Log struct{}
+
+// Send implementează Mailer
+func (Log) Send(ctx context.Context, msg Message) error {
+	log.Printf("Email pentru %s: %s\n", msg.To, msg.Subject)
+	return nil
+}
+
+// SMTP trimite email-ul printr-un server SMTP
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// Send implementează Mailer; mesajele fără destinatar sunt ignorate
+func (s *SMTP) Send(ctx context.Context, msg Message) error {
+	if msg.To == "" {
+		return nil
+	}
+
+	boundary, err := newBoundary()
+	if err != nil {
+		return err
+	}
+	body, err := Build(s.From, msg, boundary)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	if err := smtp.SendMail(addr, auth, s.From, []string{msg.To}, body); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
+
+// Build construiește mesajul MIME: text simplu, sau multipart/alternative cu variantele text și HTML
+func Build(from string, msg Message, boundary string) ([]byte, error) {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(from, "\r\n") {
+		return nil, fmt.Errorf("email: adresă invalidă")
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from)
+	header("To", msg.To)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("MIME-Version", "1.0")
+
+	if msg.HTML == "" {
+		if err := writePart(&buf, "text/plain", msg.Text); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	header("Content-Type", `multipart/alternative; boundary="`+boundary+`"`)
+	buf.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", msg.Text},
+		{"text/html", msg.HTML},
+	} {
+		buf.WriteString("--" + boundary + "\r\n")
+		if err := writePart(&buf, part.contentType, part.body); err != nil {
+			return nil, err
+		}
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("--" + boundary + "--\r\n")
+	return buf.Bytes(), nil
+}
+
+// writePart scrie antetele și conținutul (codificat quoted-printable) al unei părți
+func writePart(buf *bytes.Buffer, contentType, body string) error {
+	buf.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// newBoundary generează un separator aleator între părțile mesajului
+func newBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "helix-" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,76 @@
+package mailer
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestBuildMultipart(t *testing.T) {
+	data, err := Build("helix@example.com", Message{
+		To:      "ana@example.com",
+		Subject: "Rezumatul săptămânii",
+		Text:    "Bună, Ana!",
+		HTML:    "<p>Bună, Ana!</p>",
+	}, "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil || subject != "Rezumatul săptămânii" {
+		t.Errorf("subiect = %q, %v", subject, err)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	want := []struct{ contentType, body string }{
+		{"text/plain", "Bună, Ana!"},
+		{"text/html", "<p>Bună, Ana!</p>"},
+	}
+	for _, w := range want {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ct, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); ct != w.contentType {
+			t.Errorf("partea are tipul %q, vrem %q", ct, w.contentType)
+		}
+		// multipart.Reader decodifică automat quoted-printable
+		body, _ := io.ReadAll(part)
+		if string(body) != w.body {
+			t.Errorf("conținut %q, vrem %q", body, w.body)
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("mesajul are mai mult de două părți")
+	}
+}
+
+func TestBuildPlainText(t *testing.T) {
+	data, err := Build("helix@example.com", Message{To: "ana@example.com", Subject: "Test", Text: "Salut"}, "b1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Content-Type: text/plain; charset=UTF-8") || strings.Contains(string(data), "multipart") {
+		t.Errorf("mesajul fără HTML trebuie să fie doar text:\n%s", data)
+	}
+}
+
+func TestBuildRejectsHeaderInjection(t *testing.T) {
+	if _, err := Build("helix@example.com", Message{To: "ana@example.com\r\nBcc: x@example.com"}, "b1"); err == nil {
+		t.Errorf("adresa cu linie nouă a fost acceptată")
+	}
+}
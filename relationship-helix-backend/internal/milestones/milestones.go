@@ -0,0 +1,201 @@
+package milestones
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Kind reprezintă tipul unei aniversări
+type Kind string
+
+const (
+	KindDays    Kind = "days"
+	KindMonthly Kind = "monthly"
+	KindYearly  Kind = "yearly"
+	KindCustom  Kind = "custom"
+)
+
+// DayMilestones sunt pragurile fixe de zile; după ultimul prag se continuă din 1000 în 1000
+var DayMilestones = []int{100, 365, 500, 1000}
+
+const day = 24 * time.Hour
+
+// Milestone reprezintă o aniversare calculată pentru o zi calendaristică
+type Milestone struct {
+	Key       string    `json:"key"`
+	Kind      Kind      `json:"kind"`
+	Title     string    `json:"title"`
+	Date      time.Time `json:"date"`
+	Value     int       `json:"value"`
+	DaysUntil int       `json:"daysUntil"`
+}
+
+// CustomDate este o dată definită de utilizatori (ex. prima întâlnire)
+type CustomDate struct {
+	ID        uint
+	Title     string
+	Date      time.Time
+	Recurring bool
+}
+
+// Date returnează ziua calendaristică a momentului t în fusul orar loc, ca miezul nopții în UTC
+func Date(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// DaysBetween returnează numărul de zile calendaristice dintre două date obținute cu Date
+func DaysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / day)
+}
+
+// On returnează aniversările care cad exact în ziua dată
+func On(start time.Time, custom []CustomDate, today time.Time) []Milestone {
+	return Between(start, custom, today, today, today)
+}
+
+// Upcoming returnează următoarele n aniversări, începând cu ziua curentă
+func Upcoming(start time.Time, custom []CustomDate, today time.Time, n int) []Milestone {
+	if n <= 0 {
+		return []Milestone{}
+	}
+
+	// Aniversările lunare garantează cel puțin 12 rezultate pe an
+	to := today.AddDate(n/12+2, 0, 0)
+	result := Between(start, custom, today, to, today)
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// Between returnează aniversările din intervalul [from, to], sortate după dată
+func Between(start time.Time, custom []CustomDate, from, to, today time.Time) []Milestone {
+	result := []Milestone{}
+	add := func(m Milestone) {
+		if m.Date.Before(from) || m.Date.After(to) {
+			return
+		}
+		m.DaysUntil = DaysBetween(today, m.Date)
+		result = append(result, m)
+	}
+
+	// Praguri de zile
+	for _, n := range dayThresholds(DaysBetween(start, to)) {
+		add(Milestone{
+			Key:   fmt.Sprintf("days:%d", n),
+			Kind:  KindDays,
+			Title: countLabel(n, "zi", "zile") + " împreună",
+			Date:  start.AddDate(0, 0, n),
+			Value: n,
+		})
+	}
+
+	// Aniversări lunare și anuale
+	months := monthsBetween(start, to)
+	for k := monthsBetween(start, from); k <= months; k++ {
+		if k <= 0 {
+			continue
+		}
+		date := addMonthsClamped(start, k)
+		if k%12 == 0 {
+			add(Milestone{
+				Key:   fmt.Sprintf("yearly:%d", k/12),
+				Kind:  KindYearly,
+				Title: countLabel(k/12, "an", "ani") + " împreună",
+				Date:  date,
+				Value: k / 12,
+			})
+			continue
+		}
+		add(Milestone{
+			Key:   fmt.Sprintf("monthly:%d", k),
+			Kind:  KindMonthly,
+			Title: countLabel(k, "lună", "luni") + " împreună",
+			Date:  date,
+			Value: k,
+		})
+	}
+
+	// Date definite de utilizatori
+	for _, c := range custom {
+		if !c.Recurring {
+			add(customMilestone(c, c.Date, 0))
+			continue
+		}
+		for year := from.Year(); year <= to.Year(); year++ {
+			years := year - c.Date.Year()
+			if years < 0 {
+				continue
+			}
+			add(customMilestone(c, addMonthsClamped(c.Date, years*12), years))
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Date.Before(result[j].Date)
+	})
+	return result
+}
+
+func customMilestone(c CustomDate, date time.Time, years int) Milestone {
+	return Milestone{
+		Key:   fmt.Sprintf("custom:%d:%s", c.ID, date.Format("2006-01-02")),
+		Kind:  KindCustom,
+		Title: c.Title,
+		Date:  date,
+		Value: years,
+	}
+}
+
+// dayThresholds returnează pragurile de zile până la maxDays inclusiv
+func dayThresholds(maxDays int) []int {
+	var result []int
+	last := 0
+	for _, n := range DayMilestones {
+		if n > maxDays {
+			return result
+		}
+		result = append(result, n)
+		last = n
+	}
+	for n := (last/1000 + 1) * 1000; n <= maxDays; n += 1000 {
+		result = append(result, n)
+	}
+	return result
+}
+
+// monthsBetween returnează numărul de luni întregi scurse de la start până la date
+func monthsBetween(start, date time.Time) int {
+	months := (date.Year()-start.Year())*12 + int(date.Month()) - int(start.Month())
+	if months > 0 && addMonthsClamped(start, months).After(date) {
+		months--
+	}
+	return months
+}
+
+// addMonthsClamped adaugă luni păstrând ziua, limitată la ultima zi a lunii (31 ian + 1 lună = 28/29 feb)
+func addMonthsClamped(date time.Time, months int) time.Time {
+	first := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	d := date.Day()
+	if d > lastDay {
+		d = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), d, 0, 0, 0, 0, time.UTC)
+}
+
+// countLabel formează numerale în limba română („1 lună”, „5 luni”, „100 de zile”)
+func countLabel(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	if rest := n % 100; rest >= 20 || (rest == 0 && n >= 100) {
+		return fmt.Sprintf("%d de %s", n, plural)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,179 @@
+package milestones
+
+import (
+	"testing"
+	"time"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		start, date time.Time
+		want        int
+	}{
+		{date(2024, 1, 15), date(2024, 1, 15), 0},
+		{date(2024, 1, 15), date(2024, 2, 14), 0},
+		{date(2024, 1, 15), date(2024, 2, 15), 1},
+		{date(2024, 1, 15), date(2025, 1, 15), 12},
+		{date(2024, 1, 15), date(2023, 12, 1), -1},
+		// 31 ianuarie: aniversarea din februarie cade în ultima zi a lunii
+		{date(2024, 1, 31), date(2024, 2, 28), 0},
+		{date(2024, 1, 31), date(2024, 2, 29), 1},
+		{date(2023, 1, 31), date(2023, 2, 28), 1},
+		{date(2024, 1, 31), date(2024, 3, 30), 1},
+		{date(2024, 1, 31), date(2024, 3, 31), 2},
+		// 29 februarie: în anii fără 29 februarie, aniversarea anuală este pe 28
+		{date(2024, 2, 29), date(2025, 2, 28), 12},
+		{date(2024, 2, 29), date(2025, 2, 27), 11},
+	}
+
+	for _, tt := range tests {
+		if got := monthsBetween(tt.start, tt.date); got != tt.want {
+			t.Errorf("monthsBetween(%s, %s) = %d, vrem %d", tt.start.Format("2006-01-02"), tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestAddMonthsClamped(t *testing.T) {
+	tests := []struct {
+		start  time.Time
+		months int
+		want   time.Time
+	}{
+		{date(2024, 1, 31), 1, date(2024, 2, 29)},
+		{date(2023, 1, 31), 1, date(2023, 2, 28)},
+		{date(2024, 1, 31), 2, date(2024, 3, 31)},
+		{date(2024, 3, 31), 1, date(2024, 4, 30)},
+		{date(2024, 10, 31), 4, date(2025, 2, 28)},
+		{date(2024, 2, 29), 12, date(2025, 2, 28)},
+		{date(2024, 2, 29), 48, date(2028, 2, 29)},
+		{date(2024, 5, 15), 0, date(2024, 5, 15)},
+	}
+
+	for _, tt := range tests {
+		if got := addMonthsClamped(tt.start, tt.months); !got.Equal(tt.want) {
+			t.Errorf("addMonthsClamped(%s, %d) = %s, vrem %s", tt.start.Format("2006-01-02"), tt.months, got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestCountLabel(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{1, "1 zi"},
+		{2, "2 zile"},
+		{19, "19 zile"},
+		{20, "20 de zile"},
+		{100, "100 de zile"},
+		{101, "101 zile"},
+		{119, "119 zile"},
+		{120, "120 de zile"},
+		{365, "365 de zile"},
+		{1000, "1000 de zile"},
+		{1019, "1019 zile"},
+	}
+
+	for _, tt := range tests {
+		if got := countLabel(tt.n, "zi", "zile"); got != tt.want {
+			t.Errorf("countLabel(%d) = %q, vrem %q", tt.n, got, tt.want)
+		}
+	}
+	if got := countLabel(1, "lună", "luni"); got != "1 lună" {
+		t.Errorf("countLabel(1, lună) = %q", got)
+	}
+}
+
+func TestOn(t *testing.T) {
+	tests := []struct {
+		start, today time.Time
+		key, title   string
+	}{
+		{date(2024, 1, 31), date(2024, 2, 29), "monthly:1", "1 lună împreună"},
+		{date(2023, 1, 31), date(2023, 2, 28), "monthly:1", "1 lună împreună"},
+		{date(2024, 1, 31), date(2024, 3, 31), "monthly:2", "2 luni împreună"},
+		{date(2024, 1, 31), date(2025, 1, 31), "yearly:1", "1 an împreună"},
+		{date(2024, 2, 29), date(2026, 2, 28), "yearly:2", "2 ani împreună"},
+		{date(2024, 1, 1), date(2024, 4, 10), "days:100", "100 de zile împreună"},
+		{date(2020, 1, 1), date(2025, 6, 23), "days:2000", "2000 de zile împreună"},
+	}
+
+	for _, tt := range tests {
+		got := On(tt.start, nil, tt.today)
+		if len(got) != 1 || got[0].Key != tt.key || got[0].Title != tt.title || got[0].DaysUntil != 0 {
+			t.Errorf("On(%s, %s) = %+v, vrem %s %q", tt.start.Format("2006-01-02"), tt.today.Format("2006-01-02"), got, tt.key, tt.title)
+		}
+	}
+
+	// Ziua de început și o zi oarecare nu sunt aniversări
+	if got := On(date(2024, 1, 31), nil, date(2024, 1, 31)); len(got) != 0 {
+		t.Errorf("ziua de început: %+v", got)
+	}
+	if got := On(date(2024, 1, 31), nil, date(2024, 2, 28)); len(got) != 0 {
+		t.Errorf("28 februarie 2024: %+v", got)
+	}
+}
+
+func TestCustomDates(t *testing.T) {
+	custom := []CustomDate{
+		{ID: 7, Title: "Prima întâlnire", Date: date(2020, 2, 29), Recurring: true},
+		{ID: 8, Title: "Mutarea", Date: date(2022, 9, 1)},
+		{ID: 9, Title: "Nunta", Date: date(2030, 6, 1), Recurring: true},
+	}
+
+	var got []Milestone
+	for _, m := range Between(date(2019, 1, 1), custom, date(2021, 1, 1), date(2024, 12, 31), date(2021, 1, 1)) {
+		if m.Kind == KindCustom {
+			got = append(got, m)
+		}
+	}
+
+	want := []struct {
+		key   string
+		value int
+	}{
+		{"custom:7:2021-02-28", 1},
+		{"custom:7:2022-02-28", 2},
+		{"custom:8:2022-09-01", 0},
+		{"custom:7:2023-02-28", 3},
+		{"custom:7:2024-02-29", 4},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("date speciale = %+v", got)
+	}
+	for i, w := range want {
+		if got[i].Key != w.key || got[i].Value != w.value {
+			t.Errorf("data %d = %s (%d), vrem %s (%d)", i, got[i].Key, got[i].Value, w.key, w.value)
+		}
+	}
+	if got[0].DaysUntil != 58 {
+		t.Errorf("zile până la prima aniversare = %d", got[0].DaysUntil)
+	}
+}
+
+func TestUpcomingAndDate(t *testing.T) {
+	got := Upcoming(date(2024, 1, 31), nil, date(2024, 2, 1), 3)
+	if len(got) != 3 || got[0].Key != "monthly:1" || got[1].Key != "monthly:2" || got[2].Key != "monthly:3" {
+		t.Fatalf("Upcoming = %+v", got)
+	}
+	if got[0].DaysUntil != 28 || !got[2].Date.Equal(date(2024, 4, 30)) {
+		t.Errorf("Upcoming = %+v", got)
+	}
+	if len(Upcoming(date(2024, 1, 31), nil, date(2024, 2, 1), 0)) != 0 {
+		t.Errorf("Upcoming cu n = 0 trebuie să fie gol")
+	}
+
+	// Ziua calendaristică depinde de fusul orar
+	loc := time.FixedZone("EET", 2*60*60)
+	moment := time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC)
+	if d := Date(moment, loc); !d.Equal(date(2024, 3, 11)) {
+		t.Errorf("Date(EET) = %s", d)
+	}
+	if d := Date(moment, nil); !d.Equal(date(2024, 3, 10)) {
+		t.Errorf("Date(nil) = %s", d)
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// RelationshipEvent reprezintă un eveniment din calendarul relației
+type RelationshipEvent struct {
+	ID             uint      `json:"id"`
+	RelationshipID uint      `json:"relationshipId"`
+	CreatedBy      *uint     `json:"createdBy"`
+	Title          string    `json:"title"`
+	Description    *string   `json:"description"`
+	Location       *string   `json:"location"`
+	StartsAt       time.Time `json:"startsAt"`
+	EndsAt         time.Time `json:"endsAt"` // Exclusiv
+	AllDay         bool      `json:"allDay"`
+	Timezone       string    `json:"timezone"`
+	Recurrence     *string   `json:"recurrence"` // Regula RRULE normalizată
+	Reminders      []int     `json:"reminders"`  // Minute înainte de început
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// EventOccurrence este o apariție a unui eveniment (recurent sau nu) într-un interval
+type EventOccurrence struct {
+	EventID  uint      `json:"eventId"`
+	Title    string    `json:"title"`
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+	AllDay   bool      `json:"allDay"`
+}
@@ -12,10 +12,46 @@ type CurvePosition struct {
 	UpdatedAt      time.Time `json:"updatedAt"`
 }
 
+// Vizibilitatea notițelor atașate unei actualizări de poziție
+const (
+	VisibilityShared  = "shared"
+	VisibilityPrivate = "private"
+)
+
+// PositionEvent reprezintă o actualizare din istoricul pozițiilor, cu notița opțională
+type PositionEvent struct {
+	ID             uint      `json:"id"`
+	RelationshipID uint      `json:"relationshipId"`
+	UserID         uint      `json:"userId"`
+	Position       int       `json:"position"`
+	Note           *string   `json:"note,omitempty"`
+	Mood           *string   `json:"mood,omitempty"`
+	Emoji          *string   `json:"emoji,omitempty"`
+	Visibility     string    `json:"visibility"`
+	CreatedAt      time.Time `json:"createdAt"`
+
+	// Anularea: actualizarea a fost anulată la RevertedAt, sau este ea însăși anularea actualizării RevertsEventID
+	RevertedAt     *time.Time `json:"revertedAt,omitempty"`
+	RevertsEventID *uint      `json:"revertsEventId,omitempty"`
+
+	// Momentul importului, pentru pozițiile importate dintr-un fișier
+	ImportedAt *time.Time `json:"importedAt,omitempty"`
+}
+
+// IsVisibleTo verifică dacă notița evenimentului poate fi văzută de utilizatorul dat
+func (e *PositionEvent) IsVisibleTo(userID uint) bool {
+	return e.UserID == userID || e.Visibility == VisibilityShared
+}
+
 // PositionUpdate reprezintă o actualizare de poziție trimisă prin WebSocket
 type PositionUpdate struct {
-	RelationshipID uint `json:"relationshipId"`
-	UserID         uint `json:"userId"`
-	PartnerID      uint `json:"partnerId"`
-	Position       int  `json:"position"`
+	RelationshipID uint           `json:"relationshipId"`
+	UserID         uint           `json:"userId"`
+	RecipientIDs   []uint         `json:"-"` // Membrii relației care primesc actualizarea
+	Position       int            `json:"position"`
+	Note           *string        `json:"note,omitempty"` // Doar pentru notițele partajate
+	Mood           *string        `json:"mood,omitempty"`
+	Emoji          *string        `json:"emoji,omitempty"`
+	Axes           map[string]int `json:"axes,omitempty"` // Valorile pe dimensiuni, dacă au fost trimise
+	Paused         bool           `json:"paused"`         // Poziția nu este trimisă partenerului
 }
\ No newline at end of file
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// DigestSettings reprezintă abonarea unui utilizator la rezumatul săptămânal, așa cum apare în API
+type DigestSettings struct {
+	Enabled    bool       `json:"enabled"`
+	SendTime   string     `json:"sendTime"` // Ora de trimitere de luni (HH:MM), în fusul orar al utilizatorului
+	LastSentAt *time.Time `json:"lastSentAt"`
+}
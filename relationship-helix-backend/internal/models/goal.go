@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+// Stările unui obiectiv sau ale unei sarcini
+const (
+	GoalStatusOpen       = "open"
+	GoalStatusInProgress = "in_progress"
+	GoalStatusDone       = "done"
+)
+
+// IsGoalStatus verifică dacă starea este validă pentru un obiectiv sau o sarcină
+func IsGoalStatus(status string) bool {
+	return status == GoalStatusOpen || status == GoalStatusInProgress || status == GoalStatusDone
+}
+
+// Goal reprezintă un obiectiv comun al relației, cu lista lui de sarcini
+type Goal struct {
+	ID             uint         `json:"id"`
+	RelationshipID uint         `json:"relationshipId"`
+	CreatedBy      *uint        `json:"createdBy"`
+	Title          string       `json:"title"`
+	Description    *string      `json:"description"`
+	DueDate        *string      `json:"dueDate"` // YYYY-MM-DD
+	AssigneeID     *uint        `json:"assigneeId"`
+	Status         string       `json:"status"`
+	CompletedBy    *uint        `json:"completedBy"`
+	CompletedAt    *time.Time   `json:"completedAt"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt"`
+	Tasks          []GoalTask   `json:"tasks"`
+	Progress       GoalProgress `json:"progress"`
+}
+
+// GoalTask reprezintă o sarcină din lista unui obiectiv
+type GoalTask struct {
+	ID          uint       `json:"id"`
+	GoalID      uint       `json:"goalId"`
+	CreatedBy   *uint      `json:"createdBy"`
+	Title       string     `json:"title"`
+	DueDate     *string    `json:"dueDate"` // YYYY-MM-DD
+	AssigneeID  *uint      `json:"assigneeId"`
+	Status      string     `json:"status"`
+	CompletedBy *uint      `json:"completedBy"`
+	CompletedAt *time.Time `json:"completedAt"`
+	SortOrder   int        `json:"sortOrder"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// GoalProgress este progresul unui obiectiv: sarcinile bifate sau, fără sarcini, starea obiectivului
+type GoalProgress struct {
+	TasksTotal int `json:"tasksTotal"`
+	TasksDone  int `json:"tasksDone"`
+	Percent    int `json:"percent"`
+}
+
+// GoalsSummary rezumă obiectivele relației pentru tabloul de bord
+type GoalsSummary struct {
+	Total        int `json:"total"`
+	Open         int `json:"open"`
+	InProgress   int `json:"inProgress"`
+	Done         int `json:"done"`
+	Overdue      int `json:"overdue"`      // Obiective și sarcini neterminate, cu termenul depășit
+	AssignedOpen int `json:"assignedOpen"` // Obiective și sarcini neterminate atribuite utilizatorului
+	TasksTotal   int `json:"tasksTotal"`
+	TasksDone    int `json:"tasksDone"`
+	Percent      int `json:"percent"` // Procentul obiectivelor terminate
+}
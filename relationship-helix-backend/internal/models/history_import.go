@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"relationship-helix/internal/history"
+)
+
+// HistoryImportResult descrie rezultatul (sau, în modul dry-run, efectul) importului unui istoric de poziții
+type HistoryImportResult struct {
+	DryRun   bool               `json:"dryRun"`
+	Rows     int                `json:"rows"`     // Numărul de rânduri din fișier
+	Imported int                `json:"imported"` // Pozițiile importate; în modul dry-run, cele care ar fi importate
+	From     *time.Time         `json:"from"`     // Intervalul acoperit de pozițiile importate
+	To       *time.Time         `json:"to"`
+	Skipped  []history.Skipped  `json:"skipped"`
+	Errors   []history.RowError `json:"errors"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// Acțiunile care produc o versiune anterioară a unei intrări din jurnal
+const (
+	JournalActionEdited  = "edited"
+	JournalActionDeleted = "deleted"
+)
+
+// JournalEntry reprezintă o intrare din jurnalul comun al relației
+type JournalEntry struct {
+	ID              uint                 `json:"id"`
+	RelationshipID  uint                 `json:"relationshipId"`
+	UserID          uint                 `json:"userId"`
+	Body            *string              `json:"body"` // nil pentru intrările șterse
+	PositionEventID *uint                `json:"positionEventId"`
+	PositionEvent   *JournalPositionLink `json:"positionEvent,omitempty"`
+	Reactions       []JournalReaction    `json:"reactions"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	UpdatedAt       time.Time            `json:"updatedAt"`
+	EditedAt        *time.Time           `json:"editedAt"`
+	DeletedAt       *time.Time           `json:"deletedAt"`
+}
+
+// JournalPositionLink este actualizarea de poziție la care se referă o intrare
+type JournalPositionLink struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"userId"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// JournalReaction reprezintă reacția unui membru la o intrare
+type JournalReaction struct {
+	UserID    uint      `json:"userId"`
+	Emoji     string    `json:"emoji"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// JournalRevision reprezintă o versiune anterioară a unei intrări
+type JournalRevision struct {
+	ID              uint      `json:"id"`
+	Action          string    `json:"action"`
+	Body            string    `json:"body"`
+	PositionEventID *uint     `json:"positionEventId"`
+	CreatedAt       time.Time `json:"createdAt"` // Momentul în care versiunea a fost înlocuită
+}
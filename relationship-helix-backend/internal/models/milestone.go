@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// RelationshipDate reprezintă o dată specială definită de parteneri (ex. prima întâlnire)
+type RelationshipDate struct {
+	ID             uint      `json:"id"`
+	RelationshipID uint      `json:"relationshipId"`
+	CreatedBy      uint      `json:"createdBy"`
+	Title          string    `json:"title"`
+	Date           time.Time `json:"date"`
+	Recurring      bool      `json:"recurring"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Tipurile de gesturi pe care un membru le poate trimite altuia
+const (
+	NudgeTypeHug           = "hug"
+	NudgeTypeThinkingOfYou = "thinking_of_you"
+	NudgeTypeNeedToTalk    = "need_to_talk"
+	NudgeTypeMissYou       = "miss_you"
+	NudgeTypeProudOfYou    = "proud_of_you"
+	NudgeTypeGoodMorning   = "good_morning"
+	NudgeTypeGoodNight     = "good_night"
+)
+
+// NudgeTypes conține tipurile de gesturi acceptate, în ordinea afișării
+var NudgeTypes = []string{
+	NudgeTypeHug,
+	NudgeTypeThinkingOfYou,
+	NudgeTypeNeedToTalk,
+	NudgeTypeMissYou,
+	NudgeTypeProudOfYou,
+	NudgeTypeGoodMorning,
+	NudgeTypeGoodNight,
+}
+
+// IsNudgeType verifică dacă tipul de gest este acceptat
+func IsNudgeType(nudgeType string) bool {
+	for _, t := range NudgeTypes {
+		if t == nudgeType {
+			return true
+		}
+	}
+	return false
+}
+
+// Nudge reprezintă un gest trimis de un membru altui membru al relației
+type Nudge struct {
+	ID             uint       `json:"id"`
+	RelationshipID uint       `json:"relationshipId"`
+	SenderID       uint       `json:"senderId"`
+	RecipientID    uint       `json:"recipientId"`
+	Type           string     `json:"type"`
+	ReadAt         *time.Time `json:"readAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
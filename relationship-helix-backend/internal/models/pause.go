@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// PositionPause reprezintă o pauză de intimitate: poziția utilizatorului nu este arătată partenerului
+type PositionPause struct {
+	ID             uint       `json:"id"`
+	RelationshipID uint       `json:"relationshipId"`
+	UserID         uint       `json:"userId"`
+	StartedAt      time.Time  `json:"startedAt"`
+	EndsAt         *time.Time `json:"endsAt"` // nil = până la reluarea manuală
+	EndedAt        *time.Time `json:"endedAt,omitempty"`
+}
+
+// Starea poziției unui partener, așa cum o vede celălalt
+const (
+	PositionStatusActive = "active"
+	PositionStatusPaused = "paused"
+)
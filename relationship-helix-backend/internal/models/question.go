@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// DailyQuestion reprezintă întrebarea zilei unei relații, cu răspunsurile membrilor.
+// Răspunsurile sunt vizibile doar după ce au răspuns toți membrii (RevealedAt setat);
+// până atunci, Answers conține doar răspunsul utilizatorului curent.
+type DailyQuestion struct {
+	ID             uint                  `json:"id"`
+	RelationshipID uint                  `json:"relationshipId"`
+	Date           string                `json:"date"` // YYYY-MM-DD
+	QuestionID     string                `json:"questionId"`
+	Category       string                `json:"category"`
+	Text           string                `json:"text"`
+	Language       string                `json:"language"`
+	Revealed       bool                  `json:"revealed"`
+	RevealedAt     *time.Time            `json:"revealedAt"`
+	AnsweredBy     []uint                `json:"answeredBy"`
+	Answers        []DailyQuestionAnswer `json:"answers"`
+}
+
+// DailyQuestionAnswer reprezintă răspunsul unui membru la întrebarea zilei
+type DailyQuestionAnswer struct {
+	UserID    uint      `json:"userId"`
+	Answer    string    `json:"answer"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
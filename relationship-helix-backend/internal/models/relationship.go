@@ -2,58 +2,186 @@ package models
 
 import (
 	"time"
+
+	"relationship-helix/internal/milestones"
+)
+
+// Tipurile de relații
+const (
+	RelationshipKindCouple = "couple" // Exact doi membri
+	RelationshipKindGroup  = "group"  // Poliamor, familie, grup de prieteni
+)
+
+// Categoriile de relații, fiecare cu propria limită per utilizator
+const (
+	RelationshipTypeRomantic   = "romantic"
+	RelationshipTypeFriendship = "friendship"
+	RelationshipTypeFamily     = "family"
+	RelationshipTypeCustom     = "custom" // Cu o etichetă aleasă de utilizator
+)
+
+// RelationshipTypes conține categoriile de relații acceptate
+var RelationshipTypes = []string{
+	RelationshipTypeRomantic,
+	RelationshipTypeFriendship,
+	RelationshipTypeFamily,
+	RelationshipTypeCustom,
+}
+
+// IsRelationshipType verifică dacă t este o categorie de relație acceptată
+func IsRelationshipType(t string) bool {
+	for _, relationshipType := range RelationshipTypes {
+		if relationshipType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Rolurile membrilor unei relații
+const (
+	MemberRoleOwner  = "owner"
+	MemberRoleMember = "member"
 )
 
-// Relationship reprezintă o relație între doi utilizatori
+// Relationship reprezintă o relație între doi sau mai mulți utilizatori
 type Relationship struct {
 	ID              uint      `json:"id"`
-	User1ID         uint      `json:"user1Id"`
-	User2ID         uint      `json:"user2Id"`
-	User1Name       string    `json:"user1Name"`
-	User2Name       string    `json:"user2Name"`
+	Kind            string    `json:"kind"`
+	Name            *string   `json:"name"`
+	Type            string    `json:"type"`
+	TypeLabel       *string   `json:"typeLabel"` // Doar pentru categoria custom
 	StartDate       time.Time `json:"startDate"`
 	CreatedAt       time.Time `json:"createdAt"`
 	UpdatedAt       time.Time `json:"updatedAt"`
+
+	// Modul „dezvăluire simultană”: pozițiile sunt sigilate până când toți membrii răspund
+	RevealTogether      bool `json:"revealTogether"`
+	RevealWindowMinutes int  `json:"revealWindowMinutes"`
+
+	// Politica de estompare a pozițiilor neactualizate
+	DecayMode      string `json:"decayMode"`
+	DecayAfterDays int    `json:"decayAfterDays"`
+	DecayRate      int    `json:"decayRate"`
+
+	// Membrii relației, în ordinea intrării
+	Members []Member `json:"members"`
+}
+
+// Member reprezintă un membru al unei relații
+type Member struct {
+	UserID   uint      `json:"userId"`
+	Name     string    `json:"name"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
 }
 
 // RelationshipResponse este structura returnată în API
 type RelationshipResponse struct {
 	ID              uint      `json:"id"`
-	PartnerID       uint      `json:"partnerId"`
-	PartnerName     string    `json:"partnerName"`
+	Kind            string    `json:"kind"`
+	Name            *string   `json:"name,omitempty"`
+	Type            string    `json:"type"`
+	TypeLabel       *string   `json:"typeLabel,omitempty"`
+	PartnerID       uint      `json:"partnerId"`   // Doar pentru cupluri
+	PartnerName     string    `json:"partnerName"` // Doar pentru cupluri
+	Members         []Member  `json:"members"`
 	StartDate       time.Time `json:"startDate"`
 	DaysSinceStart  int       `json:"daysSinceStart"`
 }
 
-// ToResponse convertește un Relationship într-un RelationshipResponse pentru utilizatorul specificat
-func (r *Relationship) ToResponse(userID uint) RelationshipResponse {
+// ToResponse convertește un Relationship într-un RelationshipResponse pentru utilizatorul specificat.
+// Zilele se numără calendaristic, în fusul orar loc al utilizatorului.
+func (r *Relationship) ToResponse(userID uint, loc *time.Location) RelationshipResponse {
 	var partnerID uint
 	var partnerName string
 	
-	if r.User1ID == userID {
-		partnerID = r.User2ID
-		partnerName = r.User2Name
-	} else {
-		partnerID = r.User1ID
-		partnerName = r.User1Name
+	if r.IsCouple() {
+		for _, m := range r.Members {
+			if m.UserID != userID {
+				partnerID = m.UserID
+				partnerName = m.Name
+			}
+		}
 	}
 	
-	daysSinceStart := int(time.Since(r.StartDate).Hours() / 24)
+	daysSinceStart := milestones.DaysBetween(milestones.Date(r.StartDate, loc), milestones.Date(time.Now(), loc))
+	if daysSinceStart < 0 {
+		daysSinceStart = 0
+	}
 	
 	return RelationshipResponse{
 		ID:              r.ID,
+		Kind:            r.Kind,
+		Name:            r.Name,
+		Type:            r.Type,
+		TypeLabel:       r.TypeLabel,
 		PartnerID:       partnerID,
 		PartnerName:     partnerName,
+		Members:         r.Members,
 		StartDate:       r.StartDate,
 		DaysSinceStart:  daysSinceStart,
 	}
 }
 
-// InviteCode reprezintă un cod de invitație pentru a forma o relație
+// IsCouple verifică dacă relația este un cuplu
+func (r *Relationship) IsCouple() bool {
+	return r.Kind == RelationshipKindCouple
+}
+
+// IsMember verifică dacă utilizatorul face parte din relație
+func (r *Relationship) IsMember(userID uint) bool {
+	_, ok := r.Member(userID)
+	return ok
+}
+
+// Member returnează membrul cu ID-ul dat
+func (r *Relationship) Member(userID uint) (Member, bool) {
+	for _, m := range r.Members {
+		if m.UserID == userID {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+// MemberIDs returnează ID-urile tuturor membrilor
+func (r *Relationship) MemberIDs() []uint {
+	ids := make([]uint, len(r.Members))
+	for i, m := range r.Members {
+		ids[i] = m.UserID
+	}
+	return ids
+}
+
+// OtherMemberIDs returnează ID-urile celorlalți membri în afară de utilizatorul specificat
+func (r *Relationship) OtherMemberIDs(userID uint) []uint {
+	var ids []uint
+	for _, m := range r.Members {
+		if m.UserID != userID {
+			ids = append(ids, m.UserID)
+		}
+	}
+	return ids
+}
+
+// PartnerID returnează ID-ul partenerului utilizatorului specificat.
+// Pentru grupuri returnează primul alt membru, sau 0 dacă utilizatorul este singur.
+func (r *Relationship) PartnerID(userID uint) uint {
+	if others := r.OtherMemberIDs(userID); len(others) > 0 {
+		return others[0]
+	}
+	return 0
+}
+
+// InviteCode reprezintă un cod de invitație pentru a forma o relație sau a intra într-un grup
 type InviteCode struct {
-	ID           uint      `json:"id"`
-	UserID       uint      `json:"userId"`
-	Code         string    `json:"code"`
-	ExpiresAt    time.Time `json:"expiresAt"`
-	CreatedAt    time.Time `json:"createdAt"`
-}
\ No newline at end of file
+	ID             uint      `json:"id"`
+	UserID         uint      `json:"userId"`
+	RelationshipID *uint     `json:"relationshipId,omitempty"` // Setat pentru invitațiile într-un grup
+	Type           string    `json:"type"`                     // Categoria relației create de invitație
+	TypeLabel      *string   `json:"typeLabel,omitempty"`
+	Code           string    `json:"code"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// ReminderSettings reprezintă preferințele de memento zilnic ale unui utilizator, așa cum apar în API
+type ReminderSettings struct {
+	Enabled      bool       `json:"enabled"`
+	Time         string     `json:"time"`       // HH:MM, în fusul orar al utilizatorului
+	QuietStart   *string    `json:"quietStart"` // Începutul intervalului de liniște (HH:MM)
+	QuietEnd     *string    `json:"quietEnd"`
+	SnoozedUntil *time.Time `json:"snoozedUntil"`
+	LastSentAt   *time.Time `json:"lastSentAt"`
+}
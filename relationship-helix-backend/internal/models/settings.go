@@ -0,0 +1,7 @@
+package models
+
+// UserSettings reprezintă preferințele unui utilizator
+type UserSettings struct {
+	Timezone          string `json:"timezone"`
+	EffectiveTimezone string `json:"effectiveTimezone"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Stările unui acces partajat
+const (
+	ShareStatusPending = "pending" // Așteaptă aprobarea tuturor membrilor
+	ShareStatusActive  = "active"
+	ShareStatusExpired = "expired"
+	ShareStatusRevoked = "revoked"
+)
+
+// Resursele care pot fi citite printr-un acces partajat
+const (
+	ShareResourceOverview = "overview"
+	ShareResourceHistory  = "history"
+	ShareResourceStats    = "stats"
+	ShareResourceNotes    = "notes"
+)
+
+// ShareGrant reprezintă accesul doar pentru citire al unei terțe persoane (ex. un terapeut) la istoricul,
+// statisticile și notițele partajate ale relației. Accesul este activ doar după aprobarea tuturor membrilor.
+type ShareGrant struct {
+	ID             uint            `json:"id"`
+	RelationshipID uint            `json:"relationshipId"`
+	CreatedBy      *uint           `json:"createdBy"`
+	Label          string          `json:"label"`
+	Status         string          `json:"status"`
+	ExpiresAt      time.Time       `json:"expiresAt"`
+	RevokedAt      *time.Time      `json:"revokedAt"`
+	RevokedBy      *uint           `json:"revokedBy"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Approvals      []ShareApproval `json:"approvals"`
+	LastAccessAt   *time.Time      `json:"lastAccessAt"`
+}
+
+// ShareApproval reprezintă aprobarea unui acces partajat de către un membru
+type ShareApproval struct {
+	UserID     uint      `json:"userId"`
+	ApprovedAt time.Time `json:"approvedAt"`
+}
+
+// ShareAccess reprezintă o accesare a datelor relației printr-un acces partajat
+type ShareAccess struct {
+	ID         uint      `json:"id"`
+	GrantID    uint      `json:"grantId"`
+	Resource   string    `json:"resource"`
+	IPAddress  *string   `json:"ipAddress"`
+	UserAgent  *string   `json:"userAgent"`
+	AccessedAt time.Time `json:"accessedAt"`
+}
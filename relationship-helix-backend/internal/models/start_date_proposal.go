@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Stările posibile ale unei propuneri de modificare a datei de început
+const (
+	ProposalStatusPending   = "pending"
+	ProposalStatusApproved  = "approved"
+	ProposalStatusRejected  = "rejected"
+	ProposalStatusCancelled = "cancelled"
+)
+
+// StartDateProposal reprezintă o propunere de modificare a datei de început a relației
+type StartDateProposal struct {
+	ID             uint       `json:"id"`
+	RelationshipID uint       `json:"relationshipId"`
+	ProposedBy     uint       `json:"proposedBy"`
+	ProposedDate   time.Time  `json:"proposedDate"`
+	PreviousDate   time.Time  `json:"previousDate"`
+	Status         string     `json:"status"`
+	RespondedBy    *uint      `json:"respondedBy,omitempty"`
+	RespondedAt    *time.Time `json:"respondedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
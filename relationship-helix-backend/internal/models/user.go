@@ -7,7 +7,8 @@ type User struct {
 	ID        uint      `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
-	Password  string    `json:"-"` // Nu expune hash-ul parolei în răspunsurile JSON
+	Password  string    `json:"-"`        // Nu expune hash-ul parolei în răspunsurile JSON
+	Timezone  string    `json:"timezone"` // Fus orar IANA (gol = fusul orar implicit)
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
 }
@@ -17,6 +18,7 @@ type UserResponse struct {
 	ID        uint      `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
+	Timezone  string    `json:"timezone,omitempty"`
 	CreatedAt time.Time `json:"createdAt"`
 }
 
@@ -26,6 +28,7 @@ func (u *User) ToResponse() UserResponse {
 		ID:        u.ID,
 		Username:  u.Username,
 		Email:     u.Email,
+		Timezone:  u.Timezone,
 		CreatedAt: u.CreatedAt,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook reprezintă un URL la care sunt trimise evenimentele relațiilor utilizatorului
+type Webhook struct {
+	ID             uint       `json:"id"`
+	UserID         uint       `json:"userId"`
+	RelationshipID *uint      `json:"relationshipId"` // nil = toate relațiile utilizatorului
+	URL            string     `json:"url"`
+	Secret         string     `json:"secret,omitempty"` // Returnat doar la creare
+	Events         []string   `json:"events"`
+	Active         bool       `json:"active"`
+	FailureCount   int        `json:"failureCount"`
+	DisabledAt     *time.Time `json:"disabledAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// WebhookDelivery reprezintă o livrare din jurnalul unui webhook
+type WebhookDelivery struct {
+	ID             uint            `json:"id"`
+	WebhookID      uint            `json:"webhookId"`
+	EventType      string          `json:"eventType"`
+	RelationshipID uint            `json:"relationshipId"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt"`
+	LastStatusCode *int            `json:"lastStatusCode"`
+	LastError      *string         `json:"lastError"`
+	ReplayOf       *uint           `json:"replayOf"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	DeliveredAt    *time.Time      `json:"deliveredAt"`
+}
@@ -0,0 +1,46 @@
+package notify
+
+import (
+	"log"
+
+	"relationship-helix/internal/config"
+)
+
+// FromConfig construiește canalele de notificare din configurație; canalele incomplete sunt ignorate.
+// Canalul push este creat separat (are nevoie de baza de date) și poate lipsi (nil).
+func FromConfig(cfg *config.Config, push Notifier) Notifier {
+	var notifiers Multi
+	for _, name := range cfg.Notifiers {
+		switch name {
+		case "log":
+			notifiers = append(notifiers, Log{})
+		case "email":
+			if cfg.SMTPHost == "" || cfg.SMTPFrom == "" {
+				log.Println("Notificări: SMTP_HOST și SMTP_FROM sunt necesare pentru email")
+				continue
+			}
+			notifiers = append(notifiers, &Email{
+				Host:     cfg.SMTPHost,
+				Port:     cfg.SMTPPort,
+				Username: cfg.SMTPUsername,
+				Password: cfg.SMTPPassword,
+				From:     cfg.SMTPFrom,
+			})
+		case "push":
+			if push == nil {
+				log.Println("Notificări: VAPID_PRIVATE_KEY este necesar pentru push")
+				continue
+			}
+			notifiers = append(notifiers, push)
+		case "webhook":
+			if cfg.NotifyWebhookURL == "" {
+				log.Println("Notificări: NOTIFY_WEBHOOK_URL este necesar pentru webhook")
+				continue
+			}
+			notifiers = append(notifiers, NewWebhook(cfg.NotifyWebhookURL))
+		default:
+			log.Printf("Notificări: canal necunoscut %q\n", name)
+		}
+	}
+	return notifiers
+}
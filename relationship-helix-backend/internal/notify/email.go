@@ -0,0 +1,52 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// Email trimite notificarea prin SMTP
+type Email struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// Send implementează Notifier; utilizatorii fără adresă de email sunt ignorați
+func (e *Email) Send(ctx context.Context, msg Message) error {
+	if msg.Email == "" {
+		return nil
+	}
+
+	body := msg.Body
+	if msg.URL != "" {
+		body += "\r\n\r\n" + msg.URL
+	}
+
+	message := strings.Join([]string{
+		"From: " + e.From,
+		"To: " + msg.Email,
+		"Subject: " + msg.Title,
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		body,
+	}, "\r\n")
+
+	var auth smtp.Auth
+	if e.Username != "" {
+		auth = smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	}
+
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	if err := smtp.SendMail(addr, auth, e.From, []string{msg.Email}, []byte(message)); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Package notify trimite notificări în afara aplicației (email, webhook, ...), prin implementări interschimbabile.
+package notify
+
+import (
+	"context"
+	"errors"
+	"log"
+)
+
+// Message este o notificare adresată unui utilizator
+type Message struct {
+	UserID   uint
+	Email    string
+	Username string
+	Kind     string // Tipul evenimentului (ex. checkin_reminder)
+	Title    string
+	Body     string
+	URL      string
+	Data     map[string]interface{}
+}
+
+// Notifier trimite o notificare pe un anumit canal
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Multi trimite notificarea pe toate canalele; un canal care eșuează nu le oprește pe celelalte
+type Multi []Notifier
+
+// Send implementează Notifier
+func (m Multi) Send(ctx context.Context, msg Message) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Send(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Log scrie notificarea în jurnalul serverului (canalul implicit, util în dezvoltare)
+type Log struct{}
+
+// Send implementează Notifier
+func (Log) Send(ctx context.Context, msg Message) error {
+	log.Printf("Notificare %s pentru utilizatorul %d: %s\n", msg.Kind, msg.UserID, msg.Title)
+	return nil
+}
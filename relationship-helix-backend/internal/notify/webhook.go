@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Webhook trimite notificarea ca JSON, printr-o cerere POST către un URL fix
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhook creează un canal webhook cu un timeout rezonabil
+func NewWebhook(url string) *Webhook {
+	return &Webhook{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send implementează Notifier
+func (w *Webhook) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"type":   msg.Kind,
+		"userId": msg.UserID,
+		"title":  msg.Title,
+		"body":   msg.Body,
+		"url":    msg.URL,
+		"data":   msg.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: răspuns %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package pause gestionează pauzele de intimitate: cât timp o pauză este activă,
+// ceilalți membri văd doar starea „paused”, nu și poziția reală.
+package pause
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"relationship-helix/internal/db"
+	"relationship-helix/internal/models"
+)
+
+// MaxDuration este durata maximă a unei pauze cu termen
+const MaxDuration = 30 * 24 * time.Hour
+
+var (
+	// ErrAmbiguousEnd este returnată când sfârșitul pauzei este dat atât ca moment, cât și ca durată
+	ErrAmbiguousEnd = errors.New("pauză: se acceptă fie momentul de sfârșit, fie durata")
+	// ErrEndOutOfRange este returnată pentru un sfârșit în trecut sau mai târziu de MaxDuration
+	ErrEndOutOfRange = errors.New("pauză: sfârșitul trebuie să fie în următoarele 30 de zile")
+)
+
+// ResolveEnd calculează momentul reluării automate din momentul sau durata cerute (nil = reluare manuală)
+func ResolveEnd(now time.Time, endsAt *time.Time, durationMinutes *int) (*time.Time, error) {
+	if durationMinutes != nil {
+		if endsAt != nil {
+			return nil, ErrAmbiguousEnd
+		}
+		end := now.Add(time.Duration(*durationMinutes) * time.Minute)
+		endsAt = &end
+	}
+
+	if endsAt != nil && (!endsAt.After(now) || endsAt.Sub(now) > MaxDuration) {
+		return nil, ErrEndOutOfRange
+	}
+	return endsAt, nil
+}
+
+// IsActive verifică dacă pauza este în desfășurare la momentul now; o pauză al cărei termen
+// a trecut nu mai este activă, chiar dacă job-ul nu a închis-o încă
+func IsActive(p *models.PositionPause, now time.Time) bool {
+	return p != nil && p.EndedAt == nil && (p.EndsAt == nil || p.EndsAt.After(now))
+}
+
+// Due verifică dacă pauza trebuie închisă automat (termenul ei a trecut), ca în EndDue
+func Due(p *models.PositionPause, now time.Time) bool {
+	return p != nil && p.EndedAt == nil && p.EndsAt != nil && !p.EndsAt.After(now)
+}
+
+// HidesFrom verifică dacă pauza ascunde poziția curentă a membrului față de viewerID;
+// utilizatorul își vede mereu propria poziție
+func HidesFrom(p *models.PositionPause, viewerID uint, now time.Time) bool {
+	return IsActive(p, now) && p.UserID != viewerID
+}
+
+// Active returnează pauza activă a utilizatorului în relație, sau nil dacă nu există.
+// O pauză al cărei termen a trecut nu mai este activă, chiar dacă job-ul nu a închis-o încă.
+func Active(q db.Queryer, relationshipID, userID uint) (*models.PositionPause, error) {
+	var p models.PositionPause
+	var endsAt sql.NullTime
+	err := q.QueryRow(
+		`SELECT id, relationship_id, user_id, started_at, ends_at
+         FROM position_pauses
+         WHERE relationship_id = $1 AND user_id = $2 AND ended_at IS NULL
+           AND (ends_at IS NULL OR ends_at > NOW())`,
+		relationshipID, userID,
+	).Scan(&p.ID, &p.RelationshipID, &p.UserID, &p.StartedAt, &endsAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	return &p, nil
+}
+
+// VisibleCondition returnează condiția SQL care ascunde actualizările făcute de alți membri în timpul
+// unei pauze active. alias este aliasul tabelei position_events, iar viewerParam parametrul cu ID-ul
+// utilizatorului care vede istoricul (propriile actualizări rămân mereu vizibile).
+func VisibleCondition(alias, viewerParam string) string {
+	return `NOT EXISTS (
+             SELECT 1 FROM position_pauses pp
+             WHERE pp.relationship_id = ` + alias + `.relationship_id AND pp.user_id = ` + alias + `.user_id
+               AND pp.user_id <> ` + viewerParam + ` AND pp.ended_at IS NULL
+               AND (pp.ends_at IS NULL OR pp.ends_at > NOW())
+               AND ` + alias + `.created_at >= pp.started_at
+           )`
+}
+
+// End închide pauza deschisă a utilizatorului. Returnează nil dacă nu exista una.
+func End(db *sql.DB, relationshipID, userID uint) (*models.PositionPause, error) {
+	var p models.PositionPause
+	var endsAt sql.NullTime
+	var endedAt time.Time
+	err := db.QueryRow(
+		`UPDATE position_pauses
+         SET ended_at = NOW()
+         WHERE relationship_id = $1 AND user_id = $2 AND ended_at IS NULL
+         RETURNING id, relationship_id, user_id, started_at, ends_at, ended_at`,
+		relationshipID, userID,
+	).Scan(&p.ID, &p.RelationshipID, &p.UserID, &p.StartedAt, &endsAt, &endedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if endsAt.Valid {
+		p.EndsAt = &endsAt.Time
+	}
+	p.EndedAt = &endedAt
+	return &p, nil
+}
+
+// EndDue închide pauzele al căror termen a trecut și le returnează
+func EndDue(db *sql.DB) ([]models.PositionPause, error) {
+	rows, err := db.Query(
+		`UPDATE position_pauses
+         SET ended_at = NOW()
+         WHERE ended_at IS NULL AND ends_at <= NOW()
+         RETURNING id, relationship_id, user_id, started_at, ends_at, ended_at`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.PositionPause
+	for rows.Next() {
+		var p models.PositionPause
+		var endsAt, endedAt time.Time
+		if err := rows.Scan(&p.ID, &p.RelationshipID, &p.UserID, &p.StartedAt, &endsAt, &endedAt); err != nil {
+			return nil, err
+		}
+		p.EndsAt = &endsAt
+		p.EndedAt = &endedAt
+		result = append(result, p)
+	}
+
+	return result, rows.Err()
+}
+
+// EndedPayload construiește evenimentul pause_ended pentru ceilalți membri, cu poziția curentă a utilizatorului
+func EndedPayload(db *sql.DB, p *models.PositionPause) (map[string]interface{}, error) {
+	var position int
+	err := db.QueryRow(
+		`SELECT COALESCE((SELECT position FROM curve_positions WHERE relationship_id = $1 AND user_id = $2), 0)`,
+		p.RelationshipID, p.UserID,
+	).Scan(&position)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"userId":    p.UserID,
+		"partnerId": p.UserID,
+		"position":  position,
+		"endedAt":   p.EndedAt,
+	}, nil
+}
@@ -0,0 +1,117 @@
+package pause
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"relationship-helix/internal/models"
+)
+
+var now = time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)
+
+func at(d time.Duration) *time.Time {
+	t := now.Add(d)
+	return &t
+}
+
+func TestResolveEnd(t *testing.T) {
+	minutes := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		endsAt   *time.Time
+		duration *int
+		want     *time.Time
+		err      error
+	}{
+		{"fără termen: reluare manuală", nil, nil, nil, nil},
+		{"durată", nil, minutes(90), at(90 * time.Minute), nil},
+		{"moment", at(2 * time.Hour), nil, at(2 * time.Hour), nil},
+		{"exact 30 de zile", at(MaxDuration), nil, at(MaxDuration), nil},
+		{"moment și durată", at(time.Hour), minutes(60), nil, ErrAmbiguousEnd},
+		{"moment trecut", at(-time.Minute), nil, nil, ErrEndOutOfRange},
+		{"chiar acum", at(0), nil, nil, ErrEndOutOfRange},
+		{"durată zero", nil, minutes(0), nil, ErrEndOutOfRange},
+		{"durată negativă", nil, minutes(-5), nil, ErrEndOutOfRange},
+		{"peste 30 de zile", nil, minutes(30*24*60 + 1), nil, ErrEndOutOfRange},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveEnd(now, tt.endsAt, tt.duration)
+		if err != tt.err {
+			t.Errorf("%s: err = %v, vrem %v", tt.name, err, tt.err)
+			continue
+		}
+		if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+			t.Errorf("%s: sfârșit = %v, vrem %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAutoResume(t *testing.T) {
+	timed := &models.PositionPause{UserID: 1, StartedAt: now.Add(-time.Hour), EndsAt: at(time.Hour)}
+	manual := &models.PositionPause{UserID: 1, StartedAt: now.Add(-time.Hour)}
+	ended := &models.PositionPause{UserID: 1, StartedAt: now.Add(-time.Hour), EndsAt: at(-time.Minute), EndedAt: at(-time.Minute)}
+
+	tests := []struct {
+		name   string
+		p      *models.PositionPause
+		moment time.Time
+		active bool
+		due    bool
+	}{
+		{"înainte de termen", timed, now, true, false},
+		{"cu o secundă înainte", timed, now.Add(time.Hour - time.Second), true, false},
+		{"exact la termen", timed, now.Add(time.Hour), false, true},
+		{"după termen, înainte de job", timed, now.Add(2 * time.Hour), false, true},
+		{"fără termen", manual, now.Add(365 * 24 * time.Hour), true, false},
+		{"închisă deja", ended, now, false, false},
+		{"fără pauză", nil, now, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsActive(tt.p, tt.moment); got != tt.active {
+			t.Errorf("%s: IsActive = %v, vrem %v", tt.name, got, tt.active)
+		}
+		if got := Due(tt.p, tt.moment); got != tt.due {
+			t.Errorf("%s: Due = %v, vrem %v", tt.name, got, tt.due)
+		}
+	}
+}
+
+func TestHidesFrom(t *testing.T) {
+	p := &models.PositionPause{UserID: 1, StartedAt: now.Add(-time.Hour), EndsAt: at(time.Hour)}
+
+	// Partenerul vede doar starea neutră, autorul își vede mereu poziția
+	if !HidesFrom(p, 2, now) {
+		t.Errorf("poziția trebuie ascunsă partenerului în timpul pauzei")
+	}
+	if HidesFrom(p, 1, now) {
+		t.Errorf("autorul își vede mereu propria poziție")
+	}
+
+	// După termen, partenerul vede din nou poziția, chiar dacă job-ul nu a închis încă pauza
+	if HidesFrom(p, 2, now.Add(time.Hour)) {
+		t.Errorf("poziția nu mai este ascunsă după termen")
+	}
+	if HidesFrom(nil, 2, now) {
+		t.Errorf("fără pauză, poziția este vizibilă")
+	}
+}
+
+func TestVisibleCondition(t *testing.T) {
+	cond := VisibleCondition("e", "$3")
+	for _, part := range []string{
+		"pp.relationship_id = e.relationship_id",
+		"pp.user_id = e.user_id",
+		"pp.user_id <> $3",
+		"pp.ended_at IS NULL",
+		"pp.ends_at > NOW()",
+		"e.created_at >= pp.started_at",
+	} {
+		if !strings.Contains(cond, part) {
+			t.Errorf("condiția nu conține %q:\n%s", part, cond)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package push
+
+import (
+	"database/sql"
+
+	"relationship-helix/internal/db"
+)
+
+// eventText este textul notificării pentru un tip de eveniment
+type eventText struct {
+	title string
+	body  string
+
+	// offline indică evenimentele livrate automat când utilizatorul nu este conectat;
+	// celelalte sunt trimise doar ca notificări explicite (ex. de job-ul de mementouri)
+	offline bool
+}
+
+// texts conține tipurile de evenimente pentru care utilizatorul poate primi notificări push.
+// Textele nu includ poziții sau notițe: acestea sunt doar în datele criptate ale mesajului.
+var texts = map[string]eventText{
+	"position_update":     {"Poziție actualizată", "Un membru al relației și-a actualizat poziția.", true},
+	"position_sealed":     {"Poziție sigilată", "Un membru și-a trimis poziția. Adaug-o și pe a ta pentru dezvăluire.", true},
+	"positions_revealed":  {"Pozițiile au fost dezvăluite", "Deschide aplicația pentru a vedea pozițiile.", true},
+	"start_date_proposed": {"Propunere nouă", "A fost propusă o nouă dată de început a relației.", true},
+	"start_date_approved": {"Propunere acceptată", "Data de început propusă de tine a fost acceptată.", true},
+	"start_date_rejected": {"Propunere respinsă", "Data de început propusă de tine a fost respinsă.", true},
+	"milestone_reached":   {"Zi specială", "Astăzi este o zi specială pentru relația voastră.", true},
+	"pause_started":       {"Pauză", "Un membru și-a ascuns temporar poziția.", true},
+	"pause_ended":         {"Pauza s-a încheiat", "Un membru și-a reluat poziția.", true},
+	"member_joined":       {"Membru nou", "Un membru nou s-a alăturat relației.", true},
+	"member_left":         {"Un membru a plecat", "Un membru a părăsit relația.", true},
+	"nudge":               {"Un gând pentru tine", "Un membru al relației ți-a trimis un semn.", true},
+	"journal_entry":       {"Jurnal", "Jurnalul relației a fost actualizat.", true},
+	"calendar_event":      {"Calendar", "Calendarul relației a fost actualizat.", true},
+	"event_reminder":      {"Eveniment în curând", "Un eveniment din calendarul relației începe în curând.", true},
+	"goal_changed":        {"Obiective", "Obiectivele relației au fost actualizate.", true},
+	"question_answered":   {"Întrebarea zilei", "Un membru a răspuns la întrebarea zilei. Răspunde și tu pentru a vedea răspunsurile.", true},
+	"question_revealed":   {"Răspunsuri dezvăluite", "Toți membrii au răspuns la întrebarea zilei.", true},
+	"share_requested":     {"Cerere de acces", "Un membru vrea să ofere cuiva acces doar pentru citire la relație.", true},
+	"share_approved":      {"Acces aprobat", "Un membru a aprobat accesul partajat la relație.", true},
+	"share_revoked":       {"Acces revocat", "Un acces partajat la relație a fost revocat.", true},
+	"checkin_reminder":    {"Cum vă simțiți azi?", "Nu ți-ai actualizat astăzi poziția.", false},
+}
+
+// EventTypes returnează tipurile de evenimente care pot fi activate sau dezactivate
+func EventTypes() []string {
+	return append([]string(nil), eventOrder...)
+}
+
+// eventOrder păstrează o ordine stabilă a tipurilor de evenimente în API
+var eventOrder = []string{
+	"position_update",
+	"position_sealed",
+	"positions_revealed",
+	"start_date_proposed",
+	"start_date_approved",
+	"start_date_rejected",
+	"milestone_reached",
+	"pause_started",
+	"pause_ended",
+	"member_joined",
+	"member_left",
+	"nudge",
+	"journal_entry",
+	"calendar_event",
+	"event_reminder",
+	"goal_changed",
+	"question_answered",
+	"question_revealed",
+	"share_requested",
+	"share_approved",
+	"share_revoked",
+	"checkin_reminder",
+}
+
+// IsEventType verifică dacă tipul de eveniment poate primi notificări push
+func IsEventType(eventType string) bool {
+	_, ok := texts[eventType]
+	return ok
+}
+
+// Enabled verifică dacă utilizatorul primește notificări push pentru tipul de eveniment (implicit, da)
+func Enabled(q db.Queryer, userID uint, eventType string) (bool, error) {
+	var enabled bool
+	err := q.QueryRow(
+		`SELECT enabled FROM push_preferences WHERE user_id = $1 AND event_type = $2`,
+		userID, eventType,
+	).Scan(&enabled)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	return enabled, err
+}
+
+// Preferences returnează preferințele utilizatorului pentru toate tipurile de evenimente
+func Preferences(q db.Queryer, userID uint) (map[string]bool, error) {
+	preferences := make(map[string]bool, len(eventOrder))
+	for _, eventType := range eventOrder {
+		preferences[eventType] = true
+	}
+
+	rows, err := q.Query(`SELECT event_type, enabled FROM push_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var eventType string
+		var enabled bool
+		if err := rows.Scan(&eventType, &enabled); err != nil {
+			return nil, err
+		}
+		if IsEventType(eventType) {
+			preferences[eventType] = enabled
+		}
+	}
+	return preferences, rows.Err()
+}
@@ -0,0 +1,222 @@
+// Package push livrează evenimentele aplicației prin Web Push, utilizatorilor care nu sunt conectați prin WebSocket.
+package push
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"relationship-helix/internal/config"
+	"relationship-helix/internal/notify"
+	"relationship-helix/internal/webhooks"
+	"relationship-helix/internal/webpush"
+)
+
+// deliveryTimeout limitează durata livrării unui eveniment către toate abonamentele utilizatorului
+const deliveryTimeout = 30 * time.Second
+
+// Service trimite notificările push și întreține abonamentele
+type Service struct {
+	DB          *sql.DB
+	Client      *webpush.Client
+	TTL         time.Duration
+	MaxFailures int
+	AppURL      string
+}
+
+// NewService creează serviciul push; returnează nil dacă cheia VAPID nu este configurată
+func NewService(db *sql.DB, cfg *config.Config) (*Service, error) {
+	if cfg.VAPIDPrivateKey == "" {
+		return nil, nil
+	}
+
+	vapid, err := webpush.NewVAPID(cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	// Endpoint-urile sunt trimise de browser, deci de utilizator: conexiunile către rețeaua internă
+	// sunt refuzate la fel ca pentru webhook-uri
+	client := webpush.NewClient(vapid)
+	client.HTTP = webhooks.NewClient(client.HTTP.Timeout, cfg.PushAllowPrivate).HTTP
+
+	return &Service{
+		DB:          db,
+		Client:      client,
+		TTL:         cfg.PushTTL,
+		MaxFailures: cfg.PushMaxFailures,
+		AppURL:      cfg.AppURL,
+	}, nil
+}
+
+// message este conținutul criptat primit de service worker-ul aplicației
+type message struct {
+	Type           string      `json:"type"`
+	Title          string      `json:"title"`
+	Body           string      `json:"body"`
+	URL            string      `json:"url,omitempty"`
+	RelationshipID uint        `json:"relationshipId,omitempty"`
+	Data           interface{} `json:"data,omitempty"`
+}
+
+// Deliver trimite în fundal evenimentul unui utilizator deconectat de la relație.
+// Evenimentele fără text de notificare și cele dezactivate de utilizator sunt ignorate.
+func (s *Service) Deliver(relationshipID, userID uint, eventType string, payload interface{}) {
+	text, ok := texts[eventType]
+	if !ok || !text.offline {
+		return
+	}
+
+	msg := message{
+		Type:           eventType,
+		Title:          text.title,
+		Body:           text.body,
+		URL:            s.AppURL,
+		RelationshipID: relationshipID,
+		Data:           payload,
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+		defer cancel()
+
+		if err := s.send(ctx, userID, msg); err != nil {
+			log.Printf("Push: Eroare la livrarea %s către utilizatorul %d: %v\n", eventType, userID, err)
+		}
+	}()
+}
+
+// Send implementează notify.Notifier, pentru notificările trimise de job-uri (ex. mementourile zilnice)
+func (s *Service) Send(ctx context.Context, msg notify.Message) error {
+	url := msg.URL
+	if url == "" {
+		url = s.AppURL
+	}
+
+	return s.send(ctx, msg.UserID, message{
+		Type:  msg.Kind,
+		Title: msg.Title,
+		Body:  msg.Body,
+		URL:   url,
+		Data:  msg.Data,
+	})
+}
+
+// send trimite mesajul pe toate abonamentele valide ale utilizatorului, dacă preferințele o permit
+func (s *Service) send(ctx context.Context, userID uint, msg message) error {
+	enabled, err := Enabled(s.DB, userID, msg.Type)
+	if err != nil || !enabled {
+		return err
+	}
+
+	payload, err := encode(msg)
+	if err != nil {
+		return err
+	}
+
+	// Abonamentele expirate nu mai primesc mesaje
+	_, err = s.DB.ExecContext(ctx,
+		`DELETE FROM push_subscriptions WHERE user_id = $1 AND expires_at <= NOW()`,
+		userID,
+	)
+	if err != nil {
+		return err
+	}
+
+	subscriptions, err := s.subscriptions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	opts := webpush.Options{TTL: s.TTL, Urgency: webpush.UrgencyNormal}
+	if msg.Type == "position_update" && msg.RelationshipID != 0 {
+		// O poziție nouă o înlocuiește pe cea încă nelivrată
+		opts.Topic = fmt.Sprintf("position-%d", msg.RelationshipID)
+	}
+
+	var errs []error
+	for _, sub := range subscriptions {
+		sendErr := s.Client.Send(ctx, sub.Subscription, payload, opts)
+		if err := s.record(ctx, sub.ID, sendErr); err != nil {
+			errs = append(errs, err)
+		}
+		if sendErr != nil && !errors.Is(sendErr, webpush.ErrSubscriptionGone) {
+			errs = append(errs, sendErr)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// record actualizează starea abonamentului după o încercare de livrare: abonamentele dispărute
+// sunt șterse imediat, iar cele care eșuează de prea multe ori la rând sunt eliminate
+func (s *Service) record(ctx context.Context, subscriptionID uint, sendErr error) error {
+	var err error
+	switch {
+	case sendErr == nil:
+		_, err = s.DB.ExecContext(ctx,
+			`UPDATE push_subscriptions SET failure_count = 0, last_success_at = NOW() WHERE id = $1`,
+			subscriptionID,
+		)
+	case errors.Is(sendErr, webpush.ErrSubscriptionGone):
+		_, err = s.DB.ExecContext(ctx, `DELETE FROM push_subscriptions WHERE id = $1`, subscriptionID)
+	default:
+		_, err = s.DB.ExecContext(ctx,
+			`UPDATE push_subscriptions SET failure_count = failure_count + 1 WHERE id = $1`,
+			subscriptionID,
+		)
+		if err == nil {
+			_, err = s.DB.ExecContext(ctx,
+				`DELETE FROM push_subscriptions WHERE id = $1 AND failure_count >= $2`,
+				subscriptionID, s.MaxFailures,
+			)
+		}
+	}
+	return err
+}
+
+// storedSubscription este un abonament salvat în baza de date
+type storedSubscription struct {
+	ID uint
+	webpush.Subscription
+}
+
+// subscriptions încarcă abonamentele utilizatorului
+func (s *Service) subscriptions(ctx context.Context, userID uint) ([]storedSubscription, error) {
+	rows, err := s.DB.QueryContext(ctx,
+		`SELECT id, endpoint, p256dh, auth FROM push_subscriptions WHERE user_id = $1`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []storedSubscription
+	for rows.Next() {
+		var sub storedSubscription
+		if err := rows.Scan(&sub.ID, &sub.Endpoint, &sub.P256dh, &sub.Auth); err != nil {
+			return nil, err
+		}
+		result = append(result, sub)
+	}
+	return result, rows.Err()
+}
+
+// encode serializează mesajul; dacă datele evenimentului nu încap, se trimite doar textul
+func encode(msg message) ([]byte, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) <= webpush.MaxPayloadSize {
+		return payload, nil
+	}
+
+	msg.Data = nil
+	return json.Marshal(msg)
+}
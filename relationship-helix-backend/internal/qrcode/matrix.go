@@ -0,0 +1,281 @@
+package qrcode
+
+// matrix conține modulele codului și harta modulelor funcționale (care nu se mascheză)
+type matrix struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+func newMatrix(version int) *matrix {
+	size := version*4 + 17
+	m := &matrix{size: size}
+	m.modules = make([][]bool, size)
+	m.isFunction = make([][]bool, size)
+	for i := range m.modules {
+		m.modules[i] = make([]bool, size)
+		m.isFunction[i] = make([]bool, size)
+	}
+	return m
+}
+
+func (m *matrix) setFunction(x, y int, dark bool) {
+	m.modules[y][x] = dark
+	m.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns desenează modelele de căutare, sincronizare, aliniere și zonele rezervate
+func (m *matrix) drawFunctionPatterns(spec blockSpec) {
+	for i := 0; i < m.size; i++ {
+		m.setFunction(6, i, i%2 == 0)
+		m.setFunction(i, 6, i%2 == 0)
+	}
+
+	m.drawFinder(3, 3)
+	m.drawFinder(m.size-4, 3)
+	m.drawFinder(3, m.size-4)
+
+	last := len(spec.alignments) - 1
+	for i, x := range spec.alignments {
+		for j, y := range spec.alignments {
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			m.drawAlignment(x, y)
+		}
+	}
+
+	// Rezervă zonele de format (se suprascriu după alegerea măștii)
+	m.drawFormatBits(0)
+	m.drawVersion((m.size - 17) / 4)
+}
+
+func (m *matrix) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x, y := cx+dx, cy+dy
+			if x < 0 || y < 0 || x >= m.size || y >= m.size {
+				continue
+			}
+			dist := maxInt(absInt(dx), absInt(dy))
+			m.setFunction(x, y, dist != 2 && dist != 4)
+		}
+	}
+}
+
+func (m *matrix) drawAlignment(cx, cy int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			m.setFunction(cx+dx, cy+dy, maxInt(absInt(dx), absInt(dy)) != 1)
+		}
+	}
+}
+
+// drawFormatBits scrie informația de format (nivel M + mască) în ambele copii
+func (m *matrix) drawFormatBits(mask int) {
+	// Nivelul M are indicatorul 00
+	data := mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>uint(i))&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		m.setFunction(8, i, bit(i))
+	}
+	m.setFunction(8, 7, bit(6))
+	m.setFunction(8, 8, bit(7))
+	m.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		m.setFunction(14-i, 8, bit(i))
+	}
+
+	for i := 0; i < 8; i++ {
+		m.setFunction(m.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		m.setFunction(8, m.size-15+i, bit(i))
+	}
+	m.setFunction(8, m.size-8, true)
+}
+
+// drawVersion scrie informația de versiune (doar pentru versiunile 7+)
+func (m *matrix) drawVersion(version int) {
+	if version < 7 {
+		return
+	}
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := version<<12 | rem
+	for i := 0; i < 18; i++ {
+		dark := (bits>>uint(i))&1 != 0
+		a := m.size - 11 + i%3
+		b := i / 3
+		m.setFunction(a, b, dark)
+		m.setFunction(b, a, dark)
+	}
+}
+
+// drawCodewords plasează datele în zig-zag, de jos în sus, câte două coloane
+func (m *matrix) drawCodewords(data []byte, remainderBits int) {
+	total := len(data)*8 + remainderBits
+	i := 0
+	for right := m.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < m.size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				upward := (right+1)&2 == 0
+				y := vert
+				if upward {
+					y = m.size - 1 - vert
+				}
+				if m.isFunction[y][x] || i >= total {
+					continue
+				}
+				if i < len(data)*8 {
+					m.modules[y][x] = (data[i>>3]>>(7-uint(i&7)))&1 != 0
+				}
+				i++
+			}
+		}
+	}
+}
+
+// applyMask inversează modulele de date conform măștii (aplicată de două ori se anulează)
+func (m *matrix) applyMask(mask int) {
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.isFunction[y][x] {
+				continue
+			}
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert {
+				m.modules[y][x] = !m.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty calculează scorul de penalizare folosit pentru alegerea măștii
+func (m *matrix) penalty() int {
+	score := 0
+	dark := 0
+
+	for y := 0; y < m.size; y++ {
+		score += runPenalty(func(i int) bool { return m.modules[y][i] }, m.size)
+		score += finderLikePenalty(func(i int) bool { return m.modules[y][i] }, m.size)
+	}
+	for x := 0; x < m.size; x++ {
+		score += runPenalty(func(i int) bool { return m.modules[i][x] }, m.size)
+		score += finderLikePenalty(func(i int) bool { return m.modules[i][x] }, m.size)
+	}
+
+	for y := 0; y < m.size; y++ {
+		for x := 0; x < m.size; x++ {
+			if m.modules[y][x] {
+				dark++
+			}
+			if x < m.size-1 && y < m.size-1 {
+				c := m.modules[y][x]
+				if c == m.modules[y][x+1] && c == m.modules[y+1][x] && c == m.modules[y+1][x+1] {
+					score += 3
+				}
+			}
+		}
+	}
+
+	total := m.size * m.size
+	deviation := absInt(dark*20-total*10) / total
+	score += deviation * 10
+
+	return score
+}
+
+// runPenalty penalizează secvențele de 5+ module de aceeași culoare
+func runPenalty(at func(int) bool, size int) int {
+	score := 0
+	run := 1
+	for i := 1; i <= size; i++ {
+		if i < size && at(i) == at(i-1) {
+			run++
+			continue
+		}
+		if run >= 5 {
+			score += 3 + run - 5
+		}
+		run = 1
+	}
+	return score
+}
+
+// finderLikePenalty penalizează modelele 1:1:3:1:1 care seamănă cu cele de căutare
+func finderLikePenalty(at func(int) bool, size int) int {
+	pattern := []bool{true, false, true, true, true, false, true}
+	score := 0
+	for i := 0; i+len(pattern) <= size; i++ {
+		match := true
+		for j, p := range pattern {
+			if at(i+j) != p {
+				match = false
+				break
+			}
+		}
+		if !match {
+			continue
+		}
+		if lightRun(at, size, i-4, i) || lightRun(at, size, i+7, i+11) {
+			score += 40
+		}
+	}
+	return score
+}
+
+// lightRun verifică dacă intervalul [from, to) este deschis la culoare (în afara matricei contează ca deschis)
+func lightRun(at func(int) bool, size, from, to int) bool {
+	for i := from; i < to; i++ {
+		if i >= 0 && i < size && at(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,179 @@
+package qrcode
+
+import (
+	"errors"
+)
+
+// ErrTooLong este returnată când textul nu încape în versiunile suportate
+var ErrTooLong = errors.New("textul este prea lung pentru un cod QR")
+
+// Code reprezintă o matrice QR gata de randare (true = modul închis la culoare)
+type Code struct {
+	Size    int
+	Version int
+	modules [][]bool
+}
+
+// Dark returnează true dacă modulul de pe rândul y și coloana x este închis la culoare
+func (c *Code) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y][x]
+}
+
+// blockSpec descrie structura blocurilor de corecție pentru o versiune (nivel M)
+type blockSpec struct {
+	ecPerBlock    int
+	group1        int
+	group1Data    int
+	group2        int
+	group2Data    int
+	alignments    []int
+	remainderBits int
+}
+
+// Versiunile 1-10 cu nivel de corecție M acoperă link-uri de până la 213 octeți
+var versions = []blockSpec{
+	{},
+	{10, 1, 16, 0, 0, nil, 0},
+	{16, 1, 28, 0, 0, []int{6, 18}, 7},
+	{26, 1, 44, 0, 0, []int{6, 22}, 7},
+	{18, 2, 32, 0, 0, []int{6, 26}, 7},
+	{24, 2, 43, 0, 0, []int{6, 30}, 7},
+	{16, 4, 27, 0, 0, []int{6, 34}, 7},
+	{18, 4, 31, 0, 0, []int{6, 22, 38}, 0},
+	{22, 2, 38, 2, 39, []int{6, 24, 42}, 0},
+	{22, 3, 36, 2, 37, []int{6, 26, 46}, 0},
+	{26, 4, 43, 1, 44, []int{6, 28, 50}, 0},
+}
+
+func (s blockSpec) dataCodewords() int {
+	return s.group1*s.group1Data + s.group2*s.group2Data
+}
+
+// Encode codifică textul în modul byte, cu nivel de corecție M
+func Encode(text string) (*Code, error) {
+	data := []byte(text)
+
+	version := 0
+	for v := 1; v < len(versions); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+len(data)*8 <= versions[v].dataCodewords()*8 {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	spec := versions[version]
+	codewords := interleave(spec, buildDataCodewords(data, version, spec))
+
+	c := newMatrix(version)
+	c.drawFunctionPatterns(spec)
+	c.drawCodewords(codewords, spec.remainderBits)
+
+	// Alege masca cu cea mai mică penalizare
+	bestMask, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		penalty := c.penalty()
+		if bestPenalty < 0 || penalty < bestPenalty {
+			bestMask, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask)
+	}
+	c.applyMask(bestMask)
+	c.drawFormatBits(bestMask)
+
+	return &Code{Size: c.size, Version: version, modules: c.modules}, nil
+}
+
+// buildDataCodewords construiește fluxul de date (mod, lungime, date, terminator, umplutură)
+func buildDataCodewords(data []byte, version int, spec blockSpec) []byte {
+	capacity := spec.dataCodewords() * 8
+	var bits bitBuffer
+
+	bits.append(0x4, 4)
+	if version >= 10 {
+		bits.append(uint32(len(data)), 16)
+	} else {
+		bits.append(uint32(len(data)), 8)
+	}
+	for _, b := range data {
+		bits.append(uint32(b), 8)
+	}
+
+	terminator := capacity - len(bits)
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	if rem := len(bits) % 8; rem != 0 {
+		bits.append(0, 8-rem)
+	}
+	for pad := uint32(0xEC); len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	result := make([]byte, len(bits)/8)
+	for i, bit := range bits {
+		if bit {
+			result[i>>3] |= 1 << (7 - uint(i&7))
+		}
+	}
+	return result
+}
+
+// interleave împarte datele în blocuri, calculează corecția și le întrețese
+func interleave(spec blockSpec, data []byte) []byte {
+	divisor := reedSolomonDivisor(spec.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	addBlocks := func(count, size int) {
+		for i := 0; i < count; i++ {
+			block := data[offset : offset+size]
+			offset += size
+			dataBlocks = append(dataBlocks, block)
+			ecBlocks = append(ecBlocks, reedSolomonRemainder(block, divisor))
+		}
+	}
+	addBlocks(spec.group1, spec.group1Data)
+	addBlocks(spec.group2, spec.group2Data)
+
+	maxData := spec.group1Data
+	if spec.group2Data > maxData {
+		maxData = spec.group2Data
+	}
+
+	var result []byte
+	for i := 0; i < maxData; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < spec.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// bitBuffer este o secvență de biți construită incremental
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value uint32, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>uint(i))&1 != 0)
+	}
+}
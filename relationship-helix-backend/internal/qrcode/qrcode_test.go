@@ -0,0 +1,215 @@
+package qrcode
+
+import (
+	"strings"
+	"testing"
+)
+
+// Matricele de referință au fost generate cu o implementare independentă (github.com/skip2/go-qrcode,
+// nivel de corecție M, fără margine); '#' este un modul închis la culoare
+var knownAnswers = []struct {
+	text    string
+	version int
+	matrix  []string
+}{
+	{
+		text:    "helix.app/j/k7",
+		version: 1,
+		matrix: []string{
+			"#######.##..#.#######",
+			"#.....#.#.#.#.#.....#",
+			"#.###.#.###...#.###.#",
+			"#.###.#..#.##.#.###.#",
+			"#.###.#.#..##.#.###.#",
+			"#.....#....##.#.....#",
+			"#######.#.#.#.#######",
+			".........#.#.........",
+			"#..######.####..#.###",
+			"##.....###.#..##.##..",
+			"..###########.#...###",
+			"#.##...##.#..#.##.#..",
+			"#.....##...#...###.#.",
+			"........##...##.##.#.",
+			"#######.##.###..#.#..",
+			"#.....#.##.#..#..##..",
+			"#.###.#.##......##.#.",
+			"#.###.#.#..#.##..#...",
+			"#.###.#...###..##.###",
+			"#.....#.......#..####",
+			"#######.#.#..###.#...",
+		},
+	},
+	{
+		text:    "https://helix.example/join?code=qmxkpz&sig=a.b_c-d~e.f_g-h~i.j_k-l~m.n_o-p~q.r_s-t~u.v_w-x~y.z_a-b~c.d_e-f~g.h_i-j~k.l_m-n",
+		version: 7,
+		matrix: []string{
+			"#######.##...#.#.#..#..#..#.#.##....#.#######",
+			"#.....#.####...#...#.####..##......#..#.....#",
+			"#.###.#.#.####.##.#.#.##.##..#..##.#..#.###.#",
+			"#.###.#..#.#.#...##....#.####..###.##.#.###.#",
+			"#.###.#.###.#.##...######.##..#...###.#.###.#",
+			"#.....#...#..#......#...#.####.#......#.....#",
+			"#######.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#######",
+			"............#.#.###.#...#...#.#...##.........",
+			"#..######..##...###.######..###..#####..#.###",
+			"#..#.#.#.#..##.#....###..##.#.#..####.##.#.#.",
+			"#.#.###..#.##........####..#.#.#.###.#.#..###",
+			"#...##...#..##..#....##..##.....#.#...#...#..",
+			"#.##..#...#..####.#.#.##.#..##.##.......#..#.",
+			".##.##..##.#.#.##....###..#####.##.##.##.#...",
+			"..#.#.#.#.#.##......##.####....#.#....#..#...",
+			"#.#.#..#....###..######..##..##..#.###.#.##..",
+			"#..#####.###.#..#######...##..#.#.##.....#.#.",
+			"..#.....#..#######.##...##.#..#..##.##..##.#.",
+			".#..###..##..####.....#.#..##..#.#...#...#..#",
+			"#.#.#...#.####..#...##......#.##.#.#.##.####.",
+			"#...#####....#.#.########...#....##.#########",
+			"#...#...#..#.#...#..#...#############...####.",
+			"###.#.#.#...##......#.#.#...#.##...##.#.###.#",
+			"#.###...#..##....#.##...##.#...######...#.##.",
+			"#.#######..#.#...########.#.##..#.#.#####...#",
+			".#.....##.###.#...#...######.#####.###.#..##.",
+			".##...#####.#....####.#..##..#.#.#..#..####..",
+			".##.#..#..###...####.#.########....#.#...##..",
+			".###.###.#.##.#....###.#.##....##..##...##...",
+			"#..##..###.#...##.###..#....####.##...####..#",
+			"#..#.####.##..##..##...#.#.#.#...#..#####..##",
+			".##.##.#...#.######..####..##....#####....#.#",
+			"#.###.##.##..#.#.##...#..##.#.#.....##.#...##",
+			"#.####.###...###...###.#.###.####.###.#.##...",
+			"....#.#...#......##...##.#..##...##.###....##",
+			".####..###..#.#.#####.###.#.....##..###.#.#.#",
+			"#..##.##....#####.########.##..##..#######...",
+			"........##...###.####...#.#####.##.##...####.",
+			"#######.###..#..#.#.#.#.#.###....#.##.#.#.#..",
+			"#.....#.####.########...##....##.#.##...###.#",
+			"#.###.#.####.#......######...#.###..#####..##",
+			"#.###.#.#...#.##...#.##.##.##.##.###.##....##",
+			"#.###.#..####.##..#...#.##.##.#..#..#.#.#.#.#",
+			"#.....#...#.##..###..##.....##...#...###.####",
+			"#######.#####..#...#.#.####.#..#...#.##..#...",
+		},
+	},
+	{
+		text:    "https://helix.example/join?code=qmxkpz&sig=a.b_c-d~e.f_g-h~i.j_k-l~m.n_o-p~q.r_s-t~u.v_w-x~y.z_a-b~c.d_e-f~g.h_i-j~k.l_m-n~o.p_q-r~s.t_u-v~w.x_y-z~a.b_c-d~e.f_g-h~i.j_k-l~m.n_o-p~q.r_s-t~u.v_w-x~y",
+		version: 10,
+		matrix: []string{
+			"#######.##..#.#.#..##.#.#.##.##.#.#...#...#.####..#######",
+			"#.....#.#####.#......##.#.#..#..#.##...##....#.#..#.....#",
+			"#.###.#.#.####.#...##.#.#.##..#...#...##..######..#.###.#",
+			"#.###.#....#.....#######.####.#.#.#.#.#.##.##..#..#.###.#",
+			"#.###.#.##.#.#..#.#.###.#.#######...###..##....#..#.###.#",
+			"#.....#..#..#.#.##..#.##.##...#..#....#.##.##.#...#.....#",
+			"#######.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#.#######",
+			".........##..#.#..#.##.#..#...##.##.....##....#.#........",
+			"#..###########.#.#...##..######..#.##.#.##...###.#..#.###",
+			"#.#.##.####.#####.#..####...####.#.################..##..",
+			"#....##.#..###.##.###.#..####..#.#.#....#..#.##.##..##.##",
+			"..#....#.#...#.........##..##...##.###.##.##.##..###..##.",
+			"#####.#...####.#...#...###.###.###.###..###.#.#.#...#.#..",
+			".#.###....##.##.#......#...#.##....##.#.#####..####..#.#.",
+			"#.#...##.##..##.###......####..##.....##.#.###...###.##..",
+			"###.##.##..###....####.#.#...###.#...#...##..#....#..##.#",
+			".#.####..#.##....###.#.#..#....####.#.....###.##.#.....##",
+			"###.##.###..##.#####.####.#..###.##.##..#.#.###.#..#....#",
+			"##..#.#...#.#####.###.#......#...#...#.##....#.#......#.#",
+			"..#....#..#.##..#...###...#.##.#..###.##...#.#.#.####.##.",
+			"##..####.###.##...#.####..###.....###.#.#.##.#....#.#...#",
+			"#.##...#.......#.##..#.###.###..#.#.#.#..###.####.#.#....",
+			".##########.###..#.###.#.#..########.......##.#....#.##.#",
+			"######.......#....#..#...#.#.....#.#.#......##...##...##.",
+			"#...####.#..###.#..#..#.....#.#..#..#.#.##.###...#..#..##",
+			"#..###.#.###..#..#..#####...####.#...##.######.##.###.#..",
+			"#..######.#.#.#...#.##..#.#####.##.#.##....##...#######..",
+			"..###...#.#.#.#...##.####.#...###.#..........####...#.#..",
+			"#.###.#.##...#..###.###...#.#.###...##.#..#.#...#.#.##.#.",
+			"##.##...#...#.#.##..#.#.###...#.#.#....########.#...#..##",
+			"##.#######.#.#....#.#.##..#####.#.#..#..##.###.######..##",
+			"#...#..##..........#...##..###.#.###....##...####..####..",
+			"####..#.####.#.##....####.#..#.....####.#....#.....#.#...",
+			".#####.#.#..##.###.#.##.#.###....##..##..##..##...#.#.#.#",
+			"...#..##.#####.####...#.##....##.##.....#..#.###.##.##.#.",
+			"##.###.###.......####..##..#....##.#...#.#.##.#..#..#.#..",
+			"####..###....#..#..#.#.#.####..###.###..###.#.###......##",
+			"#....#...##..####..#..####.##.#..#.#..##.###...#..##.#...",
+			"#..#.##...#.#......#####.#.#.##.#.#.#......#.#.#....#....",
+			"....##..#.#.##...##....#...#.##..#...#.#.#...#.#.#.#.##.#",
+			"#..####......##..#######..#.#..##...###..#.######....#.#.",
+			"###..#.##.###...#..######..##.######.#....######..###.###",
+			".###..#.#.###.#.##.#.#.....##.#..#.......#.....#####.##.#",
+			"..####...#.#...###.....#.#..#...##.#...####....###...##..",
+			"##.##.####...#.#.###...#...#..##..###.#.#.#..#....#..#..#",
+			"#..#.#....#.#.#...#.#..##..######.#.###..#######.#####...",
+			"#.#..##.#...####..##..#.###.###.###....##.#......###.#..#",
+			"#####..#.#.......##..##..#.#..#.##.#.##....###....###.##.",
+			"......#.##.##.#........#..#####.##..#.#.#.#.##########..#",
+			"........#####...#.#..#..#.#...##.##...#####..#..#...####.",
+			"#######.##.#...#.#..#.#..##.#.#.###..##..#..##..#.#.##...",
+			"#.....#.#..#.#....#.###...#...##..##.##..##..##.#...#.###",
+			"#.###.#.##..#.##.#..##.##########.#.##.#..#.##########...",
+			"#.###.#.###.###.#.##......#..##.#####..########...###....",
+			"#.###.#......##.#..#####.#.##.##.#.#.#.#.#..#.#.#.#.##.##",
+			"#.....#..#...##.###...##.#.#.###.##.....#.##.###.##.#####",
+			"#######.#####.###########..##......##.#.###...##.##..#...",
+		},
+	},
+}
+
+func TestEncodeKnownAnswers(t *testing.T) {
+	for _, ka := range knownAnswers {
+		code, err := Encode(ka.text)
+		if err != nil {
+			t.Fatalf("versiunea %d: %v", ka.version, err)
+		}
+		if code.Version != ka.version || code.Size != len(ka.matrix) {
+			t.Fatalf("versiunea %d: am obținut versiunea %d, %d module", ka.version, code.Version, code.Size)
+		}
+
+		for y, row := range ka.matrix {
+			var got strings.Builder
+			for x := 0; x < code.Size; x++ {
+				if code.Dark(x, y) {
+					got.WriteByte('#')
+				} else {
+					got.WriteByte('.')
+				}
+			}
+			if got.String() != row {
+				t.Errorf("versiunea %d, rândul %d:\nam obținut %s\nvrem       %s", ka.version, y, got.String(), row)
+			}
+		}
+	}
+}
+
+func TestEncodeVersionBoundaries(t *testing.T) {
+	// Capacitatea în modul byte, nivel M: 14 octeți pentru versiunea 1, 213 pentru versiunea 10
+	for length, want := range map[int]int{1: 1, 14: 1, 15: 2, 122: 7, 123: 8, 180: 9, 181: 10, 213: 10} {
+		code, err := Encode(strings.Repeat("a", length))
+		if err != nil {
+			t.Fatalf("%d octeți: %v", length, err)
+		}
+		if code.Version != want || code.Size != want*4+17 {
+			t.Errorf("%d octeți: versiunea %d (%d module), vrem %d", length, code.Version, code.Size, want)
+		}
+	}
+
+	if _, err := Encode(strings.Repeat("a", 214)); err != ErrTooLong {
+		t.Errorf("214 octeți: err = %v, vrem ErrTooLong", err)
+	}
+}
+
+func TestDarkOutsideMatrix(t *testing.T) {
+	code, err := Encode("helix")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range [][2]int{{-1, 0}, {0, -1}, {code.Size, 0}, {0, code.Size}} {
+		if code.Dark(p[0], p[1]) {
+			t.Errorf("modulul (%d, %d) din afara matricei trebuie să fie deschis", p[0], p[1])
+		}
+	}
+	// Colțul din stânga sus aparține modelului de căutare
+	if !code.Dark(0, 0) {
+		t.Errorf("colțul modelului de căutare trebuie să fie închis la culoare")
+	}
+}
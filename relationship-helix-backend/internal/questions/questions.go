@@ -0,0 +1,134 @@
+// Package questions conține biblioteca de întrebări ale zilei și alegerea întrebării pentru o relație și o dată.
+package questions
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLanguage este limba folosită când limba cerută nu este disponibilă
+const DefaultLanguage = "ro"
+
+// Languages conține limbile în care sunt traduse toate întrebările
+var Languages = []string{"ro", "en"}
+
+// Question este o întrebare din bibliotecă, cu textul în fiecare limbă
+type Question struct {
+	ID       string            `json:"id"`
+	Category string            `json:"category"`
+	Text     map[string]string `json:"text"`
+}
+
+// Localized returnează textul întrebării în limba dată, sau în limba implicită
+func (q Question) Localized(lang string) string {
+	if text, ok := q.Text[lang]; ok {
+		return text
+	}
+	return q.Text[DefaultLanguage]
+}
+
+//go:embed prompts.json
+var promptsJSON []byte
+
+// bank este biblioteca încărcată din prompts.json. Întrebările pot fi adăugate, dar nu șterse sau
+// renumerotate: întrebările deja atribuite sunt păstrate în baza de date după ID.
+var bank = mustParse(promptsJSON)
+
+// Parse citește și validează o bibliotecă de întrebări
+func Parse(data []byte) ([]Question, error) {
+	var list []Question
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, errors.New("questions: biblioteca este goală")
+	}
+
+	seen := make(map[string]bool, len(list))
+	for _, q := range list {
+		if q.ID == "" || seen[q.ID] {
+			return nil, errors.New("questions: ID lipsă sau duplicat: " + q.ID)
+		}
+		seen[q.ID] = true
+		for _, lang := range Languages {
+			if strings.TrimSpace(q.Text[lang]) == "" {
+				return nil, errors.New("questions: întrebarea " + q.ID + " nu are text în limba " + lang)
+			}
+		}
+	}
+	return list, nil
+}
+
+// mustParse încarcă biblioteca inclusă în binar; o bibliotecă invalidă este o eroare de programare
+func mustParse(data []byte) []Question {
+	list, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
+
+// All returnează toate întrebările din bibliotecă
+func All() []Question {
+	return append([]Question(nil), bank...)
+}
+
+// Find caută întrebarea cu ID-ul dat
+func Find(id string) (Question, bool) {
+	for _, q := range bank {
+		if q.ID == id {
+			return q, true
+		}
+	}
+	return Question{}, false
+}
+
+// ForDate alege întrebarea zilei pentru relație și data calendaristică dată. Alegerea este deterministă:
+// fiecare relație parcurge biblioteca într-o ordine proprie, fără repetiții până la epuizarea ei.
+func ForDate(relationshipID uint, date time.Time) Question {
+	return pick(bank, relationshipID, date)
+}
+
+// pick alege întrebarea zilei dintr-o listă
+func pick(list []Question, relationshipID uint, date time.Time) Question {
+	order := make([]Question, len(list))
+	copy(order, list)
+
+	seed := strconv.FormatUint(uint64(relationshipID), 10) + ":"
+	keys := make(map[string][32]byte, len(order))
+	for _, q := range order {
+		keys[q.ID] = sha256.Sum256([]byte(seed + q.ID))
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := keys[order[i].ID], keys[order[j].ID]
+		return string(a[:]) < string(b[:])
+	})
+
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400
+	index := day % int64(len(order))
+	if index < 0 {
+		index += int64(len(order))
+	}
+	return order[index]
+}
+
+// ParseLanguage alege limba dintr-un parametru explicit sau dintr-un antet Accept-Language
+func ParseLanguage(value string) string {
+	for _, part := range strings.Split(value, ",") {
+		tag, _, _ := strings.Cut(strings.TrimSpace(part), ";")
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		for _, lang := range Languages {
+			if base == lang {
+				return lang
+			}
+		}
+	}
+	return DefaultLanguage
+}
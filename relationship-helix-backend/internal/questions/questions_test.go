@@ -0,0 +1,93 @@
+package questions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmbeddedBankIsValid(t *testing.T) {
+	if _, err := Parse(promptsJSON); err != nil {
+		t.Fatalf("prompts.json este invalid: %v", err)
+	}
+	if len(All()) < 30 {
+		t.Fatalf("biblioteca are doar %d întrebări", len(All()))
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, data := range []string{
+		`[]`,
+		`[{"id":"a","text":{"ro":"x"}}]`,
+		`[{"id":"a","text":{"ro":"x","en":"y"}},{"id":"a","text":{"ro":"x","en":"y"}}]`,
+		`[{"id":"","text":{"ro":"x","en":"y"}}]`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Parse(%s) a fost acceptată", data)
+		}
+	}
+}
+
+func TestForDateIsDeterministic(t *testing.T) {
+	date := time.Date(2026, 10, 19, 23, 30, 0, 0, time.UTC)
+	loc := time.FixedZone("UTC+3", 3*3600)
+
+	// Aceeași dată calendaristică dă aceeași întrebare, indiferent de oră sau fus orar
+	a := ForDate(7, date)
+	b := ForDate(7, time.Date(2026, 10, 19, 0, 5, 0, 0, loc))
+	if a.ID != b.ID {
+		t.Fatalf("întrebări diferite pentru aceeași dată: %s, %s", a.ID, b.ID)
+	}
+}
+
+func TestForDateCyclesWithoutRepeats(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := len(All())
+
+	seen := make(map[string]bool)
+	for i := 0; i < n; i++ {
+		q := ForDate(3, start.AddDate(0, 0, i))
+		if seen[q.ID] {
+			t.Fatalf("întrebarea %s s-a repetat după %d zile", q.ID, i)
+		}
+		seen[q.ID] = true
+	}
+	if q := ForDate(3, start.AddDate(0, 0, n)); q.ID != ForDate(3, start).ID {
+		t.Fatalf("ciclul nu reîncepe după %d zile", n)
+	}
+}
+
+func TestForDateDiffersBetweenRelationships(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	same := 0
+	for i := 0; i < 30; i++ {
+		if ForDate(1, start.AddDate(0, 0, i)).ID == ForDate(2, start.AddDate(0, 0, i)).ID {
+			same++
+		}
+	}
+	if same == 30 {
+		t.Fatalf("relațiile diferite primesc aceleași întrebări")
+	}
+}
+
+func TestLocalizedAndParseLanguage(t *testing.T) {
+	q := Question{ID: "x", Text: map[string]string{"ro": "Salut", "en": "Hello"}}
+	if got := q.Localized("en"); got != "Hello" {
+		t.Errorf("Localized(en) = %q", got)
+	}
+	if got := q.Localized("de"); got != "Salut" {
+		t.Errorf("Localized(de) = %q, vrem limba implicită", got)
+	}
+
+	for value, want := range map[string]string{
+		"":                   "ro",
+		"en":                 "en",
+		"en-US,en;q=0.9":     "en",
+		"de-DE,ro;q=0.8":     "ro",
+		"fr-FR, en-GB;q=0.7": "en",
+		"RO-ro":              "ro",
+	} {
+		if got := ParseLanguage(value); got != want {
+			t.Errorf("ParseLanguage(%q) = %q, vrem %q", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Package reminders decide când primește un utilizator mementoul zilnic de actualizare a poziției.
+package reminders
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MinutesPerDay este numărul de minute dintr-o zi
+const MinutesPerDay = 24 * 60
+
+// ErrInvalidClock este returnată pentru o oră care nu are formatul HH:MM
+var ErrInvalidClock = errors.New("ora trebuie să aibă formatul HH:MM")
+
+// Settings sunt preferințele de memento ale unui utilizator; orele sunt minute de la miezul nopții, în fusul orar local
+type Settings struct {
+	Enabled  bool
+	RemindAt int
+
+	// Intervalul de liniște [QuietStart, QuietEnd) poate trece peste miezul nopții
+	QuietStart *int
+	QuietEnd   *int
+
+	SnoozedUntil *time.Time
+	LastSentAt   *time.Time
+}
+
+// ParseClock transformă o oră HH:MM în minute de la miezul nopții
+func ParseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, ErrInvalidClock
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock transformă minutele de la miezul nopții într-o oră HH:MM
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// InQuietHours verifică dacă minutul dat cade în intervalul de liniște
+func (s Settings) InQuietHours(minute int) bool {
+	if s.QuietStart == nil || s.QuietEnd == nil || *s.QuietStart == *s.QuietEnd {
+		return false
+	}
+
+	start, end := *s.QuietStart, *s.QuietEnd
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// Due verifică dacă mementoul trebuie trimis acum. Un memento amânat după trimitere
+// se trimite din nou când expiră amânarea, chiar dacă a fost deja trimis în aceeași zi.
+func (s Settings) Due(now time.Time, loc *time.Location) bool {
+	if !s.Enabled {
+		return false
+	}
+
+	if s.SnoozedUntil != nil && now.Before(*s.SnoozedUntil) {
+		return false
+	}
+
+	local := now.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if minute < s.RemindAt || s.InQuietHours(minute) {
+		return false
+	}
+
+	if s.LastSentAt != nil && SameDay(*s.LastSentAt, now, loc) {
+		return s.SnoozedUntil != nil && s.LastSentAt.Before(*s.SnoozedUntil)
+	}
+
+	return true
+}
+
+// SameDay verifică dacă cele două momente cad în aceeași zi calendaristică, în fusul orar loc
+func SameDay(a, b time.Time, loc *time.Location) bool {
+	ay, am, ad := a.In(loc).Date()
+	by, bm, bd := b.In(loc).Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// DefaultTime este ora implicită a mementoului, folosită când configurația nu este validă
+const DefaultTime = 20 * 60
+
+// ParseDefault transformă ora implicită din configurație în minute, revenind la DefaultTime dacă nu este validă
+func ParseDefault(value string) int {
+	minutes, err := ParseClock(value)
+	if err != nil {
+		return DefaultTime
+	}
+	return minutes
+}
@@ -0,0 +1,114 @@
+package reminders
+
+import (
+	"testing"
+	"time"
+)
+
+func clock(t *testing.T, value string) *int {
+	t.Helper()
+	minutes, err := ParseClock(value)
+	if err != nil {
+		t.Fatalf("ParseClock(%q): %v", value, err)
+	}
+	return &minutes
+}
+
+func TestParseClock(t *testing.T) {
+	if got, err := ParseClock("20:30"); err != nil || got != 20*60+30 {
+		t.Errorf("ParseClock(20:30) = %d, %v", got, err)
+	}
+	for _, value := range []string{"", "24:00", "8", "ab:cd"} {
+		if _, err := ParseClock(value); err != ErrInvalidClock {
+			t.Errorf("ParseClock(%q): err = %v, want ErrInvalidClock", value, err)
+		}
+	}
+	if got := FormatClock(7*60 + 5); got != "07:05" {
+		t.Errorf("FormatClock = %q, want 07:05", got)
+	}
+}
+
+func TestInQuietHours(t *testing.T) {
+	overnight := Settings{QuietStart: clock(t, "22:00"), QuietEnd: clock(t, "07:00")}
+	daytime := Settings{QuietStart: clock(t, "12:00"), QuietEnd: clock(t, "14:00")}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		minute   int
+		want     bool
+	}{
+		{"overnight late", overnight, 23 * 60, true},
+		{"overnight early", overnight, 6 * 60, true},
+		{"overnight end is exclusive", overnight, 7 * 60, false},
+		{"overnight outside", overnight, 20 * 60, false},
+		{"daytime inside", daytime, 13 * 60, true},
+		{"daytime outside", daytime, 15 * 60, false},
+		{"not configured", Settings{}, 23 * 60, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.settings.InQuietHours(tt.minute); got != tt.want {
+			t.Errorf("%s: InQuietHours = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDue(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Bucharest")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+
+	at := func(value string) time.Time {
+		ts, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	ptr := func(ts time.Time) *time.Time { return &ts }
+
+	base := Settings{Enabled: true, RemindAt: *clock(t, "20:00")}
+
+	tests := []struct {
+		name   string
+		change func(s *Settings)
+		now    string
+		want   bool
+	}{
+		{"before reminder time", nil, "2024-05-10 19:59", false},
+		{"at reminder time", nil, "2024-05-10 20:00", true},
+		{"opted out", func(s *Settings) { s.Enabled = false }, "2024-05-10 21:00", false},
+		{"already sent today", func(s *Settings) { s.LastSentAt = ptr(at("2024-05-10 20:00")) }, "2024-05-10 21:00", false},
+		{"sent yesterday", func(s *Settings) { s.LastSentAt = ptr(at("2024-05-09 20:00")) }, "2024-05-10 20:01", true},
+		{"quiet hours", func(s *Settings) {
+			s.QuietStart, s.QuietEnd = clock(t, "19:00"), clock(t, "21:00")
+		}, "2024-05-10 20:30", false},
+		{"after quiet hours", func(s *Settings) {
+			s.QuietStart, s.QuietEnd = clock(t, "19:00"), clock(t, "21:00")
+		}, "2024-05-10 21:00", true},
+		{"snoozed", func(s *Settings) {
+			s.LastSentAt = ptr(at("2024-05-10 20:00"))
+			s.SnoozedUntil = ptr(at("2024-05-10 21:00"))
+		}, "2024-05-10 20:30", false},
+		{"snooze expired", func(s *Settings) {
+			s.LastSentAt = ptr(at("2024-05-10 20:00"))
+			s.SnoozedUntil = ptr(at("2024-05-10 21:00"))
+		}, "2024-05-10 21:00", true},
+		{"sent again after snooze", func(s *Settings) {
+			s.SnoozedUntil = ptr(at("2024-05-10 21:00"))
+			s.LastSentAt = ptr(at("2024-05-10 21:00"))
+		}, "2024-05-10 21:30", false},
+	}
+
+	for _, tt := range tests {
+		s := base
+		if tt.change != nil {
+			tt.change(&s)
+		}
+		if got := s.Due(at(tt.now), loc); got != tt.want {
+			t.Errorf("%s: Due = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
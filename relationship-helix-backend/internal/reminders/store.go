@@ -0,0 +1,47 @@
+package reminders
+
+import (
+	"database/sql"
+
+	"relationship-helix/internal/db"
+)
+
+// Load încarcă preferințele utilizatorului; fără preferințe salvate, mementoul este activ la ora implicită
+func Load(q db.Queryer, userID uint, defaultRemindAt int) (Settings, error) {
+	s := Settings{Enabled: true, RemindAt: defaultRemindAt}
+
+	var remindAt, quietStart, quietEnd sql.NullInt64
+	var snoozedUntil, lastSentAt sql.NullTime
+	err := q.QueryRow(
+		`SELECT enabled, remind_at, quiet_start, quiet_end, snoozed_until, last_sent_at
+         FROM checkin_reminders
+         WHERE user_id = $1`,
+		userID,
+	).Scan(&s.Enabled, &remindAt, &quietStart, &quietEnd, &snoozedUntil, &lastSentAt)
+	if err == sql.ErrNoRows {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+
+	Apply(&s, remindAt, quietStart, quietEnd, snoozedUntil, lastSentAt)
+	return s, nil
+}
+
+// Apply completează preferințele cu valorile opționale citite din baza de date
+func Apply(s *Settings, remindAt, quietStart, quietEnd sql.NullInt64, snoozedUntil, lastSentAt sql.NullTime) {
+	if remindAt.Valid {
+		s.RemindAt = int(remindAt.Int64)
+	}
+	if quietStart.Valid && quietEnd.Valid {
+		start, end := int(quietStart.Int64), int(quietEnd.Int64)
+		s.QuietStart, s.QuietEnd = &start, &end
+	}
+	if snoozedUntil.Valid {
+		s.SnoozedUntil = &snoozedUntil.Time
+	}
+	if lastSentAt.Valid {
+		s.LastSentAt = &lastSentAt.Time
+	}
+}
@@ -0,0 +1,296 @@
+package reveal
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"relationship-helix/internal/axes"
+	"relationship-helix/internal/models"
+	"relationship-helix/internal/pause"
+	"relationship-helix/internal/webhooks"
+)
+
+// NotifyFunc trimite un eveniment în timp real unui utilizator dintr-o relație
+type NotifyFunc func(relationshipID, userID uint, eventType string, payload interface{})
+
+// sealed este o poziție sigilată, în așteptarea dezvăluirii
+type sealed struct {
+	UserID     uint
+	Position   int
+	Note       *string
+	Mood       *string
+	Emoji      *string
+	Visibility string
+	Axes       map[string]int
+	UpdatedAt  time.Time
+}
+
+// Reveal aplică pozițiile sigilate ale relației și anunță toți membrii prin evenimentul positions_revealed.
+// Returnează false dacă nu exista nicio poziție sigilată.
+func Reveal(db *sql.DB, relationshipID uint, notify NotifyFunc) (bool, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Blochează relația, pentru ca o dezvăluire să nu se suprapună cu alta
+	var kind string
+	err = tx.QueryRow(`SELECT kind FROM relationships WHERE id = $1 FOR UPDATE`, relationshipID).Scan(&kind)
+	if err != nil {
+		return false, err
+	}
+
+	userIDs, err := memberIDs(tx, relationshipID)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := tx.Query(
+		`SELECT user_id, position, note, mood, emoji, visibility, axes, updated_at
+         FROM sealed_positions
+         WHERE relationship_id = $1`,
+		relationshipID,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	var positions []sealed
+	for rows.Next() {
+		var s sealed
+		var axesJSON []byte
+		if err := rows.Scan(&s.UserID, &s.Position, &s.Note, &s.Mood, &s.Emoji, &s.Visibility, &axesJSON, &s.UpdatedAt); err != nil {
+			rows.Close()
+			return false, err
+		}
+		if len(axesJSON) > 0 {
+			if err := json.Unmarshal(axesJSON, &s.Axes); err != nil {
+				rows.Close()
+				return false, err
+			}
+		}
+		positions = append(positions, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+
+	if len(positions) == 0 {
+		return false, nil
+	}
+
+	for _, s := range positions {
+		_, err = tx.Exec(
+			`INSERT INTO curve_positions (relationship_id, user_id, position, created_at, updated_at)
+             VALUES ($1, $2, $3, NOW(), NOW())
+             ON CONFLICT (relationship_id, user_id)
+             DO UPDATE SET position = $3, updated_at = NOW(), decayed_position = NULL, stale_since = NULL`,
+			relationshipID, s.UserID, s.Position,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		// Istoricul păstrează momentul în care partenerul a răspuns, nu momentul dezvăluirii
+		var eventID uint
+		err = tx.QueryRow(
+			`INSERT INTO position_events (relationship_id, user_id, position, note, mood, emoji, visibility, created_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+             RETURNING id`,
+			relationshipID, s.UserID, s.Position, s.Note, s.Mood, s.Emoji, s.Visibility, s.UpdatedAt,
+		).Scan(&eventID)
+		if err != nil {
+			return false, err
+		}
+
+		if err := axes.SaveValues(tx, relationshipID, s.UserID, eventID, s.Axes); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err = tx.Exec(`DELETE FROM sealed_positions WHERE relationship_id = $1`, relationshipID); err != nil {
+		return false, err
+	}
+
+	current := make(map[uint]int)
+	for _, userID := range userIDs {
+		var position int
+		err = tx.QueryRow(
+			`SELECT COALESCE((SELECT position FROM curve_positions WHERE relationship_id = $1 AND user_id = $2), 0)`,
+			relationshipID, userID,
+		).Scan(&position)
+		if err != nil {
+			return false, err
+		}
+		current[userID] = position
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	paused := make(map[uint]bool)
+	for _, userID := range userIDs {
+		p, err := pause.Active(db, relationshipID, userID)
+		if err != nil {
+			return true, err
+		}
+		paused[userID] = p != nil
+	}
+
+	round := revealRound{
+		kind:     kind,
+		userIDs:  userIDs,
+		current:  current,
+		paused:   paused,
+		sealedBy: make(map[uint]sealed),
+	}
+	for _, s := range positions {
+		round.sealedBy[s.UserID] = s
+	}
+
+	// Fiecare membru primește pozițiile din perspectiva sa
+	revealedAt := time.Now()
+	for _, recipientID := range userIDs {
+		notify(relationshipID, recipientID, "positions_revealed", round.payload(recipientID, revealedAt))
+	}
+
+	// Fiecare poziție dezvăluită este un eveniment position_update pentru webhook-uri
+	for _, s := range positions {
+		update, recipients := round.webhookUpdate(relationshipID, s)
+		err := webhooks.Enqueue(db, webhooks.Event{
+			Type:           webhooks.EventPositionUpdate,
+			RelationshipID: relationshipID,
+			RecipientIDs:   recipients,
+			Data:           update,
+		})
+		if err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+// Deadline returnează momentul dezvăluirii automate; termenul curge de la prima poziție sigilată
+func Deadline(firstSealedAt time.Time, windowMinutes int) time.Time {
+	return firstSealedAt.Add(time.Duration(windowMinutes) * time.Minute)
+}
+
+// Due verifică dacă pozițiile sigilate trebuie dezvăluite: toți membrii au răspuns sau termenul a expirat
+func Due(submitted, members int, deadline, now time.Time) bool {
+	return submitted > 0 && (submitted >= members || !now.Before(deadline))
+}
+
+// revealRound conține starea unei dezvăluiri, după aplicarea pozițiilor sigilate
+type revealRound struct {
+	kind     string
+	userIDs  []uint
+	current  map[uint]int // Pozițiile curente ale tuturor membrilor
+	paused   map[uint]bool
+	sealedBy map[uint]sealed // Pozițiile dezvăluite acum, după autor
+}
+
+// payload construiește evenimentul positions_revealed din perspectiva destinatarului: notițele private ale
+// celorlalți membri și pozițiile celor aflați în pauză rămân ascunse
+func (r revealRound) payload(recipientID uint, revealedAt time.Time) map[string]interface{} {
+	var list []map[string]interface{}
+	for _, userID := range r.userIDs {
+		entry := map[string]interface{}{
+			"userId":   userID,
+			"position": r.current[userID],
+			"status":   models.PositionStatusActive,
+		}
+
+		hidden := userID != recipientID && r.paused[userID]
+		if hidden {
+			entry["position"] = nil
+			entry["status"] = models.PositionStatusPaused
+		}
+
+		s, submitted := r.sealedBy[userID]
+		entry["submitted"] = submitted
+		if submitted && !hidden {
+			if len(s.Axes) > 0 {
+				entry["axes"] = s.Axes
+			}
+			if userID == recipientID || s.Visibility == models.VisibilityShared {
+				entry["note"] = s.Note
+				entry["mood"] = s.Mood
+				entry["emoji"] = s.Emoji
+			}
+		}
+		list = append(list, entry)
+	}
+
+	payload := map[string]interface{}{
+		"positions":  list,
+		"revealedAt": revealedAt,
+	}
+
+	// Câmpurile pentru cupluri rămân compatibile cu clienții existenți
+	if r.kind == models.RelationshipKindCouple {
+		for _, entry := range list {
+			if entry["userId"] == recipientID {
+				payload["userPosition"] = entry["position"]
+				continue
+			}
+			payload["partnerPosition"] = entry["position"]
+			payload["partnerStatus"] = entry["status"]
+			payload["partnerSubmitted"] = entry["submitted"]
+			payload["partnerAxes"] = entry["axes"]
+			payload["note"] = entry["note"]
+			payload["mood"] = entry["mood"]
+			payload["emoji"] = entry["emoji"]
+		}
+	}
+
+	return payload
+}
+
+// webhookUpdate construiește evenimentul position_update al unei poziții dezvăluite și destinatarii lui;
+// pozițiile din timpul unei pauze ajung doar la webhook-urile autorului
+func (r revealRound) webhookUpdate(relationshipID uint, s sealed) (models.PositionUpdate, []uint) {
+	update := models.PositionUpdate{
+		RelationshipID: relationshipID,
+		UserID:         s.UserID,
+		Position:       s.Position,
+		Axes:           s.Axes,
+		Paused:         r.paused[s.UserID],
+	}
+	if s.Visibility == models.VisibilityShared {
+		update.Note = s.Note
+		update.Mood = s.Mood
+		update.Emoji = s.Emoji
+	}
+
+	if update.Paused {
+		return update, []uint{s.UserID}
+	}
+	return update, r.userIDs
+}
+
+// memberIDs încarcă ID-urile membrilor relației
+func memberIDs(tx *sql.Tx, relationshipID uint) ([]uint, error) {
+	rows, err := tx.Query(
+		`SELECT user_id FROM relationship_members WHERE relationship_id = $1 ORDER BY joined_at, id`,
+		relationshipID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
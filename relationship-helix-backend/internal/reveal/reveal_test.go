@@ -0,0 +1,129 @@
+package reveal
+
+import (
+	"testing"
+	"time"
+
+	"relationship-helix/internal/models"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestDue(t *testing.T) {
+	first := time.Date(2024, 3, 15, 20, 0, 0, 0, time.UTC)
+	deadline := Deadline(first, 60)
+	if !deadline.Equal(first.Add(time.Hour)) {
+		t.Fatalf("Deadline = %v", deadline)
+	}
+
+	tests := []struct {
+		name               string
+		submitted, members int
+		now                time.Time
+		want               bool
+	}{
+		{"un singur răspuns, înainte de termen", 1, 2, first.Add(10 * time.Minute), false},
+		{"toți membrii au răspuns", 2, 2, first.Add(10 * time.Minute), true},
+		{"grup incomplet", 2, 3, deadline.Add(-time.Second), false},
+		{"termenul a expirat", 1, 3, deadline, true},
+		{"membru ieșit din grup după ce a răspuns", 3, 2, first, true},
+		{"niciun răspuns", 0, 2, deadline.Add(time.Hour), false},
+	}
+	for _, tt := range tests {
+		if got := Due(tt.submitted, tt.members, deadline, tt.now); got != tt.want {
+			t.Errorf("%s: Due = %v, vrem %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// coupleRound: Ana (1) a răspuns cu o notiță privată, Mihai (2) cu una partajată
+func coupleRound(paused map[uint]bool) revealRound {
+	return revealRound{
+		kind:    models.RelationshipKindCouple,
+		userIDs: []uint{1, 2},
+		current: map[uint]int{1: 40, 2: 70},
+		paused:  paused,
+		sealedBy: map[uint]sealed{
+			1: {UserID: 1, Position: 40, Note: strPtr("doar pentru mine"), Visibility: models.VisibilityPrivate, Axes: map[string]int{"trust": 50}},
+			2: {UserID: 2, Position: 70, Note: strPtr("mulțumesc"), Mood: strPtr("grateful"), Visibility: models.VisibilityShared},
+		},
+	}
+}
+
+func TestPayloadHidesPrivateNotes(t *testing.T) {
+	round := coupleRound(map[uint]bool{})
+	at := time.Now()
+
+	// Mihai vede poziția Anei, dar nu și notița ei privată
+	p := round.payload(2, at)
+	if p["userPosition"] != 70 || p["partnerPosition"] != 40 || p["partnerSubmitted"] != true {
+		t.Errorf("payload Mihai = %+v", p)
+	}
+	if note, _ := p["note"].(*string); note != nil {
+		t.Errorf("notița privată a ajuns la partener: %q", *note)
+	}
+	if axes, _ := p["partnerAxes"].(map[string]int); axes["trust"] != 50 {
+		t.Errorf("dimensiunile partenerului lipsesc: %+v", p["partnerAxes"])
+	}
+
+	// Ana vede notița partajată a lui Mihai și, în listă, propria notiță privată
+	p = round.payload(1, at)
+	if note, _ := p["note"].(*string); note == nil || *note != "mulțumesc" {
+		t.Errorf("notița partajată lipsește: %+v", p["note"])
+	}
+	own := p["positions"].([]map[string]interface{})[0]
+	if note, _ := own["note"].(*string); note == nil || *note != "doar pentru mine" {
+		t.Errorf("autorul trebuie să-și vadă notița privată: %+v", own)
+	}
+}
+
+func TestPayloadHidesPausedMembers(t *testing.T) {
+	round := coupleRound(map[uint]bool{1: true})
+	at := time.Now()
+
+	// Partenerul Anei, aflată în pauză, vede doar starea neutră
+	p := round.payload(2, at)
+	if p["partnerPosition"] != nil || p["partnerStatus"] != models.PositionStatusPaused || p["partnerAxes"] != nil {
+		t.Errorf("poziția unui membru în pauză nu trebuie expusă: %+v", p)
+	}
+
+	// Ana își vede în continuare propria poziție
+	p = round.payload(1, at)
+	if p["userPosition"] != 40 || p["partnerStatus"] != models.PositionStatusActive {
+		t.Errorf("payload Ana = %+v", p)
+	}
+}
+
+func TestPayloadGroup(t *testing.T) {
+	round := revealRound{
+		kind:     models.RelationshipKindGroup,
+		userIDs:  []uint{1, 2, 3},
+		current:  map[uint]int{1: 10, 2: 20, 3: 30},
+		paused:   map[uint]bool{},
+		sealedBy: map[uint]sealed{2: {UserID: 2, Position: 20, Visibility: models.VisibilityShared}},
+	}
+
+	p := round.payload(1, time.Now())
+	if _, ok := p["partnerPosition"]; ok {
+		t.Errorf("câmpurile pentru cupluri nu se trimit unui grup: %+v", p)
+	}
+	list := p["positions"].([]map[string]interface{})
+	if len(list) != 3 || list[1]["submitted"] != true || list[2]["submitted"] != false || list[2]["position"] != 30 {
+		t.Errorf("pozițiile grupului = %+v", list)
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	round := coupleRound(map[uint]bool{1: true})
+
+	// Poziția din timpul pauzei ajunge doar la webhook-urile autorului, fără notița privată
+	update, recipients := round.webhookUpdate(9, round.sealedBy[1])
+	if !update.Paused || update.Note != nil || len(recipients) != 1 || recipients[0] != 1 {
+		t.Errorf("update Ana = %+v, destinatari %v", update, recipients)
+	}
+
+	update, recipients = round.webhookUpdate(9, round.sealedBy[2])
+	if update.Paused || update.Note == nil || update.Mood == nil || len(recipients) != 2 || update.RelationshipID != 9 {
+		t.Errorf("update Mihai = %+v, destinatari %v", update, recipients)
+	}
+}
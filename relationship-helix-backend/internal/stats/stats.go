@@ -0,0 +1,380 @@
+// Package stats calculează statistici despre evoluția pozițiilor celor doi parteneri.
+// Funcțiile sunt pure: primesc evenimentele deja încărcate și nu accesează baza de date.
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxPosition este valoarea maximă a unei poziții (0 = apropiat, 100 = distant)
+const MaxPosition = 100
+
+// Event reprezintă o actualizare de poziție a unui partener
+type Event struct {
+	UserID   uint
+	Position int
+	At       time.Time
+}
+
+// Band este un interval de apropiere, cu limite inclusive
+type Band struct {
+	Name string `json:"name"`
+	Min  int    `json:"min"`
+	Max  int    `json:"max"`
+}
+
+// DefaultBands împarte scala în trei intervale egale
+var DefaultBands = []Band{
+	{Name: "close", Min: 0, Max: 33},
+	{Name: "middle", Min: 34, Max: 66},
+	{Name: "distant", Min: 67, Max: 100},
+}
+
+// Options configurează calculele
+type Options struct {
+	// RollingDays este lățimea ferestrei pentru media mobilă (implicit 7)
+	RollingDays int
+	// Bands sunt intervalele de apropiere (implicit DefaultBands)
+	Bands []Band
+	// Location este fusul orar în care se delimitează zilele mediei mobile (implicit UTC)
+	Location *time.Location
+}
+
+// Point este o valoare a mediei mobile la sfârșitul unei zile
+type Point struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// BandTime este timpul petrecut de un partener într-un interval de apropiere
+type BandTime struct {
+	Band     string  `json:"band"`
+	Seconds  float64 `json:"seconds"`
+	Fraction float64 `json:"fraction"`
+}
+
+// ResponseStats descrie cât de repede răspunde un partener la mișcările celuilalt
+type ResponseStats struct {
+	Count          int     `json:"count"`
+	AverageSeconds float64 `json:"averageSeconds"`
+	MedianSeconds  float64 `json:"medianSeconds"`
+}
+
+// PartnerStats conține statisticile unui partener în fereastra analizată
+type PartnerStats struct {
+	UserID             uint           `json:"userId"`
+	Updates            int            `json:"updates"`
+	Average            *float64       `json:"average"`
+	RollingAverage     []Point        `json:"rollingAverage"`
+	Volatility         float64        `json:"volatility"`
+	MeanAbsoluteChange float64        `json:"meanAbsoluteChange"`
+	Bands              []BandTime     `json:"bands"`
+	ResponseTime       *ResponseStats `json:"responseTime"`
+}
+
+// Report este rezultatul complet al analizei
+type Report struct {
+	From        time.Time    `json:"from"`
+	To          time.Time    `json:"to"`
+	User        PartnerStats `json:"user"`
+	Partner     PartnerStats `json:"partner"`
+	Convergence *float64     `json:"convergence"`
+}
+
+// Segment este un interval în care poziția unui partener a rămas constantă
+type Segment struct {
+	Start, End time.Time
+	Position   int
+}
+
+// Compute calculează statisticile pentru fereastra [from, to].
+// Evenimentele pot include și ultima poziție a fiecărui partener dinaintea ferestrei,
+// pentru ca pozițiile să fie cunoscute încă de la începutul ei.
+func Compute(events []Event, userID, partnerID uint, from, to time.Time, opts Options) Report {
+	opts = withDefaults(opts)
+
+	sorted := make([]Event, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].At.Before(sorted[j].At)
+	})
+
+	userEvents := filterUser(sorted, userID)
+	partnerEvents := filterUser(sorted, partnerID)
+
+	report := Report{
+		From:    from,
+		To:      to,
+		User:    partnerStats(userID, userEvents, from, to, opts),
+		Partner: partnerStats(partnerID, partnerEvents, from, to, opts),
+	}
+	report.User.ResponseTime = ResponseTime(sorted, userID, partnerID, from, to)
+	report.Partner.ResponseTime = ResponseTime(sorted, partnerID, userID, from, to)
+	report.Convergence = Convergence(Segments(userEvents, from, to), Segments(partnerEvents, from, to))
+
+	return report
+}
+
+func withDefaults(opts Options) Options {
+	if opts.RollingDays <= 0 {
+		opts.RollingDays = 7
+	}
+	if len(opts.Bands) == 0 {
+		opts.Bands = DefaultBands
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return opts
+}
+
+func partnerStats(userID uint, events []Event, from, to time.Time, opts Options) PartnerStats {
+	segments := Segments(events, from, to)
+
+	result := PartnerStats{
+		UserID:         userID,
+		Updates:        countBetween(events, from, to),
+		Average:        roundPtr(TimeWeightedAverage(segments)),
+		RollingAverage: RollingAverage(events, from, to, opts.RollingDays, opts.Location),
+		Bands:          TimeInBands(segments, opts.Bands),
+	}
+	result.Volatility, result.MeanAbsoluteChange = Volatility(events, from, to)
+
+	return result
+}
+
+// Segments transformă evenimentele unui partener (sortate) în intervale de poziție constantă, limitate la [from, to]
+func Segments(events []Event, from, to time.Time) []Segment {
+	var result []Segment
+	for i, e := range events {
+		start := e.At
+		end := to
+		if i+1 < len(events) {
+			end = events[i+1].At
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		result = append(result, Segment{Start: start, End: end, Position: e.Position})
+	}
+	return result
+}
+
+// TimeWeightedAverage calculează media pozițiilor ponderată cu timpul petrecut în fiecare
+func TimeWeightedAverage(segments []Segment) *float64 {
+	var total, weighted float64
+	for _, s := range segments {
+		d := s.End.Sub(s.Start).Seconds()
+		total += d
+		weighted += d * float64(s.Position)
+	}
+	if total == 0 {
+		return nil
+	}
+	avg := weighted / total
+	return &avg
+}
+
+// RollingAverage calculează media ponderată cu timpul pe ultimele days zile, la sfârșitul fiecărei zile din fereastră
+func RollingAverage(events []Event, from, to time.Time, days int, loc *time.Location) []Point {
+	result := []Point{}
+	if !to.After(from) {
+		return result
+	}
+
+	local := from.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	for ; dayStart.Before(to); dayStart = dayStart.AddDate(0, 0, 1) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		if dayEnd.After(to) {
+			dayEnd = to
+		}
+		windowStart := dayEnd.AddDate(0, 0, -days)
+		avg := TimeWeightedAverage(Segments(events, windowStart, dayEnd))
+		if avg == nil {
+			continue
+		}
+		result = append(result, Point{Date: dayStart.Format("2006-01-02"), Value: round(*avg)})
+	}
+	return result
+}
+
+// Volatility returnează deviația standard și media absolută a schimbărilor de poziție din fereastră
+func Volatility(events []Event, from, to time.Time) (float64, float64) {
+	var deltas []float64
+	for i := 1; i < len(events); i++ {
+		if events[i].At.Before(from) || events[i].At.After(to) {
+			continue
+		}
+		deltas = append(deltas, float64(events[i].Position-events[i-1].Position))
+	}
+	if len(deltas) == 0 {
+		return 0, 0
+	}
+
+	var sum, absSum float64
+	for _, d := range deltas {
+		sum += d
+		absSum += math.Abs(d)
+	}
+	mean := sum / float64(len(deltas))
+
+	var variance float64
+	for _, d := range deltas {
+		variance += (d - mean) * (d - mean)
+	}
+	variance /= float64(len(deltas))
+
+	return round(math.Sqrt(variance)), round(absSum / float64(len(deltas)))
+}
+
+// TimeInBands calculează timpul petrecut în fiecare interval de apropiere
+func TimeInBands(segments []Segment, bands []Band) []BandTime {
+	result := make([]BandTime, len(bands))
+	var total float64
+	for i, b := range bands {
+		result[i].Band = b.Name
+	}
+	for _, s := range segments {
+		d := s.End.Sub(s.Start).Seconds()
+		total += d
+		for i, b := range bands {
+			if s.Position >= b.Min && s.Position <= b.Max {
+				result[i].Seconds += d
+				break
+			}
+		}
+	}
+	if total > 0 {
+		for i := range result {
+			result[i].Fraction = round(result[i].Seconds / total)
+		}
+	}
+	return result
+}
+
+// ResponseTime măsoară cât durează până când responder se mișcă după o mișcare a lui mover.
+// Se măsoară de la prima mișcare a lui mover rămasă fără răspuns, până la următoarea mișcare a lui responder.
+func ResponseTime(events []Event, responder, mover uint, from, to time.Time) *ResponseStats {
+	var durations []float64
+	var pending *time.Time
+	for i := range events {
+		e := events[i]
+		if e.At.Before(from) || e.At.After(to) {
+			continue
+		}
+		switch e.UserID {
+		case mover:
+			if pending == nil {
+				pending = &events[i].At
+			}
+		case responder:
+			if pending != nil {
+				durations = append(durations, e.At.Sub(*pending).Seconds())
+				pending = nil
+			}
+		}
+	}
+	if len(durations) == 0 {
+		return nil
+	}
+
+	sort.Float64s(durations)
+	var sum float64
+	for _, d := range durations {
+		sum += d
+	}
+
+	median := durations[len(durations)/2]
+	if len(durations)%2 == 0 {
+		median = (durations[len(durations)/2-1] + durations[len(durations)/2]) / 2
+	}
+
+	return &ResponseStats{
+		Count:          len(durations),
+		AverageSeconds: round(sum / float64(len(durations))),
+		MedianSeconds:  round(median),
+	}
+}
+
+// Convergence returnează un scor 0-100 care arată cât de aliniate au fost pozițiile
+// (100 = identice tot timpul, 0 = la capete opuse tot timpul). Se ia în calcul doar
+// timpul în care ambele poziții sunt cunoscute.
+func Convergence(a, b []Segment) *float64 {
+	var total, weightedDistance float64
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		start := later(a[i].Start, b[j].Start)
+		end := earlier(a[i].End, b[j].End)
+		if end.After(start) {
+			d := end.Sub(start).Seconds()
+			total += d
+			weightedDistance += d * math.Abs(float64(a[i].Position-b[j].Position))
+		}
+		if a[i].End.Before(b[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	score := round(100 * (1 - weightedDistance/(total*MaxPosition)))
+	return &score
+}
+
+func filterUser(events []Event, userID uint) []Event {
+	var result []Event
+	for _, e := range events {
+		if e.UserID == userID {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func countBetween(events []Event, from, to time.Time) int {
+	count := 0
+	for _, e := range events {
+		if !e.At.Before(from) && !e.At.After(to) {
+			count++
+		}
+	}
+	return count
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func roundPtr(v *float64) *float64 {
+	if v == nil {
+		return nil
+	}
+	r := round(*v)
+	return &r
+}
+
+// round rotunjește la două zecimale, pentru răspunsuri JSON lizibile
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,166 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	userID    uint = 1
+	partnerID uint = 2
+)
+
+var t0 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func at(hours float64) time.Time {
+	return t0.Add(time.Duration(hours * float64(time.Hour)))
+}
+
+// scenario: utilizatorul pornește de la 20 (setat înainte de fereastră) și trece la 80 după 5h;
+// partenerul apare la 2h cu 20 și trece la 50 la 8h. Fereastra are 10h.
+func scenario() []Event {
+	return []Event{
+		{UserID: partnerID, Position: 50, At: at(8)},
+		{UserID: userID, Position: 20, At: at(-1)},
+		{UserID: partnerID, Position: 20, At: at(2)},
+		{UserID: userID, Position: 80, At: at(5)},
+	}
+}
+
+func TestComputeAverages(t *testing.T) {
+	report := Compute(scenario(), userID, partnerID, at(0), at(10), Options{})
+
+	tests := []struct {
+		name    string
+		stats   PartnerStats
+		updates int
+		average float64
+	}{
+		{"user", report.User, 1, 50},
+		{"partner", report.Partner, 2, 27.5},
+	}
+
+	for _, tt := range tests {
+		if tt.stats.Updates != tt.updates {
+			t.Errorf("%s: updates = %d, want %d", tt.name, tt.stats.Updates, tt.updates)
+		}
+		if tt.stats.Average == nil || *tt.stats.Average != tt.average {
+			t.Errorf("%s: average = %v, want %v", tt.name, tt.stats.Average, tt.average)
+		}
+	}
+}
+
+func TestVolatility(t *testing.T) {
+	events := []Event{
+		{UserID: userID, Position: 10, At: at(0)},
+		{UserID: userID, Position: 30, At: at(1)},
+		{UserID: userID, Position: 10, At: at(2)},
+		{UserID: userID, Position: 50, At: at(3)},
+	}
+
+	// Schimbări: +20, -20, +40 => medie 13.33, deviație standard 24.94, medie absolută 26.67
+	stddev, meanAbs := Volatility(events, at(0), at(4))
+	if stddev != 24.94 {
+		t.Errorf("stddev = %v, want 24.94", stddev)
+	}
+	if meanAbs != 26.67 {
+		t.Errorf("mean absolute change = %v, want 26.67", meanAbs)
+	}
+
+	// Schimbările dinaintea ferestrei sunt ignorate
+	stddev, meanAbs = Volatility(events, at(2.5), at(4))
+	if stddev != 0 || meanAbs != 40 {
+		t.Errorf("windowed volatility = (%v, %v), want (0, 40)", stddev, meanAbs)
+	}
+}
+
+func TestTimeInBands(t *testing.T) {
+	report := Compute(scenario(), userID, partnerID, at(0), at(10), Options{})
+
+	want := map[string]float64{"close": 0.5, "middle": 0, "distant": 0.5}
+	for _, b := range report.User.Bands {
+		if b.Fraction != want[b.Band] {
+			t.Errorf("band %s: fraction = %v, want %v", b.Band, b.Fraction, want[b.Band])
+		}
+	}
+
+	want = map[string]float64{"close": 0.75, "middle": 0.25, "distant": 0}
+	for _, b := range report.Partner.Bands {
+		if b.Fraction != want[b.Band] {
+			t.Errorf("partner band %s: fraction = %v, want %v", b.Band, b.Fraction, want[b.Band])
+		}
+	}
+}
+
+func TestResponseTime(t *testing.T) {
+	report := Compute(scenario(), userID, partnerID, at(0), at(10), Options{})
+
+	if rt := report.User.ResponseTime; rt == nil || rt.Count != 1 || rt.AverageSeconds != 3*3600 {
+		t.Errorf("user response time = %+v, want 1 response of 3h", rt)
+	}
+	if rt := report.Partner.ResponseTime; rt == nil || rt.Count != 1 || rt.AverageSeconds != 3*3600 {
+		t.Errorf("partner response time = %+v, want 1 response of 3h", rt)
+	}
+
+	// Mai multe mișcări consecutive se măsoară de la prima rămasă fără răspuns
+	events := []Event{
+		{UserID: partnerID, Position: 10, At: at(0)},
+		{UserID: partnerID, Position: 20, At: at(1)},
+		{UserID: userID, Position: 30, At: at(2)},
+		{UserID: partnerID, Position: 40, At: at(3)},
+		{UserID: userID, Position: 50, At: at(7)},
+	}
+	rt := ResponseTime(events, userID, partnerID, at(0), at(10))
+	if rt == nil || rt.Count != 2 || rt.MedianSeconds != 3*3600 || rt.AverageSeconds != 3*3600 {
+		t.Errorf("response time = %+v, want 2 responses with median 3h", rt)
+	}
+
+	if rt := ResponseTime(events[:2], userID, partnerID, at(0), at(10)); rt != nil {
+		t.Errorf("response time without answers = %+v, want nil", rt)
+	}
+}
+
+func TestConvergence(t *testing.T) {
+	report := Compute(scenario(), userID, partnerID, at(0), at(10), Options{})
+
+	// Distanța medie pe cele 8h comune: (3h*0 + 3h*60 + 2h*30) / 8h = 30
+	if report.Convergence == nil || *report.Convergence != 70 {
+		t.Errorf("convergence = %v, want 70", report.Convergence)
+	}
+
+	onlyUser := []Event{{UserID: userID, Position: 10, At: at(0)}}
+	if c := Compute(onlyUser, userID, partnerID, at(0), at(10), Options{}).Convergence; c != nil {
+		t.Errorf("convergence without partner = %v, want nil", *c)
+	}
+
+	same := []Event{
+		{UserID: userID, Position: 42, At: at(0)},
+		{UserID: partnerID, Position: 42, At: at(0)},
+	}
+	if c := Compute(same, userID, partnerID, at(0), at(10), Options{}).Convergence; c == nil || *c != 100 {
+		t.Errorf("convergence for identical positions = %v, want 100", c)
+	}
+}
+
+func TestRollingAverage(t *testing.T) {
+	events := []Event{
+		{UserID: userID, Position: 0, At: at(0)},
+		{UserID: userID, Position: 100, At: at(24)},
+	}
+
+	points := RollingAverage(events, at(0), at(72), 2, time.UTC)
+	want := []Point{
+		{Date: "2024-03-01", Value: 0},
+		{Date: "2024-03-02", Value: 50},
+		{Date: "2024-03-03", Value: 100},
+	}
+
+	if len(points) != len(want) {
+		t.Fatalf("points = %+v, want %+v", points, want)
+	}
+	for i := range want {
+		if points[i] != want[i] {
+			t.Errorf("point %d = %+v, want %+v", i, points[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInviteLinkInvalid este returnată când semnătura link-ului nu corespunde
+	ErrInviteLinkInvalid = errors.New("link de invitație invalid")
+	// ErrInviteLinkExpired este returnată când link-ul de invitație a expirat
+	ErrInviteLinkExpired = errors.New("link de invitație expirat")
+)
+
+// SignInviteCode semnează codul de invitație împreună cu momentul expirării link-ului
+func SignInviteCode(code string, expiresAt int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(code + "." + strconv.FormatInt(expiresAt, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// BuildInviteURL construiește link-ul semnat care deschide pagina de alăturare cu codul completat
+func BuildInviteURL(baseURL, code string, expiresAt time.Time, secret string) string {
+	exp := expiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("code", code)
+	query.Set("exp", strconv.FormatInt(exp, 10))
+	query.Set("sig", SignInviteCode(code, exp, secret))
+
+	return strings.TrimRight(baseURL, "/") + "/join?" + query.Encode()
+}
+
+// VerifyInviteSignature verifică semnătura și expirarea unui link de invitație
+func VerifyInviteSignature(code, exp, signature, secret string) error {
+	expiresAt, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil {
+		return ErrInviteLinkInvalid
+	}
+
+	expected := SignInviteCode(code, expiresAt, secret)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInviteLinkInvalid
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return ErrInviteLinkExpired
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "secret-de-test"
+
+func TestBuildInviteURL(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	link := BuildInviteURL("https://helix.app/", "K7QX2M", expiresAt, testSecret)
+
+	if !strings.HasPrefix(link, "https://helix.app/join?") {
+		t.Fatalf("link = %s", link)
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := u.Query()
+	if q.Get("code") != "K7QX2M" || q.Get("exp") != strconv.FormatInt(expiresAt.Unix(), 10) {
+		t.Errorf("parametri = %v", q)
+	}
+	if err := VerifyInviteSignature(q.Get("code"), q.Get("exp"), q.Get("sig"), testSecret); err != nil {
+		t.Errorf("link-ul semnat trebuie acceptat: %v", err)
+	}
+}
+
+func TestVerifyInviteSignature(t *testing.T) {
+	valid := time.Now().Add(time.Hour).Unix()
+	expired := time.Now().Add(-time.Minute).Unix()
+	exp := strconv.FormatInt(valid, 10)
+	sig := SignInviteCode("K7QX2M", valid, testSecret)
+
+	tests := []struct {
+		name                   string
+		code, exp, sig, secret string
+		want                   error
+	}{
+		{"semnătură validă", "K7QX2M", exp, sig, testSecret, nil},
+		{"cod modificat", "K7QX2N", exp, sig, testSecret, ErrInviteLinkInvalid},
+		{"expirare prelungită", "K7QX2M", strconv.FormatInt(valid+86400, 10), sig, testSecret, ErrInviteLinkInvalid},
+		{"expirare nenumerică", "K7QX2M", "mâine", sig, testSecret, ErrInviteLinkInvalid},
+		{"semnătură lipsă", "K7QX2M", exp, "", testSecret, ErrInviteLinkInvalid},
+		{"alt secret", "K7QX2M", exp, sig, "alt-secret", ErrInviteLinkInvalid},
+		{"link expirat", "K7QX2M", strconv.FormatInt(expired, 10), SignInviteCode("K7QX2M", expired, testSecret), testSecret, ErrInviteLinkExpired},
+	}
+
+	for _, tt := range tests {
+		if err := VerifyInviteSignature(tt.code, tt.exp, tt.sig, tt.secret); err != tt.want {
+			t.Errorf("%s: err = %v, vrem %v", tt.name, err, tt.want)
+		}
+	}
+}
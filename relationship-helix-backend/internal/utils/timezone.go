@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+// IsValidTimezone verifică dacă numele este un fus orar IANA cunoscut (ex. "Europe/Bucharest")
+func IsValidTimezone(name string) bool {
+	// "Local" depinde de server, deci nu este acceptat ca preferință a utilizatorului
+	if strings.TrimSpace(name) == "" || name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestIsValidTimezone(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Europe/Bucharest", true},
+		{"America/New_York", true},
+		{"UTC", true},
+		{"", false},
+		{"   ", false},
+		// "Local" este fusul orar al serverului, nu al utilizatorului
+		{"Local", false},
+		{"Europe/Atlantida", false},
+		{"+02:00", false},
+		{"../../etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidTimezone(tt.name); got != tt.want {
+			t.Errorf("IsValidTimezone(%q) = %v, vrem %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// maxErrorLength limitează mesajul de eroare păstrat în jurnalul de livrări
+const maxErrorLength = 500
+
+// ErrPrivateAddress este returnată când URL-ul webhook-ului indică o adresă din rețeaua internă
+var ErrPrivateAddress = errors.New("webhooks: adresele private nu sunt permise")
+
+// ErrInvalidURL este returnată pentru un URL de webhook care nu este http(s) absolut
+var ErrInvalidURL = errors.New("webhooks: URL invalid")
+
+// Client trimite livrările către URL-urile webhook-urilor
+type Client struct {
+	HTTP *http.Client
+}
+
+// NewClient creează un client cu timeout-ul dat; fără allowPrivate, conexiunile către adrese
+// de loopback, private sau link-local sunt refuzate (verificarea se face după rezolvarea DNS)
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivate(ip) {
+				return ErrPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &Client{
+		HTTP: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext, Proxy: nil},
+			// Redirecționările nu sunt urmate: URL-ul înregistrat este singura destinație
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// ValidateURL verifică dacă URL-ul poate fi înregistrat ca webhook
+func ValidateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+	return nil
+}
+
+// Result este rezultatul unei încercări de livrare
+type Result struct {
+	StatusCode int // 0 dacă nu s-a primit răspuns
+	Err        error
+}
+
+// OK verifică dacă livrarea a reușit (răspuns 2xx)
+func (r Result) OK() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// ErrorMessage returnează descrierea eșecului, pentru jurnalul de livrări
+func (r Result) ErrorMessage() string {
+	message := ""
+	switch {
+	case r.Err != nil:
+		message = r.Err.Error()
+	case !r.OK():
+		message = "răspuns " + strconv.Itoa(r.StatusCode)
+	}
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return message
+}
+
+// Send trimite corpul semnat la URL-ul webhook-ului
+func (c *Client) Send(ctx context.Context, rawURL, secret string, deliveryID uint, eventType string, body []byte) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rawURL, bytes.NewReader(body))
+	if err != nil {
+		return Result{Err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "RelationshipHelix-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatUint(uint64(deliveryID), 10))
+	req.Header.Set(HeaderSignature, Sign(secret, time.Now(), body))
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return Result{Err: fmt.Errorf("webhooks: %w", err)}
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	return Result{StatusCode: resp.StatusCode}
+}
+
+// isPrivate verifică dacă adresa nu este accesibilă public
+func isPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast() || ip.IsInterfaceLocalMulticast()
+}
@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Antetele trimise împreună cu fiecare livrare
+const (
+	HeaderSignature = "X-Helix-Signature" // t=<timestamp>,v1=<hex(HMAC-SHA256(secret, "<timestamp>.<body>"))>
+	HeaderEvent     = "X-Helix-Event"
+	HeaderDelivery  = "X-Helix-Delivery"
+)
+
+// ErrInvalidSignature este returnată când semnătura nu corespunde corpului cererii
+var ErrInvalidSignature = errors.New("webhooks: semnătură invalidă")
+
+// Sign calculează antetul de semnătură pentru corpul trimis la momentul dat.
+// Momentul face parte din semnătură, pentru ca destinatarul să poată respinge cererile reluate de terți.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + ts + ",v1=" + hex.EncodeToString(mac(secret, ts, body))
+}
+
+// Verify verifică antetul de semnătură; tolerance limitează vechimea acceptată (0 = fără limită)
+func Verify(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var ts, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signature = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil || signature == "" {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && now.Sub(time.Unix(unix, 0)).Abs() > tolerance {
+		return ErrInvalidSignature
+	}
+
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, mac(secret, ts, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// GenerateSecret generează un secret aleator pentru semnarea livrărilor
+func GenerateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// mac calculează HMAC-SHA256 peste "<timestamp>.<body>"
+func mac(secret, ts string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(ts))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
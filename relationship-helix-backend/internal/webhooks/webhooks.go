@@ -0,0 +1,132 @@
+// Package webhooks trimite evenimentele relațiilor către URL-urile înregistrate de utilizatori,
+// cu semnătură HMAC-SHA256, reîncercări și jurnal de livrări.
+package webhooks
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Tipurile de evenimente care pot fi trimise prin webhook
+const (
+	EventPositionUpdate      = "position_update"
+	EventPositionReverted    = "position_reverted"
+	EventRelationshipCreated = "relationship_created"
+	EventRelationshipEnded   = "relationship_ended"
+	EventMilestoneReached    = "milestone_reached"
+)
+
+// EventTypes conține tipurile de evenimente la care se poate abona un webhook
+var EventTypes = []string{
+	EventPositionUpdate,
+	EventPositionReverted,
+	EventRelationshipCreated,
+	EventRelationshipEnded,
+	EventMilestoneReached,
+}
+
+// IsEventType verifică dacă tipul de eveniment poate fi trimis prin webhook
+func IsEventType(eventType string) bool {
+	for _, t := range EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Stările unei livrări
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Event este un eveniment al unei relații, trimis webhook-urile active ale destinatarilor
+type Event struct {
+	Type           string
+	RelationshipID uint
+	RecipientIDs   []uint // Utilizatorii ale căror webhook-uri primesc evenimentul
+	Data           interface{}
+}
+
+// Execer este implementat atât de *sql.DB, cât și de *sql.Tx
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
+// Enqueue adaugă în jurnal câte o livrare pentru fiecare webhook activ abonat la eveniment;
+// livrările sunt trimise de job-ul de webhook-uri
+func Enqueue(db Execer, event Event) error {
+	if len(event.RecipientIDs) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]int64, len(event.RecipientIDs))
+	for i, id := range event.RecipientIDs {
+		recipients[i] = int64(id)
+	}
+
+	_, err = db.Exec(
+		`INSERT INTO webhook_deliveries (webhook_id, event_type, relationship_id, payload, status, attempts, next_attempt_at, created_at)
+         SELECT w.id, $1, $2, $3, $4, 0, NOW(), NOW()
+         FROM webhooks w
+         WHERE w.active
+           AND w.user_id = ANY($5)
+           AND $1 = ANY(w.events)
+           AND (w.relationship_id IS NULL OR w.relationship_id = $2)`,
+		event.Type, event.RelationshipID, data, StatusPending, pq.Array(recipients),
+	)
+	return err
+}
+
+// Envelope este corpul JSON trimis la URL-ul webhook-ului
+type Envelope struct {
+	ID             uint            `json:"id"` // ID-ul livrării (unic, util pentru deduplicare)
+	Type           string          `json:"type"`
+	RelationshipID uint            `json:"relationshipId"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	Data           json.RawMessage `json:"data"`
+}
+
+// Backoff returnează întârzierea înaintea reîncercării cu numărul attempt (1 = prima reîncercare):
+// 30s, 1m, 2m, 4m, ... limitată la 6 ore
+func Backoff(attempt int) time.Duration {
+	const (
+		base    = 30 * time.Second
+		maximum = 6 * time.Hour
+	)
+
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := base
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maximum {
+			return maximum
+		}
+	}
+	return delay
+}
+
+// CancelPending marchează ca eșuate livrările în așteptare ale unui webhook dezactivat,
+// ca să nu fie trimise evenimente vechi la reactivare
+func CancelPending(db Execer, webhookID uint, reason string) error {
+	_, err := db.Exec(
+		`UPDATE webhook_deliveries
+         SET status = $2, next_attempt_at = NULL, last_error = $3
+         WHERE webhook_id = $1 AND status = $4`,
+		webhookID, StatusFailed, reason, StatusPending,
+	)
+	return err
+}
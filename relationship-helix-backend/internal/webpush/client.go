@@ -0,0 +1,97 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Nivelurile de urgență acceptate de serviciile push (RFC 8030)
+const (
+	UrgencyVeryLow = "very-low"
+	UrgencyLow     = "low"
+	UrgencyNormal  = "normal"
+	UrgencyHigh    = "high"
+)
+
+// ErrSubscriptionGone este returnată când serviciul push raportează că abonamentul nu mai există (404 sau 410)
+var ErrSubscriptionGone = errors.New("webpush: abonamentul a expirat")
+
+// StatusError este un răspuns neașteptat al serviciului push
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return "webpush: serviciul push a răspuns cu " + strconv.Itoa(e.StatusCode)
+}
+
+// Options controlează livrarea unui mesaj
+type Options struct {
+	TTL     time.Duration // Cât timp păstrează serviciul push mesajul pentru un browser offline
+	Urgency string
+	Topic   string // Mesajele cu același subiect se înlocuiesc unul pe altul
+}
+
+// Client trimite mesaje criptate către serviciile push
+type Client struct {
+	VAPID *VAPID
+	HTTP  *http.Client
+}
+
+// NewClient creează un client cu un timeout rezonabil
+func NewClient(vapid *VAPID) *Client {
+	return &Client{
+		VAPID: vapid,
+		HTTP:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send criptează conținutul și îl trimite la endpoint-ul abonamentului
+func (c *Client) Send(ctx context.Context, sub Subscription, payload []byte, opts Options) error {
+	body, err := Encrypt(sub, payload)
+	if err != nil {
+		return err
+	}
+
+	authorization, err := c.VAPID.Authorization(sub.Endpoint, time.Now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(int(opts.TTL/time.Second)))
+	if opts.Urgency != "" {
+		req.Header.Set("Urgency", opts.Urgency)
+	}
+	if opts.Topic != "" {
+		req.Header.Set("Topic", opts.Topic)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("webpush: %w", err)
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrSubscriptionGone
+	case resp.StatusCode == http.StatusRequestEntityTooLarge:
+		return ErrPayloadTooLarge
+	default:
+		return &StatusError{StatusCode: resp.StatusCode}
+	}
+}
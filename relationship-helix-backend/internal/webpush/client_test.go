@@ -0,0 +1,149 @@
+package webpush
+
+import (
+	"context"
+	"crypto/ecdh"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// pushServiceStub simulează un serviciu push: verifică antetele, decriptează mesajul și răspunde cu status
+type pushServiceStub struct {
+	t        *testing.T
+	vapid    *VAPID
+	uaKey    *ecdh.PrivateKey
+	auth     []byte
+	status   int
+	received []string
+	headers  http.Header
+}
+
+func (s *pushServiceStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.headers = r.Header.Clone()
+
+	// Antetul VAPID: vapid t=<jwt>, k=<cheia publică>
+	authorization := r.Header.Get("Authorization")
+	params := strings.Split(strings.TrimPrefix(authorization, "vapid "), ", ")
+	if len(params) != 2 || !strings.HasPrefix(params[0], "t=") || params[1] != "k="+s.vapid.PublicKey() {
+		s.t.Errorf("unexpected Authorization header %q", authorization)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(params[0], "t="), claims, func(token *jwt.Token) (interface{}, error) {
+		return &s.vapid.PrivateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}))
+	if err != nil {
+		s.t.Errorf("invalid VAPID token: %v", err)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if claims["aud"] != "http://"+r.Host {
+		s.t.Errorf("aud = %v, want http://%s", claims["aud"], r.Host)
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	plaintext, err := Decrypt(s.uaKey, s.auth, body)
+	if err != nil {
+		s.t.Errorf("decrypt: %v", err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.received = append(s.received, string(plaintext))
+	w.WriteHeader(s.status)
+}
+
+func newStub(t *testing.T, status int) (*pushServiceStub, *httptest.Server, Subscription) {
+	privateKey, _, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapid, err := NewVAPID(privateKey, "mailto:admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uaKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, rfcUAPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stub := &pushServiceStub{t: t, vapid: vapid, uaKey: uaKey, auth: mustDecode(t, rfcAuthSecret), status: status}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	return stub, server, Subscription{Endpoint: server.URL + "/push/abc", P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+}
+
+func TestClientSend(t *testing.T) {
+	stub, _, sub := newStub(t, http.StatusCreated)
+	client := NewClient(stub.vapid)
+
+	err := client.Send(context.Background(), sub, []byte(`{"type":"position_update"}`), Options{
+		TTL:     time.Hour,
+		Urgency: UrgencyHigh,
+		Topic:   "position",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stub.received) != 1 || stub.received[0] != `{"type":"position_update"}` {
+		t.Errorf("received = %q", stub.received)
+	}
+	for header, want := range map[string]string{
+		"Content-Encoding": "aes128gcm",
+		"Ttl":              "3600",
+		"Urgency":          "high",
+		"Topic":            "position",
+	} {
+		if got := stub.headers.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestClientSendErrors(t *testing.T) {
+	for status, want := range map[int]error{
+		http.StatusGone:                  ErrSubscriptionGone,
+		http.StatusNotFound:              ErrSubscriptionGone,
+		http.StatusRequestEntityTooLarge: ErrPayloadTooLarge,
+	} {
+		stub, _, sub := newStub(t, status)
+		if err := NewClient(stub.vapid).Send(context.Background(), sub, []byte("x"), Options{}); !errors.Is(err, want) {
+			t.Errorf("status %d: err = %v, want %v", status, err, want)
+		}
+	}
+
+	stub, _, sub := newStub(t, http.StatusTooManyRequests)
+	var statusErr *StatusError
+	if err := NewClient(stub.vapid).Send(context.Background(), sub, []byte("x"), Options{}); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("status 429: err = %v, want StatusError", err)
+	}
+}
+
+func TestNewVAPID(t *testing.T) {
+	privateKey, publicKey, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	vapid, err := NewVAPID(privateKey, "mailto:admin@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vapid.PublicKey() != publicKey {
+		t.Errorf("public key = %s, want %s", vapid.PublicKey(), publicKey)
+	}
+
+	if _, err := NewVAPID("not-a-key", ""); err != ErrInvalidVAPIDKey {
+		t.Errorf("invalid key: err = %v, want ErrInvalidVAPIDKey", err)
+	}
+}
@@ -0,0 +1,82 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"errors"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrInvalidMessage este returnată pentru un mesaj aes128gcm care nu poate fi decriptat
+var ErrInvalidMessage = errors.New("webpush: mesaj invalid")
+
+// Decrypt decriptează un mesaj aes128gcm format dintr-o singură înregistrare, din perspectiva browserului.
+// Este folosită de serviciile push de test, pentru a verifica mesajele trimise.
+func Decrypt(uaKey *ecdh.PrivateKey, authSecret, message []byte) ([]byte, error) {
+	if len(message) < headerSize {
+		return nil, ErrInvalidMessage
+	}
+
+	salt := message[:16]
+	idLen := int(message[20])
+	if idLen != 65 || len(message) < 21+idLen {
+		return nil, ErrInvalidMessage
+	}
+	asPublicBytes := message[21 : 21+idLen]
+	ciphertext := message[21+idLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		return nil, ErrInvalidMessage
+	}
+	sharedSecret, err := uaKey.ECDH(asPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaKey.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := expand(hkdf.Extract(sha256.New, sharedSecret, authSecret), keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, err := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrInvalidMessage
+	}
+
+	// Elimină padding-ul și delimitatorul ultimei înregistrări
+	for i := len(plaintext) - 1; i >= 0; i-- {
+		switch plaintext[i] {
+		case 0x00:
+			continue
+		case 0x02:
+			return plaintext[:i], nil
+		}
+		break
+	}
+	return nil, ErrInvalidMessage
+}
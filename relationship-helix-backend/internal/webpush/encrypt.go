@@ -0,0 +1,164 @@
+// Package webpush implementează trimiterea mesajelor Web Push: criptarea conținutului (RFC 8291, aes128gcm)
+// și identificarea serverului prin VAPID (RFC 8292).
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/url"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// recordSize este dimensiunea înregistrării aes128gcm; mesajul încape într-o singură înregistrare
+	recordSize = 4096
+
+	// headerSize = salt (16) + rs (4) + idlen (1) + cheia publică necomprimată (65)
+	headerSize = 16 + 4 + 1 + 65
+
+	// MaxPayloadSize este dimensiunea maximă a conținutului necriptat
+	MaxPayloadSize = recordSize - headerSize - 16 - 1
+)
+
+var (
+	// ErrPayloadTooLarge este returnată când conținutul nu încape într-un mesaj push
+	ErrPayloadTooLarge = errors.New("webpush: conținutul depășește dimensiunea maximă")
+
+	// ErrInvalidSubscription este returnată pentru cheile abonamentului care nu sunt valide
+	ErrInvalidSubscription = errors.New("webpush: cheile abonamentului nu sunt valide")
+)
+
+// Subscription este abonamentul push al unui browser (PushSubscription)
+type Subscription struct {
+	Endpoint string
+	P256dh   string // Cheia publică P-256 a browserului, base64url
+	Auth     string // Secretul de autentificare, base64url
+}
+
+// Encrypt criptează conținutul pentru abonament, cu o cheie efemeră și un salt aleator
+func Encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return encrypt(sub, payload, salt, serverKey)
+}
+
+// encrypt construiește mesajul aes128gcm descris în RFC 8291, secțiunea 3.4
+func encrypt(sub Subscription, payload, salt []byte, serverKey *ecdh.PrivateKey) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+
+	uaPublicBytes, err := decodeKey(sub.P256dh)
+	if err != nil {
+		return nil, ErrInvalidSubscription
+	}
+	authSecret, err := decodeKey(sub.Auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, ErrInvalidSubscription
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, ErrInvalidSubscription
+	}
+
+	sharedSecret, err := serverKey.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	asPublicBytes := serverKey.PublicKey().Bytes()
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, err := expand(hkdf.Extract(sha256.New, sharedSecret, authSecret), keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	cek, err := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// O singură înregistrare, marcată ca ultima prin delimitatorul 0x02
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	header := make([]byte, 0, headerSize)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublicBytes)))
+	header = append(header, asPublicBytes...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// expand derivă length octeți din cheia pseudoaleatoare prk
+func expand(prk, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeKey decodifică o cheie base64url, cu sau fără padding
+func decodeKey(value string) ([]byte, error) {
+	if decoded, err := base64.RawURLEncoding.DecodeString(value); err == nil {
+		return decoded, nil
+	}
+	return base64.URLEncoding.DecodeString(value)
+}
+
+// Validate verifică endpoint-ul și cheile abonamentului
+func (s Subscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return ErrInvalidSubscription
+	}
+
+	p256dh, err := decodeKey(s.P256dh)
+	if err != nil {
+		return ErrInvalidSubscription
+	}
+	if _, err := ecdh.P256().NewPublicKey(p256dh); err != nil {
+		return ErrInvalidSubscription
+	}
+
+	auth, err := decodeKey(s.Auth)
+	if err != nil || len(auth) != 16 {
+		return ErrInvalidSubscription
+	}
+	return nil
+}
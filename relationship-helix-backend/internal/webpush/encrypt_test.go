@@ -0,0 +1,81 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"encoding/base64"
+	"testing"
+)
+
+// Exemplul din RFC 8291, Anexa A
+const (
+	rfcPlaintext  = "When I grow up, I want to be a watermelon"
+	rfcASPrivate  = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfcUAPublic   = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfcUAPrivate  = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfcSalt       = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfcAuthSecret = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfcMessage    = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func mustDecode(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		t.Fatalf("decode %q: %v", value, err)
+	}
+	return decoded
+}
+
+func TestEncryptRFC8291Vector(t *testing.T) {
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, rfcASPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub := Subscription{Endpoint: "https://push.example.net/push/JzLQ3raZJfFBR0aqvOMsLrt54w4rJUsV", P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+	got, err := encrypt(sub, []byte(rfcPlaintext), mustDecode(t, rfcSalt), serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encoded := base64.RawURLEncoding.EncodeToString(got); encoded != rfcMessage {
+		t.Errorf("message = %s\nwant      %s", encoded, rfcMessage)
+	}
+}
+
+func TestEncryptRoundTrip(t *testing.T) {
+	uaKey, err := ecdh.P256().NewPrivateKey(mustDecode(t, rfcUAPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sub := Subscription{P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+
+	body, err := Encrypt(sub, []byte(`{"type":"position_update"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := Decrypt(uaKey, mustDecode(t, rfcAuthSecret), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plaintext) != `{"type":"position_update"}` {
+		t.Errorf("plaintext = %q", plaintext)
+	}
+}
+
+func TestEncryptRejectsInvalidInput(t *testing.T) {
+	sub := Subscription{P256dh: rfcUAPublic, Auth: rfcAuthSecret}
+	if _, err := Encrypt(sub, make([]byte, MaxPayloadSize+1)); err != ErrPayloadTooLarge {
+		t.Errorf("oversized payload: err = %v, want ErrPayloadTooLarge", err)
+	}
+
+	for name, bad := range map[string]Subscription{
+		"bad public key": {P256dh: "AAAA", Auth: rfcAuthSecret},
+		"short auth":     {P256dh: rfcUAPublic, Auth: "AAAA"},
+	} {
+		if _, err := Encrypt(bad, []byte("x")); err != ErrInvalidSubscription {
+			t.Errorf("%s: err = %v, want ErrInvalidSubscription", name, err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ErrInvalidVAPIDKey este returnată pentru o cheie VAPID care nu este validă
+var ErrInvalidVAPIDKey = errors.New("webpush: cheie VAPID invalidă")
+
+// vapidTokenLifetime este valabilitatea token-ului VAPID (maximum 24 de ore, conform RFC 8292)
+const vapidTokenLifetime = 12 * time.Hour
+
+// VAPID identifică serverul aplicației față de serviciile push (RFC 8292)
+type VAPID struct {
+	PrivateKey *ecdsa.PrivateKey
+	Subject    string // mailto: sau https:, pentru contact
+}
+
+// NewVAPID încarcă cheia privată VAPID (scalarul P-256, base64url)
+func NewVAPID(privateKey, subject string) (*VAPID, error) {
+	d, err := decodeKey(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, ErrInvalidVAPIDKey
+	}
+
+	// Validează scalarul și calculează cheia publică
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, ErrInvalidVAPIDKey
+	}
+	public := key.PublicKey().Bytes()
+
+	return &VAPID{
+		PrivateKey: &ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{
+				Curve: elliptic.P256(),
+				X:     new(big.Int).SetBytes(public[1:33]),
+				Y:     new(big.Int).SetBytes(public[33:]),
+			},
+			D: new(big.Int).SetBytes(d),
+		},
+		Subject: subject,
+	}, nil
+}
+
+// GenerateVAPIDKeys generează o pereche nouă de chei VAPID (base64url)
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// PublicKey returnează cheia publică necomprimată (base64url), folosită de browser ca applicationServerKey
+func (v *VAPID) PublicKey() string {
+	key, err := v.PrivateKey.ECDH()
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes())
+}
+
+// Authorization construiește antetul Authorization pentru endpoint-ul abonamentului
+func (v *VAPID) Authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", ErrInvalidSubscription
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenLifetime).Unix(),
+		"sub": v.Subject,
+	})
+
+	signed, err := token.SignedString(v.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + signed + ", k=" + v.PublicKey(), nil
+}